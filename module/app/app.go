@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/althea-net/peggy/module/x/peggy"
+	peggyclient "github.com/althea-net/peggy/module/x/peggy/client"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
@@ -55,7 +56,7 @@ var (
 		staking.AppModuleBasic{},
 		mint.AppModuleBasic{},
 		distr.AppModuleBasic{},
-		gov.NewAppModuleBasic(paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler),
+		gov.NewAppModuleBasic(paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler, peggyclient.ProposalHandler, peggyclient.TransferVetoProposalHandler, peggyclient.BlocklistProposalHandler),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
 		slashing.AppModuleBasic{},
@@ -79,6 +80,7 @@ var (
 		staking.BondedPoolName:    {supply.Burner, supply.Staking},
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		gov.ModuleName:            {supply.Burner},
+		peggy.ModuleName:          {supply.Minter, supply.Burner},
 	}
 )
 
@@ -262,12 +264,17 @@ func NewInitApp(
 
 	app.evidenceKeeper = *evidenceKeeper
 
+	// TODO: Add your module(s) keepers
+	// the peggy keeper is created before the gov router that routes its proposals
+	app.peggyKeeper = peggy.NewKeeper(app.cdc, keys[peggy.StoreKey], app.subspaces[peggy.ModuleName], &stakingKeeper, app.supplyKeeper)
+
 	// register the proposal types
 	govRouter := gov.NewRouter()
 	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
 		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
 		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper)).
+		AddRoute(peggy.RouterKey, peggy.NewProposalHandler(app.peggyKeeper))
 	app.govKeeper = gov.NewKeeper(
 		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName],
 		app.supplyKeeper, &stakingKeeper, govRouter,
@@ -282,8 +289,9 @@ func NewInitApp(
 		),
 	)
 
-	// TODO: Add your module(s) keepers
-	app.peggyKeeper = peggy.NewKeeper(app.cdc, keys[peggy.StoreKey], &stakingKeeper)
+	app.upgradeKeeper.SetUpgradeHandler(peggy.UpgradeName, func(ctx sdk.Context, _ upgrade.Plan) {
+		app.peggyKeeper.Migrate(ctx)
+	})
 
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
@@ -308,7 +316,7 @@ func NewInitApp(
 	// CanWithdrawInvariant invariant.
 
 	app.mm.SetOrderBeginBlockers(upgrade.ModuleName, mint.ModuleName, distr.ModuleName, slashing.ModuleName)
-	app.mm.SetOrderEndBlockers(crisis.ModuleName, gov.ModuleName, staking.ModuleName)
+	app.mm.SetOrderEndBlockers(crisis.ModuleName, gov.ModuleName, staking.ModuleName, peggy.ModuleName)
 
 	// Sets the order of Genesis - Order matters, genutil is to always come last
 	// NOTE: The genutils module must occur after staking so that pools are
@@ -25,6 +25,7 @@ import (
 	"github.com/tendermint/tendermint/libs/cli"
 
 	"github.com/althea-net/peggy/module/app"
+	peggycli "github.com/althea-net/peggy/module/x/peggy/client/cli"
 )
 
 func main() {
@@ -62,10 +63,13 @@ func main() {
 		client.ConfigCmd(app.DefaultCLIHome),
 		queryCmd(cdc),
 		txCmd(cdc),
+		orchestratorCmd(cdc),
 		flags.LineBreak,
 		lcd.ServeCommand(cdc, registerRoutes),
 		flags.LineBreak,
 		keys.Commands(),
+		peggycli.GetEthKeysCmd(),
+		peggycli.GetEthSignerCmd(),
 		flags.LineBreak,
 		version.Cmd,
 		flags.NewCompletionCmd(rootCmd, true),
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/althea-net/peggy/module/contracts/peggy"
+	"github.com/althea-net/peggy/module/ethwatcher"
+	"github.com/althea-net/peggy/module/orchestrator"
+	peggycli "github.com/althea-net/peggy/module/x/peggy/client/cli"
+	peggytypes "github.com/althea-net/peggy/module/x/peggy/types"
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	flagEthRPC           = "eth-rpc"
+	flagPeggyContract    = "peggy-contract"
+	flagEthKey           = "eth-key"
+	flagConfirmations    = "confirmations"
+	flagStartBlock       = "start-block"
+	flagCursorFile       = "cursor-file"
+	flagDepositDenom     = "deposit-denom"
+	flagPollInterval     = "poll-interval"
+	flagEthBlockInterval = "eth-block-interval"
+	flagERC20            = "erc20"
+)
+
+func orchestratorCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orchestrator",
+		Short: "run the orchestrator that signs valsets and batches and attests to ethereum events for the --from validator",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := clientcontext.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+
+			signer, err := peggycli.LoadSigner(cmd, viper.GetString(flagEthKey), txBldr.Keybase(), inBuf)
+			if err != nil {
+				return fmt.Errorf("eth key: %w", err)
+			}
+			if !common.IsHexAddress(viper.GetString(flagPeggyContract)) {
+				return fmt.Errorf("invalid peggy contract address %q", viper.GetString(flagPeggyContract))
+			}
+			contract := common.HexToAddress(viper.GetString(flagPeggyContract))
+			ethClient, err := ethclient.Dial(viper.GetString(flagEthRPC))
+			if err != nil {
+				return fmt.Errorf("connect to ethereum: %w", err)
+			}
+			defer ethClient.Close()
+			caller, err := peggy.NewPeggyCaller(contract, ethClient)
+			if err != nil {
+				return err
+			}
+
+			watcherCfg := ethwatcher.DefaultConfig(contract)
+			watcherCfg.Confirmations = viper.GetUint64(flagConfirmations)
+			watcherCfg.StartBlock = viper.GetUint64(flagStartBlock)
+			cursorFile := viper.GetString(flagCursorFile)
+			if cursorFile == "" {
+				cursorFile = filepath.Join(viper.GetString(cli.HomeFlag), "orchestrator-cursor.json")
+			}
+
+			erc20Contracts := make(map[string]common.Address)
+			for _, entry := range viper.GetStringSlice(flagERC20) {
+				parts := strings.SplitN(entry, "=", 2)
+				if len(parts) != 2 || !common.IsHexAddress(parts[1]) {
+					return fmt.Errorf("invalid erc20 %q, expected <denom>=<token contract>", entry)
+				}
+				erc20Contracts[parts[0]] = common.HexToAddress(parts[1])
+			}
+
+			cfg := orchestrator.Config{
+				StoreKey:         peggytypes.StoreKey,
+				Validator:        cliCtx.GetFromAddress(),
+				DepositDenom:     viper.GetString(flagDepositDenom),
+				PollInterval:     viper.GetDuration(flagPollInterval),
+				EthBlockInterval: viper.GetUint64(flagEthBlockInterval),
+				ERC20Contracts:   erc20Contracts,
+			}
+			broadcaster := orchestrator.NewCLIBroadcaster(cliCtx, txBldr, orchestrator.DefaultBackoff(), logger)
+			o, err := orchestrator.New(cfg, cdc, cliCtx, broadcaster, signer, ethClient, orchestrator.PeggyContractState{Contract: contract, Caller: caller, Backend: ethClient},
+				watcherCfg, ethwatcher.FileCursorStore{Path: cursorFile}, logger)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+			go func() {
+				<-sigs
+				cancel()
+			}()
+			err = o.Run(ctx)
+			if errors.Is(err, ethwatcher.ErrReorgTooDeep) {
+				return fmt.Errorf("%w, remove %s and restart with --%s set to a block before the reorg", err, cursorFile, flagStartBlock)
+			}
+			if err != nil && err != context.Canceled {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(flagEthRPC, "http://localhost:8545", "ethereum JSON-RPC endpoint")
+	cmd.Flags().String(flagPeggyContract, "", "address of the peggy contract")
+	cmd.Flags().String(flagEthKey, "", "eth key of the validator, keystore:<file>, keyring:<name> or remote:<host:port>")
+	cmd.Flags().Uint64(flagConfirmations, 50, "number of blocks an ethereum event has to be buried under before it is attested")
+	cmd.Flags().Uint64(flagStartBlock, 0, "first ethereum block to scan when there is no cursor yet")
+	cmd.Flags().String(flagCursorFile, "", "file the ethereum scan progress is kept in, defaults to orchestrator-cursor.json in the home dir")
+	cmd.Flags().String(flagDepositDenom, "peggy", "denom deposits are attested with when the token of the contract is not the erc20 of a native denom")
+	cmd.Flags().Duration(flagPollInterval, 15*time.Second, "time between two rounds")
+	cmd.Flags().Uint64(flagEthBlockInterval, 10, "number of ethereum blocks between two attestations of the final block and the gas price, 0 disables them")
+	cmd.Flags().StringSlice(flagERC20, nil, "erc20 deployed for a native denom to attest, as <denom>=<token contract>, repeatable")
+	peggycli.AddSignerFlags(cmd)
+	for _, name := range []string{flagEthRPC, flagPeggyContract, flagEthKey, flagConfirmations, flagStartBlock, flagCursorFile, flagDepositDenom, flagPollInterval, flagEthBlockInterval, flagERC20} {
+		viper.BindPFlag(name, cmd.Flags().Lookup(name))
+	}
+	return flags.PostCommands(cmd)[0]
+}
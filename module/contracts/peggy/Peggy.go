@@ -0,0 +1,746 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package peggy
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// PeggyABI is the input ABI used to generate the binding from.
+const PeggyABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_tokenContract\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"_peggyId\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_powerThreshold\",\"type\":\"uint256\"},{\"internalType\":\"address[]\",\"name\":\"_validators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_powers\",\"type\":\"uint256[]\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_destination\",\"type\":\"bytes32\",\"indexed\":false},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\",\"indexed\":false}],\"name\":\"TransferOutEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_validators\",\"type\":\"address[]\",\"indexed\":false},{\"internalType\":\"uint256[]\",\"name\":\"_powers\",\"type\":\"uint256[]\",\"indexed\":false}],\"name\":\"ValsetUpdatedEvent\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_currentValidators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_currentPowers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint8[]\",\"name\":\"_v\",\"type\":\"uint8[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_r\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_s\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32\",\"name\":\"_theHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_powerThreshold\",\"type\":\"uint256\"}],\"name\":\"checkValidatorSignatures\",\"outputs\":[],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_validators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_powers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"_valsetNonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_peggyId\",\"type\":\"bytes32\"}],\"name\":\"makeCheckpoint\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"state_lastCheckpoint\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"state_lastTxNonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"state_peggyId\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"state_powerThreshold\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"state_tokenContract\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_currentValidators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_currentPowers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"_currentValsetNonce\",\"type\":\"uint256\"},{\"internalType\":\"uint8[]\",\"name\":\"_v\",\"type\":\"uint8[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_r\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_s\",\"type\":\"bytes32[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_amounts\",\"type\":\"uint256[]\"},{\"internalType\":\"address[]\",\"name\":\"_destinations\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_fees\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_nonces\",\"type\":\"uint256[]\"}],\"name\":\"submitBatch\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_currentValidators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_currentPowers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint8[]\",\"name\":\"_v\",\"type\":\"uint8[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_r\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_s\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32\",\"name\":\"_theHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_powerThreshold\",\"type\":\"uint256\"}],\"name\":\"testCheckValidatorSignatures\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_validators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_powers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"_valsetNonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_peggyId\",\"type\":\"bytes32\"}],\"name\":\"testMakeCheckpoint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_destination\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"}],\"name\":\"transferOut\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_newValidators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_newPowers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"_newValsetNonce\",\"type\":\"uint256\"},{\"internalType\":\"address[]\",\"name\":\"_currentValidators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_currentPowers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"_currentValsetNonce\",\"type\":\"uint256\"},{\"internalType\":\"uint8[]\",\"name\":\"_v\",\"type\":\"uint8[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_r\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_s\",\"type\":\"bytes32[]\"}],\"name\":\"updateValset\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_currentValidators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_currentPowers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"_currentValsetNonce\",\"type\":\"uint256\"},{\"internalType\":\"uint8[]\",\"name\":\"_v\",\"type\":\"uint8[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_r\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_s\",\"type\":\"bytes32[]\"},{\"internalType\":\"address[]\",\"name\":\"_newValidators\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_newPowers\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"_newValsetNonce\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"_amounts\",\"type\":\"uint256[]\"},{\"internalType\":\"address[]\",\"name\":\"_destinations\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_fees\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_nonces\",\"type\":\"uint256[]\"}],\"name\":\"updateValsetAndSubmitBatch\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+// Peggy is an auto generated Go binding around an Ethereum contract.
+type Peggy struct {
+	PeggyCaller     // Read-only binding to the contract
+	PeggyTransactor // Write-only binding to the contract
+	PeggyFilterer   // Log filterer for contract events
+}
+
+// PeggyCaller is an auto generated read-only Go binding around an Ethereum contract.
+type PeggyCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PeggyTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type PeggyTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PeggyFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type PeggyFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PeggySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type PeggySession struct {
+	Contract     *Peggy            // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// PeggyCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type PeggyCallerSession struct {
+	Contract *PeggyCaller  // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// PeggyTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type PeggyTransactorSession struct {
+	Contract     *PeggyTransactor  // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// PeggyRaw is an auto generated low-level Go binding around an Ethereum contract.
+type PeggyRaw struct {
+	Contract *Peggy // Generic contract binding to access the raw methods on
+}
+
+// PeggyCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type PeggyCallerRaw struct {
+	Contract *PeggyCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// PeggyTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type PeggyTransactorRaw struct {
+	Contract *PeggyTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewPeggy creates a new instance of Peggy, bound to a specific deployed contract.
+func NewPeggy(address common.Address, backend bind.ContractBackend) (*Peggy, error) {
+	contract, err := bindPeggy(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Peggy{PeggyCaller: PeggyCaller{contract: contract}, PeggyTransactor: PeggyTransactor{contract: contract}, PeggyFilterer: PeggyFilterer{contract: contract}}, nil
+}
+
+// NewPeggyCaller creates a new read-only instance of Peggy, bound to a specific deployed contract.
+func NewPeggyCaller(address common.Address, caller bind.ContractCaller) (*PeggyCaller, error) {
+	contract, err := bindPeggy(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PeggyCaller{contract: contract}, nil
+}
+
+// NewPeggyTransactor creates a new write-only instance of Peggy, bound to a specific deployed contract.
+func NewPeggyTransactor(address common.Address, transactor bind.ContractTransactor) (*PeggyTransactor, error) {
+	contract, err := bindPeggy(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PeggyTransactor{contract: contract}, nil
+}
+
+// NewPeggyFilterer creates a new log filterer instance of Peggy, bound to a specific deployed contract.
+func NewPeggyFilterer(address common.Address, filterer bind.ContractFilterer) (*PeggyFilterer, error) {
+	contract, err := bindPeggy(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &PeggyFilterer{contract: contract}, nil
+}
+
+// bindPeggy binds a generic wrapper to an already deployed contract.
+func bindPeggy(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(PeggyABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Peggy *PeggyRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Peggy.Contract.PeggyCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Peggy *PeggyRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Peggy.Contract.PeggyTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Peggy *PeggyRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Peggy.Contract.PeggyTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Peggy *PeggyCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Peggy.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Peggy *PeggyTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Peggy.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Peggy *PeggyTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Peggy.Contract.contract.Transact(opts, method, params...)
+}
+
+// CheckValidatorSignatures is a free data retrieval call binding the contract method 0xea10bb20.
+//
+// Solidity: function checkValidatorSignatures(address[] _currentValidators, uint256[] _currentPowers, uint8[] _v, bytes32[] _r, bytes32[] _s, bytes32 _theHash, uint256 _powerThreshold) pure returns()
+func (_Peggy *PeggyCaller) CheckValidatorSignatures(opts *bind.CallOpts, _currentValidators []common.Address, _currentPowers []*big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _theHash [32]byte, _powerThreshold *big.Int) error {
+	var ()
+	out := &[]interface{}{}
+	err := _Peggy.contract.Call(opts, out, "checkValidatorSignatures", _currentValidators, _currentPowers, _v, _r, _s, _theHash, _powerThreshold)
+	return err
+}
+
+// CheckValidatorSignatures is a free data retrieval call binding the contract method 0xea10bb20.
+//
+// Solidity: function checkValidatorSignatures(address[] _currentValidators, uint256[] _currentPowers, uint8[] _v, bytes32[] _r, bytes32[] _s, bytes32 _theHash, uint256 _powerThreshold) pure returns()
+func (_Peggy *PeggySession) CheckValidatorSignatures(_currentValidators []common.Address, _currentPowers []*big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _theHash [32]byte, _powerThreshold *big.Int) error {
+	return _Peggy.Contract.CheckValidatorSignatures(&_Peggy.CallOpts, _currentValidators, _currentPowers, _v, _r, _s, _theHash, _powerThreshold)
+}
+
+// CheckValidatorSignatures is a free data retrieval call binding the contract method 0xea10bb20.
+//
+// Solidity: function checkValidatorSignatures(address[] _currentValidators, uint256[] _currentPowers, uint8[] _v, bytes32[] _r, bytes32[] _s, bytes32 _theHash, uint256 _powerThreshold) pure returns()
+func (_Peggy *PeggyCallerSession) CheckValidatorSignatures(_currentValidators []common.Address, _currentPowers []*big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _theHash [32]byte, _powerThreshold *big.Int) error {
+	return _Peggy.Contract.CheckValidatorSignatures(&_Peggy.CallOpts, _currentValidators, _currentPowers, _v, _r, _s, _theHash, _powerThreshold)
+}
+
+// MakeCheckpoint is a free data retrieval call binding the contract method 0x71cbf381.
+//
+// Solidity: function makeCheckpoint(address[] _validators, uint256[] _powers, uint256 _valsetNonce, bytes32 _peggyId) pure returns(bytes32)
+func (_Peggy *PeggyCaller) MakeCheckpoint(opts *bind.CallOpts, _validators []common.Address, _powers []*big.Int, _valsetNonce *big.Int, _peggyId [32]byte) ([32]byte, error) {
+	var (
+		ret0 = new([32]byte)
+	)
+	out := ret0
+	err := _Peggy.contract.Call(opts, out, "makeCheckpoint", _validators, _powers, _valsetNonce, _peggyId)
+	return *ret0, err
+}
+
+// MakeCheckpoint is a free data retrieval call binding the contract method 0x71cbf381.
+//
+// Solidity: function makeCheckpoint(address[] _validators, uint256[] _powers, uint256 _valsetNonce, bytes32 _peggyId) pure returns(bytes32)
+func (_Peggy *PeggySession) MakeCheckpoint(_validators []common.Address, _powers []*big.Int, _valsetNonce *big.Int, _peggyId [32]byte) ([32]byte, error) {
+	return _Peggy.Contract.MakeCheckpoint(&_Peggy.CallOpts, _validators, _powers, _valsetNonce, _peggyId)
+}
+
+// MakeCheckpoint is a free data retrieval call binding the contract method 0x71cbf381.
+//
+// Solidity: function makeCheckpoint(address[] _validators, uint256[] _powers, uint256 _valsetNonce, bytes32 _peggyId) pure returns(bytes32)
+func (_Peggy *PeggyCallerSession) MakeCheckpoint(_validators []common.Address, _powers []*big.Int, _valsetNonce *big.Int, _peggyId [32]byte) ([32]byte, error) {
+	return _Peggy.Contract.MakeCheckpoint(&_Peggy.CallOpts, _validators, _powers, _valsetNonce, _peggyId)
+}
+
+// StateLastCheckpoint is a free data retrieval call binding the contract method 0x420f6569.
+//
+// Solidity: function state_lastCheckpoint() view returns(bytes32)
+func (_Peggy *PeggyCaller) StateLastCheckpoint(opts *bind.CallOpts) ([32]byte, error) {
+	var (
+		ret0 = new([32]byte)
+	)
+	out := ret0
+	err := _Peggy.contract.Call(opts, out, "state_lastCheckpoint")
+	return *ret0, err
+}
+
+// StateLastCheckpoint is a free data retrieval call binding the contract method 0x420f6569.
+//
+// Solidity: function state_lastCheckpoint() view returns(bytes32)
+func (_Peggy *PeggySession) StateLastCheckpoint() ([32]byte, error) {
+	return _Peggy.Contract.StateLastCheckpoint(&_Peggy.CallOpts)
+}
+
+// StateLastCheckpoint is a free data retrieval call binding the contract method 0x420f6569.
+//
+// Solidity: function state_lastCheckpoint() view returns(bytes32)
+func (_Peggy *PeggyCallerSession) StateLastCheckpoint() ([32]byte, error) {
+	return _Peggy.Contract.StateLastCheckpoint(&_Peggy.CallOpts)
+}
+
+// StateLastTxNonce is a free data retrieval call binding the contract method 0x015dde77.
+//
+// Solidity: function state_lastTxNonce() view returns(uint256)
+func (_Peggy *PeggyCaller) StateLastTxNonce(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _Peggy.contract.Call(opts, out, "state_lastTxNonce")
+	return *ret0, err
+}
+
+// StateLastTxNonce is a free data retrieval call binding the contract method 0x015dde77.
+//
+// Solidity: function state_lastTxNonce() view returns(uint256)
+func (_Peggy *PeggySession) StateLastTxNonce() (*big.Int, error) {
+	return _Peggy.Contract.StateLastTxNonce(&_Peggy.CallOpts)
+}
+
+// StateLastTxNonce is a free data retrieval call binding the contract method 0x015dde77.
+//
+// Solidity: function state_lastTxNonce() view returns(uint256)
+func (_Peggy *PeggyCallerSession) StateLastTxNonce() (*big.Int, error) {
+	return _Peggy.Contract.StateLastTxNonce(&_Peggy.CallOpts)
+}
+
+// StatePeggyId is a free data retrieval call binding the contract method 0x69dd3908.
+//
+// Solidity: function state_peggyId() view returns(bytes32)
+func (_Peggy *PeggyCaller) StatePeggyId(opts *bind.CallOpts) ([32]byte, error) {
+	var (
+		ret0 = new([32]byte)
+	)
+	out := ret0
+	err := _Peggy.contract.Call(opts, out, "state_peggyId")
+	return *ret0, err
+}
+
+// StatePeggyId is a free data retrieval call binding the contract method 0x69dd3908.
+//
+// Solidity: function state_peggyId() view returns(bytes32)
+func (_Peggy *PeggySession) StatePeggyId() ([32]byte, error) {
+	return _Peggy.Contract.StatePeggyId(&_Peggy.CallOpts)
+}
+
+// StatePeggyId is a free data retrieval call binding the contract method 0x69dd3908.
+//
+// Solidity: function state_peggyId() view returns(bytes32)
+func (_Peggy *PeggyCallerSession) StatePeggyId() ([32]byte, error) {
+	return _Peggy.Contract.StatePeggyId(&_Peggy.CallOpts)
+}
+
+// StatePowerThreshold is a free data retrieval call binding the contract method 0xe5a2b5d2.
+//
+// Solidity: function state_powerThreshold() view returns(uint256)
+func (_Peggy *PeggyCaller) StatePowerThreshold(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _Peggy.contract.Call(opts, out, "state_powerThreshold")
+	return *ret0, err
+}
+
+// StatePowerThreshold is a free data retrieval call binding the contract method 0xe5a2b5d2.
+//
+// Solidity: function state_powerThreshold() view returns(uint256)
+func (_Peggy *PeggySession) StatePowerThreshold() (*big.Int, error) {
+	return _Peggy.Contract.StatePowerThreshold(&_Peggy.CallOpts)
+}
+
+// StatePowerThreshold is a free data retrieval call binding the contract method 0xe5a2b5d2.
+//
+// Solidity: function state_powerThreshold() view returns(uint256)
+func (_Peggy *PeggyCallerSession) StatePowerThreshold() (*big.Int, error) {
+	return _Peggy.Contract.StatePowerThreshold(&_Peggy.CallOpts)
+}
+
+// StateTokenContract is a free data retrieval call binding the contract method 0xdccfb508.
+//
+// Solidity: function state_tokenContract() view returns(address)
+func (_Peggy *PeggyCaller) StateTokenContract(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _Peggy.contract.Call(opts, out, "state_tokenContract")
+	return *ret0, err
+}
+
+// StateTokenContract is a free data retrieval call binding the contract method 0xdccfb508.
+//
+// Solidity: function state_tokenContract() view returns(address)
+func (_Peggy *PeggySession) StateTokenContract() (common.Address, error) {
+	return _Peggy.Contract.StateTokenContract(&_Peggy.CallOpts)
+}
+
+// StateTokenContract is a free data retrieval call binding the contract method 0xdccfb508.
+//
+// Solidity: function state_tokenContract() view returns(address)
+func (_Peggy *PeggyCallerSession) StateTokenContract() (common.Address, error) {
+	return _Peggy.Contract.StateTokenContract(&_Peggy.CallOpts)
+}
+
+// SubmitBatch is a paid mutator transaction binding the contract method 0xed661f61.
+//
+// Solidity: function submitBatch(address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s, uint256[] _amounts, address[] _destinations, uint256[] _fees, uint256[] _nonces) returns()
+func (_Peggy *PeggyTransactor) SubmitBatch(opts *bind.TransactOpts, _currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _amounts []*big.Int, _destinations []common.Address, _fees []*big.Int, _nonces []*big.Int) (*types.Transaction, error) {
+	return _Peggy.contract.Transact(opts, "submitBatch", _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s, _amounts, _destinations, _fees, _nonces)
+}
+
+// SubmitBatch is a paid mutator transaction binding the contract method 0xed661f61.
+//
+// Solidity: function submitBatch(address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s, uint256[] _amounts, address[] _destinations, uint256[] _fees, uint256[] _nonces) returns()
+func (_Peggy *PeggySession) SubmitBatch(_currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _amounts []*big.Int, _destinations []common.Address, _fees []*big.Int, _nonces []*big.Int) (*types.Transaction, error) {
+	return _Peggy.Contract.SubmitBatch(&_Peggy.TransactOpts, _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s, _amounts, _destinations, _fees, _nonces)
+}
+
+// SubmitBatch is a paid mutator transaction binding the contract method 0xed661f61.
+//
+// Solidity: function submitBatch(address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s, uint256[] _amounts, address[] _destinations, uint256[] _fees, uint256[] _nonces) returns()
+func (_Peggy *PeggyTransactorSession) SubmitBatch(_currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _amounts []*big.Int, _destinations []common.Address, _fees []*big.Int, _nonces []*big.Int) (*types.Transaction, error) {
+	return _Peggy.Contract.SubmitBatch(&_Peggy.TransactOpts, _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s, _amounts, _destinations, _fees, _nonces)
+}
+
+// TestCheckValidatorSignatures is a paid mutator transaction binding the contract method 0xdb7c4e57.
+//
+// Solidity: function testCheckValidatorSignatures(address[] _currentValidators, uint256[] _currentPowers, uint8[] _v, bytes32[] _r, bytes32[] _s, bytes32 _theHash, uint256 _powerThreshold) returns()
+func (_Peggy *PeggyTransactor) TestCheckValidatorSignatures(opts *bind.TransactOpts, _currentValidators []common.Address, _currentPowers []*big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _theHash [32]byte, _powerThreshold *big.Int) (*types.Transaction, error) {
+	return _Peggy.contract.Transact(opts, "testCheckValidatorSignatures", _currentValidators, _currentPowers, _v, _r, _s, _theHash, _powerThreshold)
+}
+
+// TestCheckValidatorSignatures is a paid mutator transaction binding the contract method 0xdb7c4e57.
+//
+// Solidity: function testCheckValidatorSignatures(address[] _currentValidators, uint256[] _currentPowers, uint8[] _v, bytes32[] _r, bytes32[] _s, bytes32 _theHash, uint256 _powerThreshold) returns()
+func (_Peggy *PeggySession) TestCheckValidatorSignatures(_currentValidators []common.Address, _currentPowers []*big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _theHash [32]byte, _powerThreshold *big.Int) (*types.Transaction, error) {
+	return _Peggy.Contract.TestCheckValidatorSignatures(&_Peggy.TransactOpts, _currentValidators, _currentPowers, _v, _r, _s, _theHash, _powerThreshold)
+}
+
+// TestCheckValidatorSignatures is a paid mutator transaction binding the contract method 0xdb7c4e57.
+//
+// Solidity: function testCheckValidatorSignatures(address[] _currentValidators, uint256[] _currentPowers, uint8[] _v, bytes32[] _r, bytes32[] _s, bytes32 _theHash, uint256 _powerThreshold) returns()
+func (_Peggy *PeggyTransactorSession) TestCheckValidatorSignatures(_currentValidators []common.Address, _currentPowers []*big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _theHash [32]byte, _powerThreshold *big.Int) (*types.Transaction, error) {
+	return _Peggy.Contract.TestCheckValidatorSignatures(&_Peggy.TransactOpts, _currentValidators, _currentPowers, _v, _r, _s, _theHash, _powerThreshold)
+}
+
+// TestMakeCheckpoint is a paid mutator transaction binding the contract method 0xc227c30b.
+//
+// Solidity: function testMakeCheckpoint(address[] _validators, uint256[] _powers, uint256 _valsetNonce, bytes32 _peggyId) returns()
+func (_Peggy *PeggyTransactor) TestMakeCheckpoint(opts *bind.TransactOpts, _validators []common.Address, _powers []*big.Int, _valsetNonce *big.Int, _peggyId [32]byte) (*types.Transaction, error) {
+	return _Peggy.contract.Transact(opts, "testMakeCheckpoint", _validators, _powers, _valsetNonce, _peggyId)
+}
+
+// TestMakeCheckpoint is a paid mutator transaction binding the contract method 0xc227c30b.
+//
+// Solidity: function testMakeCheckpoint(address[] _validators, uint256[] _powers, uint256 _valsetNonce, bytes32 _peggyId) returns()
+func (_Peggy *PeggySession) TestMakeCheckpoint(_validators []common.Address, _powers []*big.Int, _valsetNonce *big.Int, _peggyId [32]byte) (*types.Transaction, error) {
+	return _Peggy.Contract.TestMakeCheckpoint(&_Peggy.TransactOpts, _validators, _powers, _valsetNonce, _peggyId)
+}
+
+// TestMakeCheckpoint is a paid mutator transaction binding the contract method 0xc227c30b.
+//
+// Solidity: function testMakeCheckpoint(address[] _validators, uint256[] _powers, uint256 _valsetNonce, bytes32 _peggyId) returns()
+func (_Peggy *PeggyTransactorSession) TestMakeCheckpoint(_validators []common.Address, _powers []*big.Int, _valsetNonce *big.Int, _peggyId [32]byte) (*types.Transaction, error) {
+	return _Peggy.Contract.TestMakeCheckpoint(&_Peggy.TransactOpts, _validators, _powers, _valsetNonce, _peggyId)
+}
+
+// TransferOut is a paid mutator transaction binding the contract method 0xbafd3680.
+//
+// Solidity: function transferOut(bytes32 _destination, uint256 _amount) returns()
+func (_Peggy *PeggyTransactor) TransferOut(opts *bind.TransactOpts, _destination [32]byte, _amount *big.Int) (*types.Transaction, error) {
+	return _Peggy.contract.Transact(opts, "transferOut", _destination, _amount)
+}
+
+// TransferOut is a paid mutator transaction binding the contract method 0xbafd3680.
+//
+// Solidity: function transferOut(bytes32 _destination, uint256 _amount) returns()
+func (_Peggy *PeggySession) TransferOut(_destination [32]byte, _amount *big.Int) (*types.Transaction, error) {
+	return _Peggy.Contract.TransferOut(&_Peggy.TransactOpts, _destination, _amount)
+}
+
+// TransferOut is a paid mutator transaction binding the contract method 0xbafd3680.
+//
+// Solidity: function transferOut(bytes32 _destination, uint256 _amount) returns()
+func (_Peggy *PeggyTransactorSession) TransferOut(_destination [32]byte, _amount *big.Int) (*types.Transaction, error) {
+	return _Peggy.Contract.TransferOut(&_Peggy.TransactOpts, _destination, _amount)
+}
+
+// UpdateValset is a paid mutator transaction binding the contract method 0xe3cb9f62.
+//
+// Solidity: function updateValset(address[] _newValidators, uint256[] _newPowers, uint256 _newValsetNonce, address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s) returns()
+func (_Peggy *PeggyTransactor) UpdateValset(opts *bind.TransactOpts, _newValidators []common.Address, _newPowers []*big.Int, _newValsetNonce *big.Int, _currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte) (*types.Transaction, error) {
+	return _Peggy.contract.Transact(opts, "updateValset", _newValidators, _newPowers, _newValsetNonce, _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s)
+}
+
+// UpdateValset is a paid mutator transaction binding the contract method 0xe3cb9f62.
+//
+// Solidity: function updateValset(address[] _newValidators, uint256[] _newPowers, uint256 _newValsetNonce, address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s) returns()
+func (_Peggy *PeggySession) UpdateValset(_newValidators []common.Address, _newPowers []*big.Int, _newValsetNonce *big.Int, _currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte) (*types.Transaction, error) {
+	return _Peggy.Contract.UpdateValset(&_Peggy.TransactOpts, _newValidators, _newPowers, _newValsetNonce, _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s)
+}
+
+// UpdateValset is a paid mutator transaction binding the contract method 0xe3cb9f62.
+//
+// Solidity: function updateValset(address[] _newValidators, uint256[] _newPowers, uint256 _newValsetNonce, address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s) returns()
+func (_Peggy *PeggyTransactorSession) UpdateValset(_newValidators []common.Address, _newPowers []*big.Int, _newValsetNonce *big.Int, _currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte) (*types.Transaction, error) {
+	return _Peggy.Contract.UpdateValset(&_Peggy.TransactOpts, _newValidators, _newPowers, _newValsetNonce, _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s)
+}
+
+// UpdateValsetAndSubmitBatch is a paid mutator transaction binding the contract method 0x5429c0de.
+//
+// Solidity: function updateValsetAndSubmitBatch(address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s, address[] _newValidators, uint256[] _newPowers, uint256 _newValsetNonce, uint256[] _amounts, address[] _destinations, uint256[] _fees, uint256[] _nonces) returns()
+func (_Peggy *PeggyTransactor) UpdateValsetAndSubmitBatch(opts *bind.TransactOpts, _currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _newValidators []common.Address, _newPowers []*big.Int, _newValsetNonce *big.Int, _amounts []*big.Int, _destinations []common.Address, _fees []*big.Int, _nonces []*big.Int) (*types.Transaction, error) {
+	return _Peggy.contract.Transact(opts, "updateValsetAndSubmitBatch", _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s, _newValidators, _newPowers, _newValsetNonce, _amounts, _destinations, _fees, _nonces)
+}
+
+// UpdateValsetAndSubmitBatch is a paid mutator transaction binding the contract method 0x5429c0de.
+//
+// Solidity: function updateValsetAndSubmitBatch(address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s, address[] _newValidators, uint256[] _newPowers, uint256 _newValsetNonce, uint256[] _amounts, address[] _destinations, uint256[] _fees, uint256[] _nonces) returns()
+func (_Peggy *PeggySession) UpdateValsetAndSubmitBatch(_currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _newValidators []common.Address, _newPowers []*big.Int, _newValsetNonce *big.Int, _amounts []*big.Int, _destinations []common.Address, _fees []*big.Int, _nonces []*big.Int) (*types.Transaction, error) {
+	return _Peggy.Contract.UpdateValsetAndSubmitBatch(&_Peggy.TransactOpts, _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s, _newValidators, _newPowers, _newValsetNonce, _amounts, _destinations, _fees, _nonces)
+}
+
+// UpdateValsetAndSubmitBatch is a paid mutator transaction binding the contract method 0x5429c0de.
+//
+// Solidity: function updateValsetAndSubmitBatch(address[] _currentValidators, uint256[] _currentPowers, uint256 _currentValsetNonce, uint8[] _v, bytes32[] _r, bytes32[] _s, address[] _newValidators, uint256[] _newPowers, uint256 _newValsetNonce, uint256[] _amounts, address[] _destinations, uint256[] _fees, uint256[] _nonces) returns()
+func (_Peggy *PeggyTransactorSession) UpdateValsetAndSubmitBatch(_currentValidators []common.Address, _currentPowers []*big.Int, _currentValsetNonce *big.Int, _v []uint8, _r [][32]byte, _s [][32]byte, _newValidators []common.Address, _newPowers []*big.Int, _newValsetNonce *big.Int, _amounts []*big.Int, _destinations []common.Address, _fees []*big.Int, _nonces []*big.Int) (*types.Transaction, error) {
+	return _Peggy.Contract.UpdateValsetAndSubmitBatch(&_Peggy.TransactOpts, _currentValidators, _currentPowers, _currentValsetNonce, _v, _r, _s, _newValidators, _newPowers, _newValsetNonce, _amounts, _destinations, _fees, _nonces)
+}
+
+// PeggyTransferOutEventIterator is returned from FilterTransferOutEvent and is used to iterate over the raw logs and unpacked data for TransferOutEvent events raised by the Peggy contract.
+type PeggyTransferOutEventIterator struct {
+	Event *PeggyTransferOutEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PeggyTransferOutEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PeggyTransferOutEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PeggyTransferOutEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PeggyTransferOutEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PeggyTransferOutEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PeggyTransferOutEvent represents a TransferOutEvent event raised by the Peggy contract.
+type PeggyTransferOutEvent struct {
+	Destination [32]byte
+	Amount      *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransferOutEvent is a free log retrieval operation binding the contract event 0x7e9f78fa56b8594872fe87bd90b9ba96d2ac2d90a65772142488374315ba2c41.
+//
+// Solidity: event TransferOutEvent(bytes32 _destination, uint256 _amount)
+func (_Peggy *PeggyFilterer) FilterTransferOutEvent(opts *bind.FilterOpts) (*PeggyTransferOutEventIterator, error) {
+
+	logs, sub, err := _Peggy.contract.FilterLogs(opts, "TransferOutEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &PeggyTransferOutEventIterator{contract: _Peggy.contract, event: "TransferOutEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchTransferOutEvent is a free log subscription operation binding the contract event 0x7e9f78fa56b8594872fe87bd90b9ba96d2ac2d90a65772142488374315ba2c41.
+//
+// Solidity: event TransferOutEvent(bytes32 _destination, uint256 _amount)
+func (_Peggy *PeggyFilterer) WatchTransferOutEvent(opts *bind.WatchOpts, sink chan<- *PeggyTransferOutEvent) (event.Subscription, error) {
+
+	logs, sub, err := _Peggy.contract.WatchLogs(opts, "TransferOutEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PeggyTransferOutEvent)
+				if err := _Peggy.contract.UnpackLog(event, "TransferOutEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransferOutEvent is a log parse operation binding the contract event 0x7e9f78fa56b8594872fe87bd90b9ba96d2ac2d90a65772142488374315ba2c41.
+//
+// Solidity: event TransferOutEvent(bytes32 _destination, uint256 _amount)
+func (_Peggy *PeggyFilterer) ParseTransferOutEvent(log types.Log) (*PeggyTransferOutEvent, error) {
+	event := new(PeggyTransferOutEvent)
+	if err := _Peggy.contract.UnpackLog(event, "TransferOutEvent", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// PeggyValsetUpdatedEventIterator is returned from FilterValsetUpdatedEvent and is used to iterate over the raw logs and unpacked data for ValsetUpdatedEvent events raised by the Peggy contract.
+type PeggyValsetUpdatedEventIterator struct {
+	Event *PeggyValsetUpdatedEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PeggyValsetUpdatedEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PeggyValsetUpdatedEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PeggyValsetUpdatedEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PeggyValsetUpdatedEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PeggyValsetUpdatedEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PeggyValsetUpdatedEvent represents a ValsetUpdatedEvent event raised by the Peggy contract.
+type PeggyValsetUpdatedEvent struct {
+	Validators []common.Address
+	Powers     []*big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterValsetUpdatedEvent is a free log retrieval operation binding the contract event 0x6f40fa4457f9356252fbc7f83aea9262e328b5e4e4284fbdafec7517584c0558.
+//
+// Solidity: event ValsetUpdatedEvent(address[] _validators, uint256[] _powers)
+func (_Peggy *PeggyFilterer) FilterValsetUpdatedEvent(opts *bind.FilterOpts) (*PeggyValsetUpdatedEventIterator, error) {
+
+	logs, sub, err := _Peggy.contract.FilterLogs(opts, "ValsetUpdatedEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &PeggyValsetUpdatedEventIterator{contract: _Peggy.contract, event: "ValsetUpdatedEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchValsetUpdatedEvent is a free log subscription operation binding the contract event 0x6f40fa4457f9356252fbc7f83aea9262e328b5e4e4284fbdafec7517584c0558.
+//
+// Solidity: event ValsetUpdatedEvent(address[] _validators, uint256[] _powers)
+func (_Peggy *PeggyFilterer) WatchValsetUpdatedEvent(opts *bind.WatchOpts, sink chan<- *PeggyValsetUpdatedEvent) (event.Subscription, error) {
+
+	logs, sub, err := _Peggy.contract.WatchLogs(opts, "ValsetUpdatedEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PeggyValsetUpdatedEvent)
+				if err := _Peggy.contract.UnpackLog(event, "ValsetUpdatedEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValsetUpdatedEvent is a log parse operation binding the contract event 0x6f40fa4457f9356252fbc7f83aea9262e328b5e4e4284fbdafec7517584c0558.
+//
+// Solidity: event ValsetUpdatedEvent(address[] _validators, uint256[] _powers)
+func (_Peggy *PeggyFilterer) ParseValsetUpdatedEvent(log types.Log) (*PeggyValsetUpdatedEvent, error) {
+	event := new(PeggyValsetUpdatedEvent)
+	if err := _Peggy.contract.UnpackLog(event, "ValsetUpdatedEvent", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,7 @@
+// Package peggy contains the Go bindings for the Peggy Ethereum contract in solidity/contracts/Peggy.sol
+//
+// Peggy.abi has to be kept in sync with the contract by hand, the bindings are then regenerated with
+// abigen from the go-ethereum version in go.mod.
+package peggy
+
+//go:generate abigen --abi Peggy.abi --pkg peggy --type Peggy --out Peggy.go
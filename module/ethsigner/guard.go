@@ -0,0 +1,106 @@
+package ethsigner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/althea-net/peggy/module/x/peggy/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrDoubleSign is returned for a request to sign a different hash for a checkpoint that was
+// signed before
+var ErrDoubleSign = errors.New("refusing to sign a different hash for an already signed checkpoint")
+
+var _ utils.Signer = &GuardedSigner{}
+
+// GuardedSigner wraps a signer and refuses to sign two different hashes for the same checkpoint.
+// The signed hashes are kept in a json file when a path is given so that the protection survives
+// restarts, like the state file of a consensus key signer.
+type GuardedSigner struct {
+	signer utils.Signer
+	path   string
+
+	mu     sync.Mutex
+	signed map[string][]byte
+}
+
+// NewGuardedSigner loads the state file at path, an empty path keeps the state in memory only
+func NewGuardedSigner(signer utils.Signer, path string) (*GuardedSigner, error) {
+	g := &GuardedSigner{signer: signer, path: path, signed: make(map[string][]byte)}
+	if path == "" {
+		return g, nil
+	}
+	bz, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return g, nil
+	case err != nil:
+		return nil, err
+	}
+	if err := json.Unmarshal(bz, &g.signed); err != nil {
+		return nil, fmt.Errorf("sign state %s: %w", path, err)
+	}
+	return g, nil
+}
+
+func (g *GuardedSigner) Address() common.Address {
+	return g.signer.Address()
+}
+
+// SignCheckpoint records the hash before signing it, a request for the same checkpoint and hash is
+// signed again
+func (g *GuardedSigner) SignCheckpoint(req utils.SignRequest) ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	id := req.ID()
+	if prev, ok := g.signed[id]; ok {
+		if !bytes.Equal(prev, req.Hash) {
+			return nil, ErrDoubleSign
+		}
+	} else {
+		g.signed[id] = req.Hash
+		if err := g.save(); err != nil {
+			delete(g.signed, id)
+			return nil, err
+		}
+	}
+	return g.signer.SignCheckpoint(req)
+}
+
+// save writes the state to a temporary file first and moves it in place afterwards
+func (g *GuardedSigner) save() error {
+	if g.path == "" {
+		return nil
+	}
+	bz, err := json.Marshal(g.signed)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(g.path), filepath.Base(g.path))
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	// the state has to be on disk before the signature leaves the process
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), g.path)
+}
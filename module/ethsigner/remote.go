@@ -0,0 +1,213 @@
+// Package ethsigner runs the eth key of a validator in a separate process.
+//
+// The Server holds the key behind a GuardedSigner and answers sign requests over a TLS connection
+// that requires a client certificate, the RemoteSigner is the client side and implements
+// utils.Signer so that the CLI and the orchestrator can use it in place of a local key. The wire
+// protocol is net/rpc with the json codec.
+package ethsigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+
+	"github.com/althea-net/peggy/module/x/peggy/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const serviceName = "EthSigner"
+
+// TLSFiles are the PEM files for one side of the mutually authenticated connection. CA is the
+// certificate authority the certificate of the other side has to be signed by.
+type TLSFiles struct {
+	Cert string
+	Key  string
+	CA   string
+}
+
+func (f TLSFiles) load() (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(f.Cert, f.Key)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("load tls key pair: %w", err)
+	}
+	caPEM, err := ioutil.ReadFile(f.CA)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("load ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return tls.Certificate{}, nil, errors.New("no certificates in ca file")
+	}
+	return cert, pool, nil
+}
+
+// ServerConfig requires clients to present a certificate signed by the CA
+func (f TLSFiles) ServerConfig() (*tls.Config, error) {
+	cert, pool, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientConfig only accepts a server certificate signed by the CA
+func (f TLSFiles) ClientConfig() (*tls.Config, error) {
+	cert, pool, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// AddressResponse is the reply to an address request
+type AddressResponse struct {
+	Address common.Address `json:"address"`
+}
+
+// SignResponse is the reply to a sign request
+type SignResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+// service is the rpc receiver, its exported methods are the protocol
+type service struct {
+	signer utils.Signer
+	logger log.Logger
+}
+
+func (s *service) Address(_ struct{}, res *AddressResponse) error {
+	res.Address = s.signer.Address()
+	return nil
+}
+
+func (s *service) Sign(req utils.SignRequest, res *SignResponse) error {
+	if len(req.Hash) != 32 {
+		return errors.New("hash must be 32 bytes")
+	}
+	sig, err := s.signer.SignCheckpoint(req)
+	if err != nil {
+		s.logger.Error("refused to sign", "checkpoint", req.ID(), "err", err)
+		return err
+	}
+	s.logger.Info("signed", "checkpoint", req.ID())
+	res.Signature = sig
+	return nil
+}
+
+// Server answers sign requests of authenticated clients
+type Server struct {
+	rpc      *rpc.Server
+	tlsCfg   *tls.Config
+	logger   log.Logger
+	mu       sync.Mutex
+	listener net.Listener
+}
+
+// NewServer creates a server for the signer, use a GuardedSigner to protect against double signing
+func NewServer(signer utils.Signer, tlsCfg *tls.Config, logger log.Logger) (*Server, error) {
+	s := rpc.NewServer()
+	if err := s.RegisterName(serviceName, &service{signer: signer, logger: logger}); err != nil {
+		return nil, err
+	}
+	return &Server{rpc: s, tlsCfg: tlsCfg, logger: logger}, nil
+}
+
+// ListenAndServe serves on the given tcp address until Close is called
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := tls.Listen("tcp", addr, s.tlsCfg)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts connections on a listener that already does the TLS handshake until Close is called
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	s.listener = l
+	s.mu.Unlock()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		s.logger.Info("client connected", "remote", conn.RemoteAddr().String())
+		go s.rpc.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// Close stops accepting new connections
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+var _ utils.Signer = &RemoteSigner{}
+
+// RemoteSigner is the client of a Server
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// Dial connects to the server and fetches the address of its key
+func Dial(addr string, tlsCfg *tls.Config) (*RemoteSigner, error) {
+	conn, err := tls.Dial("tcp", addr, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	client := jsonrpc.NewClient(conn)
+	var res AddressResponse
+	if err := client.Call(serviceName+".Address", struct{}{}, &res); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &RemoteSigner{client: client, address: res.Address}, nil
+}
+
+func (r *RemoteSigner) Address() common.Address {
+	return r.address
+}
+
+// SignCheckpoint asks the server for a signature and checks that it is one of the server's key
+// over the requested hash
+func (r *RemoteSigner) SignCheckpoint(req utils.SignRequest) ([]byte, error) {
+	var res SignResponse
+	if err := r.client.Call(serviceName+".Sign", req, &res); err != nil {
+		return nil, err
+	}
+	pubKey, err := crypto.SigToPub(req.Hash, res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature from remote signer: %w", err)
+	}
+	if crypto.PubkeyToAddress(*pubKey) != r.address {
+		return nil, errors.New("remote signer signed with an unexpected key")
+	}
+	return res.Signature, nil
+}
+
+// Close closes the connection to the server
+func (r *RemoteSigner) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,147 @@
+package ethsigner
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/althea-net/peggy/module/x/peggy/utils"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// testPKI writes a CA and certificates for the server and the client signed by it
+type testPKI struct {
+	dir    string
+	caKey  *ecdsa.PrivateKey
+	caCert *x509.Certificate
+	caFile string
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	p := &testPKI{dir: t.TempDir()}
+	var err error
+	p.caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &p.caKey.PublicKey, p.caKey)
+	require.NoError(t, err)
+	p.caCert, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	p.caFile = p.writePEM(t, "ca.pem", "CERTIFICATE", der)
+	return p
+}
+
+func (p *testPKI) writePEM(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(p.dir, name)
+	require.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return path
+}
+
+// issue creates a key pair signed by the CA, or self signed when the CA is nil
+func (p *testPKI) issue(t *testing.T, name string, serial int64, ca *x509.Certificate, caKey *ecdsa.PrivateKey) TLSFiles {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if ca == nil {
+		ca, caKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return TLSFiles{
+		Cert: p.writePEM(t, name+".pem", "CERTIFICATE", der),
+		Key:  p.writePEM(t, name+"-key.pem", "EC PRIVATE KEY", keyDER),
+		CA:   p.caFile,
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	pki := newTestPKI(t)
+	serverFiles := pki.issue(t, "server", 2, pki.caCert, pki.caKey)
+	clientFiles := pki.issue(t, "client", 3, pki.caCert, pki.caKey)
+	strangerFiles := pki.issue(t, "stranger", 4, nil, nil)
+
+	ethKey, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	guarded, err := NewGuardedSigner(utils.LocalSigner{Key: ethKey}, statePath)
+	require.NoError(t, err)
+
+	serverCfg, err := serverFiles.ServerConfig()
+	require.NoError(t, err)
+	server, err := NewServer(guarded, serverCfg, log.NewNopLogger())
+	require.NoError(t, err)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	require.NoError(t, err)
+	go server.Serve(listener)
+	defer server.Close()
+	addr := listener.Addr().String()
+
+	clientCfg, err := clientFiles.ClientConfig()
+	require.NoError(t, err)
+	remote, err := Dial(addr, clientCfg)
+	require.NoError(t, err)
+	defer remote.Close()
+	assert.Equal(t, ethCrypto.PubkeyToAddress(ethKey.PublicKey), remote.Address())
+
+	hash := ethCrypto.Keccak256([]byte("checkpoint"))
+	req := utils.SignRequest{Kind: utils.CheckpointValset, Nonce: 1, Hash: hash}
+	sig, err := remote.SignCheckpoint(req)
+	require.NoError(t, err)
+	require.NoError(t, utils.ValidateEthSig(hash, sig, remote.Address()))
+
+	// the same checkpoint can be signed again, a different hash for it is refused
+	_, err = remote.SignCheckpoint(req)
+	require.NoError(t, err)
+	_, err = remote.SignCheckpoint(utils.SignRequest{Kind: utils.CheckpointValset, Nonce: 1, Hash: ethCrypto.Keccak256([]byte("other"))})
+	assert.Error(t, err)
+	// another kind with the same nonce is a different checkpoint
+	_, err = remote.SignCheckpoint(utils.SignRequest{Kind: utils.CheckpointBatch, Nonce: 1, Hash: ethCrypto.Keccak256([]byte("other"))})
+	require.NoError(t, err)
+
+	// the protection survives a restart
+	restarted, err := NewGuardedSigner(utils.LocalSigner{Key: ethKey}, statePath)
+	require.NoError(t, err)
+	_, err = restarted.SignCheckpoint(utils.SignRequest{Kind: utils.CheckpointValset, Nonce: 1, Hash: ethCrypto.Keccak256([]byte("other"))})
+	assert.Equal(t, ErrDoubleSign, err)
+
+	// clients without a certificate of the CA are rejected
+	strangerCfg, err := strangerFiles.ClientConfig()
+	require.NoError(t, err)
+	_, err = Dial(addr, strangerCfg)
+	assert.Error(t, err)
+	noCertCfg := clientCfg.Clone()
+	noCertCfg.Certificates = nil
+	_, err = Dial(addr, noCertCfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package ethwatcher
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockRef identifies an Ethereum block by number and hash
+type BlockRef struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// Cursor is the progress of a Watcher. All events up to and including the last entry of Processed
+// have been handed to the Handler. The entries before it are kept so that the watcher can find the
+// common ancestor after a reorg and re-scan from there.
+type Cursor struct {
+	Processed []BlockRef `json:"processed"`
+}
+
+// Head returns the last fully processed block, the bool is false for an empty cursor
+func (c Cursor) Head() (BlockRef, bool) {
+	if len(c.Processed) == 0 {
+		return BlockRef{}, false
+	}
+	return c.Processed[len(c.Processed)-1], true
+}
+
+// push appends a processed block and drops the oldest entries beyond the given history size
+func (c *Cursor) push(ref BlockRef, history int) {
+	c.Processed = append(c.Processed, ref)
+	if len(c.Processed) > history {
+		c.Processed = c.Processed[len(c.Processed)-history:]
+	}
+}
+
+// CursorStore persists the cursor of a Watcher between restarts
+type CursorStore interface {
+	// Load returns the stored cursor or nil if there is none yet
+	Load() (*Cursor, error)
+	Save(Cursor) error
+}
+
+var _ CursorStore = &FileCursorStore{}
+
+// FileCursorStore keeps the cursor as json in a single file
+type FileCursorStore struct {
+	Path string
+}
+
+func (s FileCursorStore) Load() (*Cursor, error) {
+	bz, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c Cursor
+	if err := json.Unmarshal(bz, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Save writes the cursor to a temporary file first and moves it in place afterwards so that a crash
+// never leaves a partially written cursor behind
+func (s FileCursorStore) Save(c Cursor) error {
+	bz, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path))
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+var _ CursorStore = &MemCursorStore{}
+
+// MemCursorStore keeps the cursor in memory only
+type MemCursorStore struct {
+	mu     sync.Mutex
+	cursor *Cursor
+}
+
+func (s *MemCursorStore) Load() (*Cursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cursor == nil {
+		return nil, nil
+	}
+	c := Cursor{Processed: append([]BlockRef{}, s.cursor.Processed...)}
+	return &c, nil
+}
+
+func (s *MemCursorStore) Save(c Cursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursor = &Cursor{Processed: append([]BlockRef{}, c.Processed...)}
+	return nil
+}
@@ -0,0 +1,283 @@
+// Package ethwatcher follows the events the Peggy contract emits on Ethereum.
+//
+// Orchestrators only attest to events that are at least EthBlockDelay blocks deep. The Watcher
+// polls an Ethereum JSON-RPC endpoint, waits for the configured number of confirmations, decodes
+// ValsetUpdatedEvent and TransferOutEvent with the contract bindings and hands them to a Handler.
+// Progress is persisted through a CursorStore so that a restarted watcher continues where it left
+// off. When a reorg replaces blocks that were already processed the watcher rewinds to the common
+// ancestor and re-scans from there, so handlers can see an event more than once and must be
+// idempotent.
+package ethwatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/althea-net/peggy/module/contracts/peggy"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// ErrReorgTooDeep is returned when a reorg replaced every block the cursor still remembers. Polling
+// again can not recover from it, Run stops and the cursor has to be reset to a block before the
+// reorg.
+var ErrReorgTooDeep = errors.New("reorg deeper than the cursor history")
+
+// ChainClient is the subset of the go-ethereum client API the watcher needs. Both ethclient.Client
+// and the simulated backend implement it.
+type ChainClient interface {
+	bind.ContractFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Handler receives the decoded contract events in the order they were emitted
+type Handler interface {
+	HandleValsetUpdated(ctx context.Context, ev *peggy.PeggyValsetUpdatedEvent) error
+	HandleTransferOut(ctx context.Context, ev *peggy.PeggyTransferOutEvent) error
+}
+
+// Config of a Watcher
+type Config struct {
+	// Contract is the address of the Peggy contract
+	Contract common.Address
+	// Confirmations is the number of blocks an event has to be buried under before it is handled,
+	// this is the EthBlockDelay of the design
+	Confirmations uint64
+	// StartBlock is the first block scanned when there is no cursor yet, usually the block the
+	// contract was deployed in
+	StartBlock uint64
+	// PollInterval is the time Run waits between two polls
+	PollInterval time.Duration
+	// MaxBlockRange limits the number of blocks requested in a single eth_getLogs call
+	MaxBlockRange uint64
+	// History is the number of processed blocks the cursor remembers to find the common ancestor
+	// after a reorg, it has to be larger than the deepest reorg that can reach past Confirmations
+	History int
+}
+
+// DefaultConfig returns a config with the defaults for everything but the contract address
+func DefaultConfig(contract common.Address) Config {
+	return Config{
+		Contract:      contract,
+		Confirmations: 50,
+		PollInterval:  15 * time.Second,
+		MaxBlockRange: 1000,
+		History:       128,
+	}
+}
+
+// Watcher polls an Ethereum node for Peggy contract events
+type Watcher struct {
+	client   ChainClient
+	cfg      Config
+	store    CursorStore
+	handler  Handler
+	filterer *peggy.PeggyFilterer
+	topics   []common.Hash
+	logger   log.Logger
+}
+
+// NewWatcher creates a watcher, call Run or Poll to start processing
+func NewWatcher(client ChainClient, cfg Config, store CursorStore, handler Handler, logger log.Logger) (*Watcher, error) {
+	if cfg.MaxBlockRange == 0 || cfg.History <= 0 {
+		return nil, errors.New("max block range and history must be positive")
+	}
+	filterer, err := peggy.NewPeggyFilterer(cfg.Contract, client)
+	if err != nil {
+		return nil, err
+	}
+	contractAbi, err := abi.JSON(strings.NewReader(peggy.PeggyABI))
+	if err != nil {
+		return nil, err
+	}
+	return &Watcher{
+		client:   client,
+		cfg:      cfg,
+		store:    store,
+		handler:  handler,
+		filterer: filterer,
+		topics: []common.Hash{
+			contractAbi.Events["ValsetUpdatedEvent"].ID,
+			contractAbi.Events["TransferOutEvent"].ID,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Run polls until the context is cancelled. Failed polls are logged and retried on the next tick,
+// except for ErrReorgTooDeep which is returned.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := w.Poll(ctx); errors.Is(err, ErrReorgTooDeep) {
+			return err
+		} else if err != nil {
+			w.logger.Error("polling ethereum events", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll handles all events in blocks that reached the confirmation depth since the last poll
+func (w *Watcher) Poll(ctx context.Context) error {
+	head, err := w.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("latest header: %w", err)
+	}
+	if head.Number.Uint64() < w.cfg.Confirmations {
+		return nil
+	}
+	safe := head.Number.Uint64() - w.cfg.Confirmations
+
+	cursor, err := w.store.Load()
+	if err != nil {
+		return fmt.Errorf("load cursor: %w", err)
+	}
+	if cursor == nil {
+		cursor = &Cursor{}
+	}
+
+	from := w.cfg.StartBlock
+	if _, ok := cursor.Head(); ok {
+		ancestor, err := w.rewind(ctx, cursor)
+		if err != nil {
+			return err
+		}
+		from = ancestor.Number + 1
+	}
+
+	for from <= safe {
+		to := from + w.cfg.MaxBlockRange - 1
+		if to > safe {
+			to = safe
+		}
+		// only the blocks close to the head can still be reorged, remember those
+		track := from
+		if safe >= uint64(w.cfg.History) && safe-uint64(w.cfg.History) >= track {
+			track = safe - uint64(w.cfg.History) + 1
+		}
+		refs, err := w.scan(ctx, from, to, track)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			cursor.push(ref, w.cfg.History)
+		}
+		if err := w.store.Save(*cursor); err != nil {
+			return fmt.Errorf("save cursor: %w", err)
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// rewind drops all blocks from the cursor that are no longer part of the canonical chain and
+// returns the last block that still is
+func (w *Watcher) rewind(ctx context.Context, cursor *Cursor) (BlockRef, error) {
+	for i := len(cursor.Processed) - 1; i >= 0; i-- {
+		ref := cursor.Processed[i]
+		header, err := w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(ref.Number))
+		if err != nil {
+			return BlockRef{}, fmt.Errorf("header %d: %w", ref.Number, err)
+		}
+		if header.Hash() == ref.Hash {
+			if i != len(cursor.Processed)-1 {
+				w.logger.Info("reorg detected, re-scanning", "from", ref.Number+1)
+				cursor.Processed = cursor.Processed[:i+1]
+			}
+			return ref, nil
+		}
+	}
+	return BlockRef{}, ErrReorgTooDeep
+}
+
+// scan handles all events between from and to inclusive. It returns references to the blocks from
+// track to `to`, the last one always included.
+func (w *Watcher) scan(ctx context.Context, from, to, track uint64) ([]BlockRef, error) {
+	toNumber := new(big.Int).SetUint64(to)
+	before, err := w.client.HeaderByNumber(ctx, toNumber)
+	if err != nil {
+		return nil, fmt.Errorf("header %d: %w", to, err)
+	}
+	logs, err := w.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   toNumber,
+		Addresses: []common.Address{w.cfg.Contract},
+		Topics:    [][]common.Hash{w.topics},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("filter logs %d-%d: %w", from, to, err)
+	}
+	var refs []BlockRef
+	for n := track; n < to; n++ {
+		header, err := w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, fmt.Errorf("header %d: %w", n, err)
+		}
+		refs = append(refs, BlockRef{Number: n, Hash: header.Hash()})
+	}
+	// the hash of `to` commits to all blocks before it, if it did not change while the logs were
+	// queried they all belong to the same chain
+	after, err := w.client.HeaderByNumber(ctx, toNumber)
+	if err != nil {
+		return nil, fmt.Errorf("header %d: %w", to, err)
+	}
+	if before.Hash() != after.Hash() {
+		return nil, fmt.Errorf("reorg while scanning %d-%d", from, to)
+	}
+	refs = append(refs, BlockRef{Number: to, Hash: after.Hash()})
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		if err := w.dispatch(ctx, l); err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+func (w *Watcher) dispatch(ctx context.Context, l types.Log) error {
+	if len(l.Topics) == 0 {
+		return nil
+	}
+	switch l.Topics[0] {
+	case w.topics[0]:
+		ev, err := w.filterer.ParseValsetUpdatedEvent(l)
+		if err != nil {
+			return fmt.Errorf("decode valset updated event in tx %s: %w", l.TxHash.Hex(), err)
+		}
+		// the generated parsers leave the raw log empty
+		ev.Raw = l
+		return w.handler.HandleValsetUpdated(ctx, ev)
+	case w.topics[1]:
+		ev, err := w.filterer.ParseTransferOutEvent(l)
+		if err != nil {
+			return fmt.Errorf("decode transfer out event in tx %s: %w", l.TxHash.Hex(), err)
+		}
+		ev.Raw = l
+		return w.handler.HandleTransferOut(ctx, ev)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package ethwatcher
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/althea-net/peggy/module/contracts/peggy"
+	"github.com/althea-net/peggy/module/internal/ethtest"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+type recordingHandler struct {
+	valsets   []*peggy.PeggyValsetUpdatedEvent
+	transfers []*peggy.PeggyTransferOutEvent
+}
+
+func (h *recordingHandler) HandleValsetUpdated(_ context.Context, ev *peggy.PeggyValsetUpdatedEvent) error {
+	h.valsets = append(h.valsets, ev)
+	return nil
+}
+
+func (h *recordingHandler) HandleTransferOut(_ context.Context, ev *peggy.PeggyTransferOutEvent) error {
+	h.transfers = append(h.transfers, ev)
+	return nil
+}
+
+func TestWatcherConfirmations(t *testing.T) {
+	chain := ethtest.NewSimulatedChain(t)
+	defer chain.Close()
+	cfg := DefaultConfig(chain.Contract)
+	cfg.Confirmations = 3
+	cfg.MaxBlockRange = 2
+	store := FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor.json")}
+	handler := &recordingHandler{}
+	w, err := NewWatcher(chain, cfg, store, handler, log.NewNopLogger())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	validators := []common.Address{common.HexToAddress("0xc783df8a850f42e7F7e57013759C285caa701eB6")}
+	powers := []*big.Int{big.NewInt(3333)}
+	var dest [32]byte
+	copy(dest[:], "cosmos destination")
+	chain.Emit(t, "ValsetUpdatedEvent", validators, powers)
+	chain.Emit(t, "TransferOutEvent", dest, big.NewInt(100))
+
+	// not deep enough yet
+	require.NoError(t, w.Poll(ctx))
+	assert.Empty(t, handler.valsets)
+	assert.Empty(t, handler.transfers)
+
+	chain.Mine(2)
+	require.NoError(t, w.Poll(ctx))
+	require.Len(t, handler.valsets, 1)
+	assert.Equal(t, validators, handler.valsets[0].Validators)
+	assert.Equal(t, powers, handler.valsets[0].Powers)
+	assert.Empty(t, handler.transfers)
+
+	chain.Mine(1)
+	require.NoError(t, w.Poll(ctx))
+	require.Len(t, handler.transfers, 1)
+	assert.Equal(t, dest, handler.transfers[0].Destination)
+	assert.Equal(t, big.NewInt(100), handler.transfers[0].Amount)
+
+	// a new watcher on the same store continues from the persisted cursor
+	chain.Mine(5)
+	restarted := &recordingHandler{}
+	w, err = NewWatcher(chain, cfg, store, restarted, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, w.Poll(ctx))
+	assert.Empty(t, restarted.valsets)
+	assert.Empty(t, restarted.transfers)
+	cursor, err := store.Load()
+	require.NoError(t, err)
+	head, ok := cursor.Head()
+	require.True(t, ok)
+	latest, err := chain.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, latest.Number.Uint64()-cfg.Confirmations, head.Number)
+}
+
+// forkableChain serves headers and logs from a chain that the test can replace to simulate a reorg
+type forkableChain struct {
+	*ethtest.SimulatedChain
+	headers map[uint64]*types.Header
+	logs    []types.Log
+}
+
+func (f *forkableChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = big.NewInt(int64(len(f.headers) - 1))
+	}
+	h, ok := f.headers[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return h, nil
+}
+
+func (f *forkableChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var res []types.Log
+	for _, l := range f.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() {
+			res = append(res, l)
+		}
+	}
+	return res, nil
+}
+
+// fork replaces all blocks from the given height on with new ones, tagged by extra
+func (f *forkableChain) fork(from uint64, height uint64, extra string) {
+	for n := from; n <= height; n++ {
+		f.headers[n] = &types.Header{Number: new(big.Int).SetUint64(n), Extra: []byte(extra)}
+	}
+	var kept []types.Log
+	for _, l := range f.logs {
+		if l.BlockNumber < from {
+			kept = append(kept, l)
+		}
+	}
+	f.logs = kept
+}
+
+func (f *forkableChain) transferLog(t *testing.T, block uint64, amount int64) {
+	var dest [32]byte
+	data, err := f.ABI.Events["TransferOutEvent"].Inputs.Pack(dest, big.NewInt(amount))
+	require.NoError(t, err)
+	f.logs = append(f.logs, types.Log{
+		Address:     f.Contract,
+		Topics:      []common.Hash{f.ABI.Events["TransferOutEvent"].ID},
+		Data:        data,
+		BlockNumber: block,
+		BlockHash:   f.headers[block].Hash(),
+	})
+}
+
+func TestWatcherReorg(t *testing.T) {
+	chain := &forkableChain{SimulatedChain: ethtest.NewSimulatedChain(t), headers: make(map[uint64]*types.Header)}
+	defer chain.Close()
+	chain.fork(0, 20, "a")
+	chain.transferLog(t, 10, 1)
+
+	cfg := DefaultConfig(chain.Contract)
+	cfg.Confirmations = 5
+	store := &MemCursorStore{}
+	handler := &recordingHandler{}
+	w, err := NewWatcher(chain, cfg, store, handler, log.NewNopLogger())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, w.Poll(ctx))
+	require.Len(t, handler.transfers, 1)
+
+	// blocks 13 onwards are replaced, block 15 was processed before and carries a new event now
+	chain.fork(13, 25, "b")
+	chain.transferLog(t, 15, 2)
+	require.NoError(t, w.Poll(ctx))
+	require.Len(t, handler.transfers, 2)
+	assert.Equal(t, big.NewInt(2), handler.transfers[1].Amount)
+
+	// a reorg below everything the cursor remembers can not be handled automatically
+	chain.fork(0, 30, "c")
+	assert.Equal(t, ErrReorgTooDeep, w.Poll(ctx))
+	assert.Equal(t, ErrReorgTooDeep, w.Run(ctx))
+}
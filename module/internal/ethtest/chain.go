@@ -0,0 +1,83 @@
+// Package ethtest runs a simulated Ethereum chain for the tests of the packages that follow the
+// Peggy contract.
+package ethtest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/althea-net/peggy/module/contracts/peggy"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// logEmitterCode deploys a minimal contract that emits a LOG1 for every call, the first 32 bytes of
+// the calldata are the topic and the rest is the log data. This lets the tests produce Peggy events
+// without the solidity toolchain.
+//
+//	runtime: CALLDATACOPY(0, 32, CALLDATASIZE-32) LOG1(0, CALLDATASIZE-32, CALLDATALOAD(0)) STOP
+var logEmitterCode = common.FromHex("0x6014600c60003960146000f3" + "60203603602060003760003560203603" + "6000a100")
+
+// SimulatedChain is a go-ethereum simulated backend with a log emitter deployed in place of the
+// Peggy contract
+type SimulatedChain struct {
+	*backends.SimulatedBackend
+	key      *ecdsa.PrivateKey
+	from     common.Address
+	Contract common.Address
+	// ABI of the Peggy contract, Emit encodes the events with it
+	ABI abi.ABI
+}
+
+// NewSimulatedChain starts a simulated chain and deploys the log emitter
+func NewSimulatedChain(t *testing.T) *SimulatedChain {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{from: {Balance: big.NewInt(1e18)}}, 10000000)
+	contractAbi, err := abi.JSON(strings.NewReader(peggy.PeggyABI))
+	require.NoError(t, err)
+	c := &SimulatedChain{SimulatedBackend: sim, key: key, from: from, ABI: contractAbi}
+
+	c.Send(t, nil, logEmitterCode)
+	c.Contract = crypto.CreateAddress(from, 0)
+	return c
+}
+
+// Send sends a transaction from the funded test account and mines it
+func (c *SimulatedChain) Send(t *testing.T, to *common.Address, data []byte) {
+	nonce, err := c.PendingNonceAt(context.Background(), c.from)
+	require.NoError(t, err)
+	var tx *types.Transaction
+	if to == nil {
+		tx = types.NewContractCreation(nonce, big.NewInt(0), 1000000, big.NewInt(1), data)
+	} else {
+		tx = types.NewTransaction(nonce, *to, big.NewInt(0), 1000000, big.NewInt(1), data)
+	}
+	signed, err := types.SignTx(tx, types.HomesteadSigner{}, c.key)
+	require.NoError(t, err)
+	require.NoError(t, c.SendTransaction(context.Background(), signed))
+	c.Commit()
+}
+
+// Emit makes the log emitter emit the Peggy contract event with the given arguments
+func (c *SimulatedChain) Emit(t *testing.T, event string, args ...interface{}) {
+	data, err := c.ABI.Events[event].Inputs.Pack(args...)
+	require.NoError(t, err)
+	c.Send(t, &c.Contract, append(c.ABI.Events[event].ID.Bytes(), data...))
+}
+
+// Mine mines n empty blocks
+func (c *SimulatedChain) Mine(n int) {
+	for i := 0; i < n; i++ {
+		c.Commit()
+	}
+}
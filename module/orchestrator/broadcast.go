@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// AccountFetcher returns the account number and the next sequence of the sending account
+type AccountFetcher func() (accountNumber uint64, sequence uint64, err error)
+
+// TxSender signs the messages with the given account number and sequence and broadcasts them in a
+// single transaction. It returns an error when the transaction was not accepted.
+type TxSender func(msgs []sdk.Msg, accountNumber uint64, sequence uint64) error
+
+// Backoff configures the retries of a Broadcaster
+type Backoff struct {
+	// Initial is the wait before the first retry, it doubles with every further retry
+	Initial time.Duration
+	// Max caps the wait between two attempts
+	Max time.Duration
+	// Attempts is the number of tries before Broadcast gives up
+	Attempts int
+}
+
+// DefaultBackoff retries for roughly a minute
+func DefaultBackoff() Backoff {
+	return Backoff{Initial: time.Second, Max: 16 * time.Second, Attempts: 7}
+}
+
+// Broadcaster sends transactions from a single account. It keeps track of the account sequence
+// locally so that consecutive transactions do not have to wait for a block, and re-reads it from
+// the chain whenever a transaction is rejected.
+type Broadcaster struct {
+	fetch   AccountFetcher
+	send    TxSender
+	backoff Backoff
+	logger  log.Logger
+
+	mu            sync.Mutex
+	synced        bool
+	accountNumber uint64
+	sequence      uint64
+}
+
+// NewBroadcaster creates a broadcaster, the account is fetched on the first Broadcast
+func NewBroadcaster(fetch AccountFetcher, send TxSender, backoff Backoff, logger log.Logger) *Broadcaster {
+	return &Broadcaster{fetch: fetch, send: send, backoff: backoff, logger: logger}
+}
+
+// Broadcast sends the messages in one transaction and retries with exponential backoff until it
+// is accepted, the attempts are exhausted or the context is cancelled
+func (b *Broadcaster) Broadcast(ctx context.Context, msgs ...sdk.Msg) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wait := b.backoff.Initial
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = b.try(msgs); err == nil {
+			return nil
+		}
+		if attempt >= b.backoff.Attempts {
+			return fmt.Errorf("broadcast failed after %d attempts: %w", attempt, err)
+		}
+		b.logger.Info("broadcast failed, retrying", "attempt", attempt, "wait", wait, "err", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if wait *= 2; wait > b.backoff.Max {
+			wait = b.backoff.Max
+		}
+	}
+}
+
+func (b *Broadcaster) try(msgs []sdk.Msg) error {
+	if !b.synced {
+		accountNumber, sequence, err := b.fetch()
+		if err != nil {
+			return fmt.Errorf("fetch account: %w", err)
+		}
+		b.accountNumber, b.sequence, b.synced = accountNumber, sequence, true
+	}
+	if err := b.send(msgs, b.accountNumber, b.sequence); err != nil {
+		// the sequence could be off after any failure, read it again before the next attempt
+		b.synced = false
+		return err
+	}
+	b.sequence++
+	return nil
+}
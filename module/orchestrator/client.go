@@ -0,0 +1,36 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// NewCLIBroadcaster creates a broadcaster that signs with the `--from` key of the CLI context and
+// broadcasts to its node in sync mode, so a transaction counts as sent once it passed CheckTx
+func NewCLIBroadcaster(cliCtx context.CLIContext, txBldr auth.TxBuilder, backoff Backoff, logger log.Logger) *Broadcaster {
+	fetch := func() (uint64, uint64, error) {
+		return auth.NewAccountRetriever(cliCtx).GetAccountNumberSequence(cliCtx.GetFromAddress())
+	}
+	send := func(msgs []sdk.Msg, accountNumber uint64, sequence uint64) error {
+		txBytes, err := txBldr.WithAccountNumber(accountNumber).WithSequence(sequence).
+			BuildAndSign(cliCtx.GetFromName(), keys.DefaultKeyPass, msgs)
+		if err != nil {
+			return err
+		}
+		res, err := cliCtx.BroadcastTxSync(txBytes)
+		if err != nil {
+			return err
+		}
+		if res.Code != 0 {
+			return fmt.Errorf("tx rejected, codespace %s code %d: %s", res.Codespace, res.Code, res.RawLog)
+		}
+		logger.Debug("tx broadcast", "hash", res.TxHash)
+		return nil
+	}
+	return NewBroadcaster(fetch, send, backoff, logger)
+}
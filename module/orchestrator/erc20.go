@@ -0,0 +1,118 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// erc20MetadataABI is the optional metadata part of the ERC20 interface
+const erc20MetadataABI = `[
+	{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"}
+]`
+
+var erc20ABI abi.ABI
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(erc20MetadataABI))
+	if err != nil {
+		panic(err)
+	}
+	erc20ABI = parsed
+}
+
+// ERC20Metadata reads the name, symbol and decimals of the token contract
+func (s PeggyContractState) ERC20Metadata(ctx context.Context, token common.Address, block *big.Int) (types.ERC20Metadata, error) {
+	contract := bind.NewBoundContract(token, erc20ABI, s.Backend, nil, nil)
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: block}
+	var metadata types.ERC20Metadata
+	if err := contract.Call(opts, &metadata.Name, "name"); err != nil {
+		return metadata, fmt.Errorf("name: %w", err)
+	}
+	if err := contract.Call(opts, &metadata.Symbol, "symbol"); err != nil {
+		return metadata, fmt.Errorf("symbol: %w", err)
+	}
+	var decimals uint8
+	if err := contract.Call(opts, &decimals, "decimals"); err != nil {
+		return metadata, fmt.Errorf("decimals: %w", err)
+	}
+	metadata.Decimals = uint32(decimals)
+	return metadata, nil
+}
+
+// AttestERC20Deployments attests to the ERC20s of the native denoms in the config that the chain
+// did not record yet. Only the ERC20 that is the token of the contract is attested, the others
+// could never be bridged and are logged. The metadata is read at the confirmation depth, an ERC20
+// is attested once per run of the orchestrator.
+func (o *Orchestrator) AttestERC20Deployments(ctx context.Context) error {
+	if len(o.cfg.ERC20Contracts) == 0 {
+		return nil
+	}
+	head, err := o.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("latest header: %w", err)
+	}
+	if head.Number.Uint64() <= o.watcherCfg.Confirmations {
+		return nil
+	}
+	final := new(big.Int).SetUint64(head.Number.Uint64() - o.watcherCfg.Confirmations)
+	bridged, err := o.bridgedToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	denoms := make([]string, 0, len(o.cfg.ERC20Contracts))
+	for denom := range o.cfg.ERC20Contracts {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	var msgs []sdk.Msg
+	var attested []string
+	for _, denom := range denoms {
+		if o.attestedERC20[denom] {
+			continue
+		}
+		var erc20 types.CosmosERC20
+		found, err := o.query(fmt.Sprintf("cosmosERC20/%s", denom), &erc20)
+		if err != nil {
+			return err
+		}
+		if found {
+			o.attestedERC20[denom] = true
+			continue
+		}
+		token := o.cfg.ERC20Contracts[denom]
+		if token != bridged {
+			o.logger.Error("erc20 is not the token of the contract", "denom", denom, "token", token.Hex(), "contract_token", bridged.Hex())
+			o.attestedERC20[denom] = true
+			continue
+		}
+		metadata, err := o.contract.ERC20Metadata(ctx, token, final)
+		if err != nil {
+			return fmt.Errorf("erc20 %s of %s: %w", token.Hex(), denom, err)
+		}
+		o.logger.Info("attesting erc20 deployment", "denom", denom, "token", token.Hex(), "metadata", metadata.String())
+		msgs = append(msgs, types.NewMsgERC20Deployed(denom, types.EthAddressFromCommon(token), metadata, o.cfg.Validator))
+		attested = append(attested, denom)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	if err := o.broadcaster.Broadcast(ctx, msgs...); err != nil {
+		return err
+	}
+	for _, denom := range attested {
+		o.attestedERC20[denom] = true
+	}
+	return nil
+}
@@ -0,0 +1,520 @@
+// Package orchestrator implements the validator side daemon of the bridge.
+//
+// An Orchestrator runs next to a validator. It signs every valset and batch the validator has not
+// confirmed yet through the utils.Signer of the validator's Ethereum key and submits the
+// MsgValsetConfirm and MsgConfirmBatch messages. It follows the Peggy contract with an ethwatcher.Watcher and attests
+// to deposits (MsgEthDeposit), to batches that were executed on Ethereum (MsgBatchInChain), to
+// the latest final Ethereum block (MsgEthBlockObserved) and to the ERC20s deployed for native
+// Cosmos denoms (MsgERC20Deployed).
+package orchestrator
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/althea-net/peggy/module/contracts/peggy"
+	"github.com/althea-net/peggy/module/ethwatcher"
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/althea-net/peggy/module/x/peggy/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// Querier runs custom queries against the chain, CLIContext implements it
+type Querier interface {
+	QueryWithData(path string, data []byte) ([]byte, int64, error)
+}
+
+// EthClient is the watcher's view of Ethereum plus the gas price the orchestrator attests
+type EthClient interface {
+	ethwatcher.ChainClient
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// ContractState reads the state of the Peggy contract and of token contracts at a given block
+type ContractState interface {
+	LastTxNonce(ctx context.Context, block *big.Int) (uint64, error)
+	// ExecutedBatches returns the last tx nonce of every batch submitted to the contract by a
+	// successful transaction in the block
+	ExecutedBatches(ctx context.Context, block *big.Int) ([]uint64, error)
+	// TokenContract returns the ERC20 the contract was deployed for, the only token it carries
+	TokenContract(ctx context.Context, block *big.Int) (common.Address, error)
+	ERC20Metadata(ctx context.Context, token common.Address, block *big.Int) (types.ERC20Metadata, error)
+}
+
+// ContractBackend is the part of the Ethereum client PeggyContractState reads from, ethclient.Client
+// implements it
+type ContractBackend interface {
+	bind.ContractCaller
+	BlockByNumber(ctx context.Context, number *big.Int) (*ethtypes.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
+}
+
+var _ ContractState = PeggyContractState{}
+
+var peggyABI abi.ABI
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(peggy.PeggyABI))
+	if err != nil {
+		panic(err)
+	}
+	peggyABI = parsed
+}
+
+// PeggyContractState reads the contract state with the generated bindings, token contracts are
+// called through the backend
+type PeggyContractState struct {
+	Contract common.Address
+	Caller   *peggy.PeggyCaller
+	Backend  ContractBackend
+}
+
+func (s PeggyContractState) LastTxNonce(ctx context.Context, block *big.Int) (uint64, error) {
+	nonce, err := s.Caller.StateLastTxNonce(&bind.CallOpts{Context: ctx, BlockNumber: block})
+	if err != nil {
+		return 0, err
+	}
+	return nonce.Uint64(), nil
+}
+
+func (s PeggyContractState) TokenContract(ctx context.Context, block *big.Int) (common.Address, error) {
+	return s.Caller.StateTokenContract(&bind.CallOpts{Context: ctx, BlockNumber: block})
+}
+
+// ExecutedBatches decodes the submitBatch and updateValsetAndSubmitBatch calls in the block. Only
+// transactions sent to the contract directly are seen, a batch relayed through another contract
+// is not.
+func (s PeggyContractState) ExecutedBatches(ctx context.Context, block *big.Int) ([]uint64, error) {
+	b, err := s.Backend.BlockByNumber(ctx, block)
+	if err != nil {
+		return nil, fmt.Errorf("block %s: %w", block, err)
+	}
+	var executed []uint64
+	for _, tx := range b.Transactions() {
+		if tx.To() == nil || *tx.To() != s.Contract || len(tx.Data()) < 4 {
+			continue
+		}
+		method, err := peggyABI.MethodById(tx.Data()[:4])
+		if err != nil || (method.Name != "submitBatch" && method.Name != "updateValsetAndSubmitBatch") {
+			continue
+		}
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			continue
+		}
+		nonces, ok := args["_nonces"].([]*big.Int)
+		if !ok || len(nonces) == 0 {
+			continue
+		}
+		receipt, err := s.Backend.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("receipt %s: %w", tx.Hash().Hex(), err)
+		}
+		if receipt.Status == ethtypes.ReceiptStatusSuccessful {
+			executed = append(executed, nonces[len(nonces)-1].Uint64())
+		}
+	}
+	return executed, nil
+}
+
+// Config of an Orchestrator
+type Config struct {
+	// StoreKey is the query route of the peggy module
+	StoreKey string
+	// Validator is the cosmos address the confirms and claims are sent from
+	Validator sdk.AccAddress
+	// DepositDenom is the denom the tokens locked in the contract are issued as on the cosmos side,
+	// unless the token of the contract is one of the ERC20Contracts
+	DepositDenom string
+	// PollInterval is the time between two rounds of signing and watching
+	PollInterval time.Duration
+	// EthBlockInterval is the number of Ethereum blocks the final block has to move past the last
+	// attested one before it is attested again together with the gas price, zero disables the
+	// attestations
+	EthBlockInterval uint64
+	// ERC20Contracts are the ERC20s deployed for native denoms by denom, they are attested until
+	// the chain recorded them. The contract carries a single token, only the ERC20 that is the
+	// token of the contract is attested and its deposits are issued as its denom.
+	ERC20Contracts map[string]common.Address
+}
+
+// Orchestrator signs pending valsets and batches and relays Ethereum events as claims
+type Orchestrator struct {
+	cfg         Config
+	cdc         *codec.Codec
+	querier     Querier
+	broadcaster *Broadcaster
+	signer      utils.Signer
+	ethClient   EthClient
+	watcher     *ethwatcher.Watcher
+	contract    ContractState
+	watcherCfg  ethwatcher.Config
+	logger      log.Logger
+
+	// resolvedBatches are the nonces of the batches that were claimed as executed or found
+	// superseded
+	resolvedBatches map[int64]bool
+	// lastEthBlock is the number of the Ethereum block attested last, zero before the first one
+	lastEthBlock uint64
+	// attestedERC20 are the denoms of ERC20Contracts that were attested, recorded or found not to
+	// be the token of the contract
+	attestedERC20 map[string]bool
+	// tokenContract is the token of the contract, nil until it was read
+	tokenContract *common.Address
+}
+
+// New creates an orchestrator. The watcher config determines the contract and the confirmation
+// depth that is used for deposits and for the executed batches.
+func New(cfg Config, cdc *codec.Codec, querier Querier, broadcaster *Broadcaster, signer utils.Signer,
+	ethClient EthClient, contract ContractState, watcherCfg ethwatcher.Config, store ethwatcher.CursorStore,
+	logger log.Logger) (*Orchestrator, error) {
+	o := &Orchestrator{
+		cfg:         cfg,
+		cdc:         cdc,
+		querier:     querier,
+		broadcaster: broadcaster,
+		signer:      signer,
+		ethClient:   ethClient,
+		contract:    contract,
+		watcherCfg:  watcherCfg,
+		logger:      logger,
+
+		resolvedBatches: make(map[int64]bool),
+		attestedERC20:   make(map[string]bool),
+	}
+	w, err := ethwatcher.NewWatcher(ethClient, watcherCfg, store, o, logger.With("module", "ethwatcher"))
+	if err != nil {
+		return nil, err
+	}
+	o.watcher = w
+	return o, nil
+}
+
+// Run processes rounds until the context is cancelled. A failing round is logged and retried on
+// the next tick, except for an ethwatcher.ErrReorgTooDeep which is returned.
+func (o *Orchestrator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(o.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := o.Step(ctx); errors.Is(err, ethwatcher.ErrReorgTooDeep) {
+			return err
+		} else if err != nil {
+			o.logger.Error("orchestrator round", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Step runs a single round: sign what is pending, handle new Ethereum events, claim executed
+// batches, attest to the final Ethereum block and to deployed ERC20s
+func (o *Orchestrator) Step(ctx context.Context) error {
+	if err := o.SignPending(ctx); err != nil {
+		return err
+	}
+	if err := o.watcher.Poll(ctx); err != nil {
+		return err
+	}
+	if err := o.ClaimExecutedBatches(ctx); err != nil {
+		return err
+	}
+	if err := o.AttestEthBlock(ctx); err != nil {
+		return err
+	}
+	return o.AttestERC20Deployments(ctx)
+}
+
+// SignPending confirms the latest valset request and the latest batch when the validator has not
+// done so yet
+func (o *Orchestrator) SignPending(ctx context.Context) error {
+	var msgs []sdk.Msg
+
+	var params types.Params
+	if _, err := o.query("params", &params); err != nil {
+		return err
+	}
+
+	var valset types.Valset
+	found, err := o.query(fmt.Sprintf("lastPendingValsetRequest/%s", o.cfg.Validator), &valset)
+	if err != nil {
+		return err
+	}
+	if found {
+		checkpoint, err := valset.GetCheckpoint(params.PeggyID)
+		if err != nil {
+			return err
+		}
+		sig, err := o.signer.SignCheckpoint(utils.SignRequest{
+			Kind:  utils.CheckpointValset,
+			Nonce: valset.Nonce,
+			Hash:  checkpoint,
+		})
+		if err != nil {
+			return err
+		}
+		o.logger.Info("signing valset", "nonce", valset.Nonce)
+		msgs = append(msgs, types.NewMsgValsetConfirm(valset.Nonce, o.cfg.Validator, hex.EncodeToString(sig)))
+	}
+
+	var batch types.OutgoingTxBatch
+	found, err = o.query(fmt.Sprintf("lastPendingBatchRequest/%s", o.cfg.Validator), &batch)
+	if err != nil {
+		return err
+	}
+	if found {
+		checkpoint, err := batch.GetCheckpoint(params.PeggyID)
+		if err != nil {
+			return err
+		}
+		sig, err := o.signer.SignCheckpoint(utils.SignRequest{
+			Kind:  utils.CheckpointBatch,
+			Nonce: batch.Nonce,
+			Hash:  checkpoint,
+		})
+		if err != nil {
+			return err
+		}
+		o.logger.Info("signing batch", "nonce", batch.Nonce)
+		msgs = append(msgs, types.NewMsgConfirmBatch(batch.Nonce, 0, o.cfg.Validator, hex.EncodeToString(sig)))
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+	return o.broadcaster.Broadcast(ctx, msgs...)
+}
+
+// ClaimExecutedBatches attests to the pending batches the contract executed at the confirmation
+// depth. The contract only keeps the highest tx nonce it executed, a batch whose last transfer is
+// above it was not executed yet. For any other batch the block that moved the nonce to or past its
+// last transfer is looked up. The batch was executed there if the nonce stopped exactly at its
+// last transfer or if the block contains a successful call submitting it. Otherwise a batch with
+// higher nonces was executed instead, this one can never be and is not claimed, the chain
+// invalidates it once the executed batch is observed.
+func (o *Orchestrator) ClaimExecutedBatches(ctx context.Context) error {
+	head, err := o.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("latest header: %w", err)
+	}
+	if head.Number.Uint64() < o.watcherCfg.Confirmations {
+		return nil
+	}
+	safe := head.Number.Uint64() - o.watcherCfg.Confirmations
+	lastTxNonce, err := o.contract.LastTxNonce(ctx, new(big.Int).SetUint64(safe))
+	if err != nil {
+		return fmt.Errorf("last tx nonce: %w", err)
+	}
+
+	var pending []types.OutgoingTxBatch
+	if _, err := o.query("pendingOutgoingTxBatches", &pending); err != nil {
+		return err
+	}
+	var msgs []sdk.Msg
+	var resolved []int64
+	for _, batch := range pending {
+		if o.resolvedBatches[batch.Nonce] || len(batch.Elements) == 0 {
+			continue
+		}
+		last := batch.Elements[len(batch.Elements)-1].ID
+		if last > lastTxNonce {
+			continue
+		}
+		executed, err := o.batchExecuted(ctx, last, safe)
+		if err != nil {
+			return err
+		}
+		resolved = append(resolved, batch.Nonce)
+		if !executed {
+			o.logger.Info("batch superseded on ethereum", "nonce", batch.Nonce)
+			continue
+		}
+		o.logger.Info("batch executed on ethereum", "nonce", batch.Nonce)
+		msgs = append(msgs, types.NewMsgBatchInChain(batch.Nonce, o.cfg.Validator))
+	}
+	if len(msgs) != 0 {
+		if err := o.broadcaster.Broadcast(ctx, msgs...); err != nil {
+			return err
+		}
+	}
+	for _, nonce := range resolved {
+		o.resolvedBatches[nonce] = true
+	}
+	return nil
+}
+
+// batchExecuted tells whether the batch ending with the tx nonce last was executed, the contract
+// reached that nonce at or before the block safe
+func (o *Orchestrator) batchExecuted(ctx context.Context, last uint64, safe uint64) (bool, error) {
+	reached := func(block uint64) (bool, error) {
+		nonce, err := o.contract.LastTxNonce(ctx, new(big.Int).SetUint64(block))
+		if err != nil {
+			return false, fmt.Errorf("last tx nonce at %d: %w", block, err)
+		}
+		return nonce >= last, nil
+	}
+	// the nonce only grows, search backwards in growing steps for a block before it was reached
+	// and then narrow down to the first block it was reached in
+	lo, hi := uint64(0), safe
+	for step := uint64(1); hi > 0; step *= 2 {
+		below := uint64(0)
+		if hi > step {
+			below = hi - step
+		}
+		ok, err := reached(below)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			lo = below
+			break
+		}
+		hi = below
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		ok, err := reached(mid)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	nonce, err := o.contract.LastTxNonce(ctx, new(big.Int).SetUint64(hi))
+	if err != nil {
+		return false, fmt.Errorf("last tx nonce at %d: %w", hi, err)
+	}
+	if nonce == last {
+		return true, nil
+	}
+	executed, err := o.contract.ExecutedBatches(ctx, new(big.Int).SetUint64(hi))
+	if err != nil {
+		return false, fmt.Errorf("executed batches at %d: %w", hi, err)
+	}
+	for _, nonce := range executed {
+		if nonce == last {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AttestEthBlock attests to the latest final Ethereum block, the one at the confirmation depth,
+// and to the current gas price once the final block moved EthBlockInterval blocks past the block
+// attested last
+func (o *Orchestrator) AttestEthBlock(ctx context.Context) error {
+	if o.cfg.EthBlockInterval == 0 {
+		return nil
+	}
+	head, err := o.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("latest header: %w", err)
+	}
+	if head.Number.Uint64() <= o.watcherCfg.Confirmations {
+		return nil
+	}
+	final := head.Number.Uint64() - o.watcherCfg.Confirmations
+	if o.lastEthBlock != 0 && final < o.lastEthBlock+o.cfg.EthBlockInterval {
+		return nil
+	}
+	header, err := o.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(final))
+	if err != nil {
+		return fmt.Errorf("header %d: %w", final, err)
+	}
+	gasPrice, err := o.ethClient.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("gas price: %w", err)
+	}
+	o.logger.Info("attesting final ethereum block", "number", final, "hash", header.Hash().Hex(), "gas_price", gasPrice.String())
+	if err := o.broadcaster.Broadcast(ctx,
+		types.NewMsgEthBlockObserved(final, header.Hash().Hex(), header.Time, o.cfg.Validator),
+		types.NewMsgGasPriceObserved(sdk.NewIntFromBigInt(gasPrice), o.cfg.Validator),
+	); err != nil {
+		return err
+	}
+	o.lastEthBlock = final
+	return nil
+}
+
+// HandleValsetUpdated implements ethwatcher.Handler. Valset updates need no attestation, the chain
+// already knows the valset that was signed.
+func (o *Orchestrator) HandleValsetUpdated(_ context.Context, ev *peggy.PeggyValsetUpdatedEvent) error {
+	o.logger.Info("valset updated on ethereum", "validators", len(ev.Validators), "tx", ev.Raw.TxHash.Hex())
+	return nil
+}
+
+// HandleTransferOut implements ethwatcher.Handler. The destination of the event is the cosmos
+// address left aligned in the bytes32. The event carries no token, the amount is of the token of
+// the contract and is attested as the denom that token is issued as, see depositDenom.
+func (o *Orchestrator) HandleTransferOut(ctx context.Context, ev *peggy.PeggyTransferOutEvent) error {
+	destination := sdk.AccAddress(ev.Destination[:sdk.AddrLen])
+	denom, err := o.depositDenom(ctx)
+	if err != nil {
+		return err
+	}
+	amount := sdk.NewCoin(denom, sdk.NewIntFromBigInt(ev.Amount))
+	o.logger.Info("deposit on ethereum", "destination", destination.String(), "amount", amount.String(), "tx", ev.Raw.TxHash.Hex())
+	return o.broadcaster.Broadcast(ctx, types.NewMsgEthDeposit(o.cfg.Validator, destination, amount, ev.Raw.TxHash.Hex(), uint64(ev.Raw.Index)))
+}
+
+// bridgedToken returns the token of the contract. It is set when the contract is deployed and
+// never changes, it is read once.
+func (o *Orchestrator) bridgedToken(ctx context.Context) (common.Address, error) {
+	if o.tokenContract != nil {
+		return *o.tokenContract, nil
+	}
+	token, err := o.contract.TokenContract(ctx, nil)
+	if err != nil {
+		return token, fmt.Errorf("token contract: %w", err)
+	}
+	o.tokenContract = &token
+	return token, nil
+}
+
+// depositDenom returns the native denom whose ERC20 is the token of the contract or DepositDenom
+// when the token originates on Ethereum
+func (o *Orchestrator) depositDenom(ctx context.Context) (string, error) {
+	token, err := o.bridgedToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	for denom, erc20 := range o.cfg.ERC20Contracts {
+		if erc20 == token {
+			return denom, nil
+		}
+	}
+	return o.cfg.DepositDenom, nil
+}
+
+// query runs the custom peggy query and decodes the result into dst. It returns false when the
+// query returned nothing.
+func (o *Orchestrator) query(route string, dst interface{}) (bool, error) {
+	res, _, err := o.querier.QueryWithData(fmt.Sprintf("custom/%s/%s", o.cfg.StoreKey, route), nil)
+	if err != nil {
+		return false, fmt.Errorf("query %s: %w", route, err)
+	}
+	if len(res) == 0 {
+		return false, nil
+	}
+	if err := o.cdc.UnmarshalJSON(res, dst); err != nil {
+		return false, fmt.Errorf("decode %s: %w", route, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,368 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	peggycontract "github.com/althea-net/peggy/module/contracts/peggy"
+	"github.com/althea-net/peggy/module/ethwatcher"
+	"github.com/althea-net/peggy/module/internal/ethtest"
+	"github.com/althea-net/peggy/module/x/peggy"
+	"github.com/althea-net/peggy/module/x/peggy/keeper"
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/althea-net/peggy/module/x/peggy/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// testChain runs the peggy handler and querier in process and accepts transactions with the right
+// sequence only, like the ante handler of a real chain
+type testChain struct {
+	keeper    keeper.Keeper
+	ctx       sdk.Context
+	handler   sdk.Handler
+	querier   sdk.Querier
+	sequence  uint64
+	delivered []sdk.Msg
+}
+
+func (c *testChain) QueryWithData(path string, _ []byte) ([]byte, int64, error) {
+	// custom/<store key>/<route...>
+	res, err := c.querier(c.ctx, strings.Split(path, "/")[2:], abci.RequestQuery{})
+	return res, c.ctx.BlockHeight(), err
+}
+
+func (c *testChain) fetch() (uint64, uint64, error) {
+	return 0, c.sequence, nil
+}
+
+func (c *testChain) send(msgs []sdk.Msg, _ uint64, sequence uint64) error {
+	if sequence != c.sequence {
+		return errors.New("invalid sequence")
+	}
+	cacheCtx, write := c.ctx.CacheContext()
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+		if _, err := c.handler(cacheCtx, msg); err != nil {
+			return err
+		}
+	}
+	write()
+	c.sequence++
+	c.delivered = append(c.delivered, msgs...)
+	return nil
+}
+
+func (c *testChain) take() []sdk.Msg {
+	msgs := c.delivered
+	c.delivered = nil
+	return msgs
+}
+
+// fakeContractState replays the executions, the tx nonce of a block is the one of the last execution
+// at or before it, and returns the token of the contract and the metadata of the tokens
+type fakeContractState struct {
+	executions []fakeExecution
+	token      common.Address
+	tokens     map[common.Address]types.ERC20Metadata
+}
+
+// fakeExecution are the batches, by their last tx nonce, executed in a block
+type fakeExecution struct {
+	block   uint64
+	batches []uint64
+}
+
+func (s *fakeContractState) LastTxNonce(_ context.Context, block *big.Int) (uint64, error) {
+	var nonce uint64
+	for _, e := range s.executions {
+		if e.block <= block.Uint64() {
+			nonce = e.batches[len(e.batches)-1]
+		}
+	}
+	return nonce, nil
+}
+
+func (s *fakeContractState) ExecutedBatches(_ context.Context, block *big.Int) ([]uint64, error) {
+	for _, e := range s.executions {
+		if e.block == block.Uint64() {
+			return e.batches, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeContractState) TokenContract(_ context.Context, _ *big.Int) (common.Address, error) {
+	return s.token, nil
+}
+
+func (s *fakeContractState) ERC20Metadata(_ context.Context, token common.Address, _ *big.Int) (types.ERC20Metadata, error) {
+	metadata, ok := s.tokens[token]
+	if !ok {
+		return metadata, errors.New("no contract code at given address")
+	}
+	return metadata, nil
+}
+
+func TestOrchestrator(t *testing.T) {
+	k, ctx := keeper.CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	validator := sdk.AccAddress(valAddr)
+	ethKey, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+	k.SetEthAddress(ctx, validator, types.EthAddressFromCommon(ethCrypto.PubkeyToAddress(ethKey.PublicKey)))
+	k.StakingKeeper = keeper.NewStakingKeeperMock(valAddr)
+
+	chain := &testChain{keeper: k, ctx: ctx.WithBlockHeight(100), handler: peggy.NewHandler(k), querier: keeper.NewQuerier(k)}
+	_, err = k.SetValsetRequest(chain.ctx)
+	require.NoError(t, err)
+	dest, err := types.NewEthAddress("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	require.NoError(t, err)
+	keeper.AddEscrowedTransfer(t, k, chain.ctx, validator, dest, sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 1))
+	keeper.AddEscrowedTransfer(t, k, chain.ctx, validator, dest, sdk.NewInt64Coin("mytoken", 200), sdk.NewInt64Coin("mytoken", 2))
+	_, err = k.BuildOutgoingTXBatch(chain.ctx, "mytoken")
+	require.NoError(t, err)
+
+	eth := ethtest.NewSimulatedChain(t)
+	defer eth.Close()
+	contract := &fakeContractState{}
+	watcherCfg := ethwatcher.DefaultConfig(eth.Contract)
+	watcherCfg.Confirmations = 2
+	broadcaster := NewBroadcaster(chain.fetch, chain.send, Backoff{Initial: time.Millisecond, Max: time.Millisecond, Attempts: 3}, log.NewNopLogger())
+	cfg := Config{StoreKey: types.StoreKey, Validator: validator, DepositDenom: "peggy", PollInterval: time.Second}
+	o, err := New(cfg, types.ModuleCdc, chain, broadcaster, utils.LocalSigner{Key: ethKey}, eth, contract, watcherCfg, &ethwatcher.MemCursorStore{}, log.NewNopLogger())
+	require.NoError(t, err)
+	bgCtx := context.Background()
+
+	// the pending valset and batch are confirmed in one transaction
+	require.NoError(t, o.Step(bgCtx))
+	msgs := chain.take()
+	require.Len(t, msgs, 2)
+	assert.IsType(t, types.MsgValsetConfirm{}, msgs[0])
+	assert.IsType(t, types.MsgConfirmBatch{}, msgs[1])
+	assert.True(t, k.HasValsetConfirm(chain.ctx, 1, validator))
+	assert.True(t, k.HasBatchConfirm(chain.ctx, 1, 0, validator))
+
+	// nothing is pending anymore
+	require.NoError(t, o.Step(bgCtx))
+	assert.Empty(t, chain.take())
+
+	// a deposit and the execution of the batch are claimed once they are deep enough
+	var destination [32]byte
+	copy(destination[:], validator)
+	eth.Emit(t, "TransferOutEvent", destination, big.NewInt(500))
+	head, err := eth.HeaderByNumber(bgCtx, nil)
+	require.NoError(t, err)
+	depositBlock, err := eth.BlockByNumber(bgCtx, head.Number)
+	require.NoError(t, err)
+	contract.executions = []fakeExecution{{block: head.Number.Uint64(), batches: []uint64{2}}}
+	require.NoError(t, o.Step(bgCtx))
+	assert.Empty(t, chain.take())
+
+	eth.Mine(2)
+	require.NoError(t, o.Step(bgCtx))
+	msgs = chain.take()
+	require.Len(t, msgs, 2)
+	assert.Equal(t, types.NewMsgEthDeposit(validator, validator, sdk.NewInt64Coin("peggy", 500), depositBlock.Transactions()[0].Hash().Hex(), 0), msgs[0])
+	assert.Equal(t, types.NewMsgBatchInChain(1, validator), msgs[1])
+
+	require.NoError(t, o.Step(bgCtx))
+	assert.Empty(t, chain.take())
+
+	// another transaction from the same account moved the sequence, the broadcaster catches up
+	chain.sequence++
+	chain.ctx = chain.ctx.WithBlockHeight(101)
+	otherValAddr := sdk.ValAddress(bytes.Repeat([]byte{2}, sdk.AddrLen))
+	k.SetEthAddress(chain.ctx, sdk.AccAddress(otherValAddr), dest)
+	stakingKeeper := k.StakingKeeper.(*keeper.StakingKeeperMock)
+	stakingKeeper.BondedValidators = keeper.NewStakingKeeperMock(valAddr, otherValAddr).BondedValidators
+	stakingKeeper.ValidatorPower[otherValAddr.String()] = 100
+	_, err = k.SetValsetRequest(chain.ctx)
+	require.NoError(t, err)
+	require.NoError(t, o.Step(bgCtx))
+	msgs = chain.take()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, int64(2), msgs[0].(types.MsgValsetConfirm).Nonce)
+}
+
+func TestClaimExecutedBatches(t *testing.T) {
+	k, ctx := keeper.CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	validator := sdk.AccAddress(valAddr)
+	k.StakingKeeper = keeper.NewStakingKeeperMock(valAddr)
+	chain := &testChain{keeper: k, ctx: ctx.WithBlockHeight(100), handler: peggy.NewHandler(k), querier: keeper.NewQuerier(k)}
+	dest, err := types.NewEthAddress("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	require.NoError(t, err)
+	// batch 1 holds tx 1 and 2, batch 2 tx 3 and batch 3 tx 4
+	keeper.AddEscrowedTransfer(t, k, chain.ctx, validator, dest, sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 1))
+	keeper.AddEscrowedTransfer(t, k, chain.ctx, validator, dest, sdk.NewInt64Coin("mytoken", 200), sdk.NewInt64Coin("mytoken", 2))
+	keeper.AddEscrowedTransfer(t, k, chain.ctx, validator, dest, sdk.NewInt64Coin("othertoken", 300), sdk.NewInt64Coin("othertoken", 3))
+	keeper.AddEscrowedTransfer(t, k, chain.ctx, validator, dest, sdk.NewInt64Coin("thirdtoken", 400), sdk.NewInt64Coin("thirdtoken", 4))
+	for _, denom := range []string{"mytoken", "othertoken", "thirdtoken"} {
+		_, err = k.BuildOutgoingTXBatch(chain.ctx, denom)
+		require.NoError(t, err)
+	}
+
+	eth := ethtest.NewSimulatedChain(t)
+	defer eth.Close()
+	eth.Mine(10)
+	head, err := eth.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	// batch 2 and 3 are executed in the same block, batch 1 is superseded by them
+	contract := &fakeContractState{executions: []fakeExecution{{block: head.Number.Uint64() - 5, batches: []uint64{3, 4}}}}
+	watcherCfg := ethwatcher.DefaultConfig(eth.Contract)
+	watcherCfg.Confirmations = 2
+	broadcaster := NewBroadcaster(chain.fetch, chain.send, Backoff{Initial: time.Millisecond, Max: time.Millisecond, Attempts: 3}, log.NewNopLogger())
+	cfg := Config{StoreKey: types.StoreKey, Validator: validator, DepositDenom: "peggy", PollInterval: time.Second}
+	o, err := New(cfg, types.ModuleCdc, chain, broadcaster, nil, eth, contract, watcherCfg, &ethwatcher.MemCursorStore{}, log.NewNopLogger())
+	require.NoError(t, err)
+	bgCtx := context.Background()
+
+	require.NoError(t, o.ClaimExecutedBatches(bgCtx))
+	msgs := chain.take()
+	require.Len(t, msgs, 2)
+	assert.Equal(t, types.NewMsgBatchInChain(2, validator), msgs[0])
+	assert.Equal(t, types.NewMsgBatchInChain(3, validator), msgs[1])
+
+	// the superseded batch was invalidated and its transfers are back in the pool
+	assert.Nil(t, k.GetOutgoingTXBatch(chain.ctx, 1))
+	assert.Empty(t, k.GetPendingOutgoingTXBatches(chain.ctx))
+	assert.Equal(t, uint64(4), k.GetLastObservedTxNonce(chain.ctx))
+	var pooled []uint64
+	k.IterateOutgoingPool(chain.ctx, func(tx types.OutgoingTx) bool {
+		pooled = append(pooled, tx.ID)
+		return false
+	})
+	assert.Equal(t, []uint64{5, 6}, pooled)
+
+	require.NoError(t, o.ClaimExecutedBatches(bgCtx))
+	assert.Empty(t, chain.take())
+}
+
+func TestAttestEthBlock(t *testing.T) {
+	k, ctx := keeper.CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	validator := sdk.AccAddress(valAddr)
+	k.StakingKeeper = keeper.NewStakingKeeperMock(valAddr)
+	chain := &testChain{keeper: k, ctx: ctx.WithBlockHeight(100), handler: peggy.NewHandler(k), querier: keeper.NewQuerier(k)}
+
+	eth := ethtest.NewSimulatedChain(t)
+	defer eth.Close()
+	watcherCfg := ethwatcher.DefaultConfig(eth.Contract)
+	watcherCfg.Confirmations = 2
+	broadcaster := NewBroadcaster(chain.fetch, chain.send, Backoff{Initial: time.Millisecond, Max: time.Millisecond, Attempts: 3}, log.NewNopLogger())
+	cfg := Config{StoreKey: types.StoreKey, Validator: validator, DepositDenom: "peggy", PollInterval: time.Second, EthBlockInterval: 5}
+	o, err := New(cfg, types.ModuleCdc, chain, broadcaster, nil, eth, &fakeContractState{}, watcherCfg, &ethwatcher.MemCursorStore{}, log.NewNopLogger())
+	require.NoError(t, err)
+	bgCtx := context.Background()
+
+	eth.Mine(5)
+	head, err := eth.HeaderByNumber(bgCtx, nil)
+	require.NoError(t, err)
+	final, err := eth.HeaderByNumber(bgCtx, new(big.Int).Sub(head.Number, big.NewInt(2)))
+	require.NoError(t, err)
+	gasPrice, err := eth.SuggestGasPrice(bgCtx)
+	require.NoError(t, err)
+	require.NoError(t, o.AttestEthBlock(bgCtx))
+	msgs := chain.take()
+	require.Len(t, msgs, 2)
+	assert.Equal(t, types.NewMsgEthBlockObserved(final.Number.Uint64(), final.Hash().Hex(), final.Time, validator), msgs[0])
+	assert.Equal(t, types.NewMsgGasPriceObserved(sdk.NewIntFromBigInt(gasPrice), validator), msgs[1])
+	assert.Equal(t, final.Number.Uint64(), k.GetEthBlock(chain.ctx).Number)
+	assert.Equal(t, sdk.NewIntFromBigInt(gasPrice), k.GetGasPrice(chain.ctx).Price)
+
+	// the final block is attested again once it moved by the interval
+	eth.Mine(4)
+	require.NoError(t, o.AttestEthBlock(bgCtx))
+	assert.Empty(t, chain.take())
+	eth.Mine(1)
+	require.NoError(t, o.AttestEthBlock(bgCtx))
+	msgs = chain.take()
+	require.Len(t, msgs, 2)
+	assert.Equal(t, final.Number.Uint64()+5, msgs[0].(types.MsgEthBlockObserved).Number)
+}
+
+func TestAttestERC20Deployments(t *testing.T) {
+	k, ctx := keeper.CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	validator := sdk.AccAddress(valAddr)
+	k.StakingKeeper = keeper.NewStakingKeeperMock(valAddr)
+	metadata := types.ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 6}
+	params := k.GetParams(ctx)
+	params.NativeTokens = []types.NativeToken{{Denom: "stake", Metadata: metadata}}
+	k.SetParams(ctx, params)
+	chain := &testChain{keeper: k, ctx: ctx.WithBlockHeight(100), handler: peggy.NewHandler(k), querier: keeper.NewQuerier(k)}
+
+	eth := ethtest.NewSimulatedChain(t)
+	defer eth.Close()
+	watcherCfg := ethwatcher.DefaultConfig(eth.Contract)
+	watcherCfg.Confirmations = 2
+	token := common.HexToAddress("0x7c2C195CD6D34B8F845992d380aADB2730bB9C6F")
+	other := common.HexToAddress("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	contract := &fakeContractState{token: token, tokens: map[common.Address]types.ERC20Metadata{token: metadata, other: metadata}}
+	broadcaster := NewBroadcaster(chain.fetch, chain.send, Backoff{Initial: time.Millisecond, Max: time.Millisecond, Attempts: 3}, log.NewNopLogger())
+	// other is not the token of the contract and is never attested
+	cfg := Config{StoreKey: types.StoreKey, Validator: validator, DepositDenom: "peggy", PollInterval: time.Second, ERC20Contracts: map[string]common.Address{"stake": token, "other": other}}
+	o, err := New(cfg, types.ModuleCdc, chain, broadcaster, nil, eth, contract, watcherCfg, &ethwatcher.MemCursorStore{}, log.NewNopLogger())
+	require.NoError(t, err)
+	bgCtx := context.Background()
+
+	// nothing is final yet
+	require.NoError(t, o.AttestERC20Deployments(bgCtx))
+	assert.Empty(t, chain.take())
+
+	eth.Mine(3)
+	require.NoError(t, o.AttestERC20Deployments(bgCtx))
+	msgs := chain.take()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, types.NewMsgERC20Deployed("stake", types.EthAddressFromCommon(token), metadata, validator), msgs[0])
+	erc20 := k.GetCosmosERC20(chain.ctx, "stake")
+	require.NotNil(t, erc20)
+	assert.Equal(t, types.EthAddressFromCommon(token), erc20.TokenContract)
+
+	require.NoError(t, o.AttestERC20Deployments(bgCtx))
+	assert.Empty(t, chain.take())
+
+	// deposits of the token of the contract are attested as the native denom
+	var dest [32]byte
+	copy(dest[:], validator)
+	require.NoError(t, o.HandleTransferOut(bgCtx, &peggycontract.PeggyTransferOutEvent{Destination: dest, Amount: big.NewInt(7)}))
+	msgs = chain.take()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, sdk.NewInt64Coin("stake", 7), msgs[0].(types.MsgEthDeposit).Amount)
+}
+
+func TestBroadcasterBackoff(t *testing.T) {
+	var attempts, fetches int
+	fetch := func() (uint64, uint64, error) {
+		fetches++
+		return 7, 3, nil
+	}
+	send := func(_ []sdk.Msg, accountNumber uint64, sequence uint64) error {
+		attempts++
+		assert.Equal(t, uint64(7), accountNumber)
+		assert.Equal(t, uint64(3), sequence)
+		return errors.New("node unavailable")
+	}
+	b := NewBroadcaster(fetch, send, Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, Attempts: 4}, log.NewNopLogger())
+	assert.Error(t, b.Broadcast(context.Background(), types.NewMsgBatchInChain(1, nil)))
+	assert.Equal(t, 4, attempts)
+	assert.Equal(t, 4, fetches)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b = NewBroadcaster(fetch, send, Backoff{Initial: time.Hour, Max: time.Hour, Attempts: 4}, log.NewNopLogger())
+	assert.Equal(t, context.Canceled, b.Broadcast(ctx, types.NewMsgBatchInChain(1, nil)))
+}
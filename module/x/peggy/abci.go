@@ -0,0 +1,13 @@
+package peggy
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EndBlocker prunes the valset requests and confirms that are no longer needed, releases the time
+// locked transfers whose delay passed and mints the observed deposits that could not be minted yet
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	k.PruneValsets(ctx)
+	k.ReleaseTimeLockedTransfers(ctx)
+	k.MintObservedDeposits(ctx)
+}
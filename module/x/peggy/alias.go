@@ -11,18 +11,26 @@ const (
 	StoreKey          = types.StoreKey
 	DefaultParamspace = types.ModuleName
 	QuerierRoute      = types.QuerierRoute
+
+	UpgradeName = keeper.UpgradeName
 )
 
 var (
-	NewKeeper           = keeper.NewKeeper
-	NewQuerier          = keeper.NewQuerier
-	NewMsgSetEthAddress = types.NewMsgSetEthAddress
-	ModuleCdc           = types.ModuleCdc
-	RegisterCodec       = types.RegisterCodec
+	NewKeeper              = keeper.NewKeeper
+	NewQuerier             = keeper.NewQuerier
+	RegisterInvariants     = keeper.RegisterInvariants
+	NewGenesisState        = types.NewGenesisState
+	ValidateGenesis        = types.ValidateGenesis
+	DefaultGenesisState    = types.DefaultGenesisState
+	NewMsgSetEthAddress    = types.NewMsgSetEthAddress
+	NewMsgRotateEthAddress = types.NewMsgRotateEthAddress
+	ModuleCdc              = types.ModuleCdc
+	RegisterCodec          = types.RegisterCodec
 )
 
 type (
 	Keeper           = keeper.Keeper
+	GenesisState     = types.GenesisState
 	MsgSetEthAddress = types.MsgSetEthAddress
 	MsgValsetConfirm = types.MsgValsetConfirm
 	MsgValsetRequest = types.MsgValsetRequest
@@ -31,4 +39,16 @@ type (
 	MsgConfirmBatch  = types.MsgConfirmBatch
 	MsgBatchInChain  = types.MsgBatchInChain
 	MsgEthDeposit    = types.MsgEthDeposit
+
+	MsgRotateEthAddress = types.MsgRotateEthAddress
+	MsgValsetObserved   = types.MsgValsetObserved
+	MsgEthBlockObserved = types.MsgEthBlockObserved
+	MsgGasPriceObserved = types.MsgGasPriceObserved
+	MsgERC20Deployed    = types.MsgERC20Deployed
+	MsgPauseVote        = types.MsgPauseVote
+	MsgVetoTransfer     = types.MsgVetoTransfer
+
+	BridgePauseProposal  = types.BridgePauseProposal
+	TransferVetoProposal = types.TransferVetoProposal
+	BlocklistProposal    = types.BlocklistProposal
 )
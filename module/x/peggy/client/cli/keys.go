@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/althea-net/peggy/module/x/peggy/client/ethkey"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// GetEthKeysCmd manages the eth keys of validators in the keyring
+func GetEthKeysCmd() *cobra.Command {
+	ethKeysCmd := &cobra.Command{
+		Use:                        "eth-keys",
+		Short:                      "Manage the eth keys validators sign peggy checkpoints with",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	ethKeysCmd.AddCommand(
+		CmdAddEthKey(),
+		CmdImportEthKey(),
+		CmdShowEthKey(),
+	)
+	for _, cmd := range ethKeysCmd.Commands() {
+		cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	}
+	return ethKeysCmd
+}
+
+func CmdAddEthKey() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add [name]",
+		Short: "generate a new eth key and store it in the keyring",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			kb, err := ethKeyring(cmd, inBuf)
+			if err != nil {
+				return err
+			}
+			key, err := ethCrypto.GenerateKey()
+			if err != nil {
+				return err
+			}
+			if err := ethkey.ImportToKeyring(kb, args[0], key); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), ethCrypto.PubkeyToAddress(key.PublicKey).Hex())
+			return nil
+		},
+	}
+}
+
+func CmdImportEthKey() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [name] [keystore file]",
+		Short: "import the eth key of a go-ethereum keystore file into the keyring",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			kb, err := ethKeyring(cmd, inBuf)
+			if err != nil {
+				return err
+			}
+			passphrase, err := input.GetPassword("Enter passphrase to decrypt the eth keystore:", inBuf)
+			if err != nil {
+				return err
+			}
+			key, err := ethkey.FromKeystore(args[1], passphrase)
+			if err != nil {
+				return err
+			}
+			if err := ethkey.ImportToKeyring(kb, args[0], key); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), ethCrypto.PubkeyToAddress(key.PublicKey).Hex())
+			return nil
+		},
+	}
+}
+
+func CmdShowEthKey() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [name]",
+		Short: "print the eth address of a key in the keyring",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			kb, err := ethKeyring(cmd, inBuf)
+			if err != nil {
+				return err
+			}
+			key, err := ethkey.FromKeyring(kb, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), ethCrypto.PubkeyToAddress(key.PublicKey).Hex())
+			return nil
+		},
+	}
+}
+
+// ethKeyring opens the keyring with the backend of the command's flag. The flag is read from the
+// command instead of viper so that it does not interfere with the binding of the keys commands.
+func ethKeyring(cmd *cobra.Command, inBuf *bufio.Reader) (keys.Keybase, error) {
+	backend, err := cmd.Flags().GetString(flags.FlagKeyringBackend)
+	if err != nil {
+		return nil, err
+	}
+	return keys.NewKeyring(sdk.KeyringServiceName(), backend, viper.GetString(flags.FlagHome), inBuf)
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+)
+
+// GetCmdSubmitBridgePauseProposal submits a governance proposal to pause or resume the bridge
+func GetCmdSubmitBridgePauseProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bridge-pause [true|false]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to pause or resume the peggy bridge",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paused, err := strconv.ParseBool(args[0])
+			if err != nil {
+				return err
+			}
+			return submitProposal(cmd, cdc, func(title, description string) gov.Content {
+				return types.NewBridgePauseProposal(title, description, paused)
+			})
+		},
+	}
+	return withProposalFlags(cmd)
+}
+
+// GetCmdSubmitTransferVetoProposal submits a governance proposal to veto time locked transfers
+func GetCmdSubmitTransferVetoProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-veto [id,...]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to veto time locked transfers to Ethereum and refund their senders",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var ids []uint64
+			for _, s := range strings.Split(args[0], ",") {
+				id, err := strconv.ParseUint(s, 10, 64)
+				if err != nil {
+					return err
+				}
+				ids = append(ids, id)
+			}
+			return submitProposal(cmd, cdc, func(title, description string) gov.Content {
+				return types.NewTransferVetoProposal(title, description, ids)
+			})
+		},
+	}
+	return withProposalFlags(cmd)
+}
+
+// GetCmdSubmitBlocklistProposal submits a governance proposal to block or unblock addresses
+func GetCmdSubmitBlocklistProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "blocklist [block|unblock] [address,...]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Submit a proposal to block or unblock 0x prefixed eth addresses and bech32 cosmos addresses",
+		Long: `Submit a proposal to block or unblock 0x prefixed eth addresses and bech32 cosmos addresses.
+No transfers to Ethereum are accepted from blocked cosmos addresses or to blocked eth addresses, and
+deposits to blocked cosmos addresses are held until they are unblocked.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var blocked bool
+			switch args[0] {
+			case "block":
+				blocked = true
+			case "unblock":
+			default:
+				return fmt.Errorf("expected block or unblock, got %q", args[0])
+			}
+			var ethAddresses []types.EthAddress
+			var cosmosAddresses []sdk.AccAddress
+			for _, s := range strings.Split(args[1], ",") {
+				if strings.HasPrefix(s, "0x") {
+					addr, err := types.NewEthAddress(s)
+					if err != nil {
+						return err
+					}
+					ethAddresses = append(ethAddresses, addr)
+					continue
+				}
+				addr, err := sdk.AccAddressFromBech32(s)
+				if err != nil {
+					return err
+				}
+				cosmosAddresses = append(cosmosAddresses, addr)
+			}
+			return submitProposal(cmd, cdc, func(title, description string) gov.Content {
+				return types.NewBlocklistProposal(title, description, blocked, ethAddresses, cosmosAddresses)
+			})
+		},
+	}
+	return withProposalFlags(cmd)
+}
+
+func withProposalFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+// submitProposal submits the content built from the proposal flags
+func submitProposal(cmd *cobra.Command, cdc *codec.Codec, content func(title, description string) gov.Content) error {
+	title, err := cmd.Flags().GetString(govcli.FlagTitle)
+	if err != nil {
+		return err
+	}
+	description, err := cmd.Flags().GetString(govcli.FlagDescription)
+	if err != nil {
+		return err
+	}
+	depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+	if err != nil {
+		return err
+	}
+	deposit, err := sdk.ParseCoins(depositStr)
+	if err != nil {
+		return err
+	}
+
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+	cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+	msg := gov.NewMsgSubmitProposal(content(title, description), deposit, cliCtx.GetFromAddress())
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+}
@@ -24,6 +24,32 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		CmdGetCurrentValset(storeKey, cdc),
 		CmdGetValsetRequest(storeKey, cdc),
 		CmdGetValsetConfirm(storeKey, cdc),
+		CmdGetOutgoingTxBatch(storeKey, cdc),
+		CmdGetPendingOutgoingTxBatches(storeKey, cdc),
+		CmdGetSubmitBatchPayload(storeKey, cdc),
+		CmdGetEthAddressRegistration(storeKey, cdc),
+		CmdGetEthAddressHistory(storeKey, cdc),
+		CmdGetLastObservedValsetNonce(storeKey, cdc),
+		CmdGetValsetNonce(storeKey, cdc),
+		CmdGetValsetRetention(storeKey, cdc),
+		CmdGetParams(storeKey, cdc),
+		CmdGetPauseState(storeKey, cdc),
+		CmdGetRateLimits(storeKey, cdc),
+		CmdGetTimeLockedTransfers(storeKey, cdc),
+		CmdGetBlocklist(storeKey, cdc),
+		CmdGetHeldDeposits(storeKey, cdc),
+		CmdGetFeeEstimate(storeKey, cdc),
+		CmdGetTransferStatus(storeKey, cdc),
+		CmdGetTransfersBySender(storeKey, cdc),
+		CmdGetTransfersByRecipient(storeKey, cdc),
+		CmdGetDepositStatus(storeKey, cdc),
+		CmdGetDepositsByRecipient(storeKey, cdc),
+		CmdGetEthBlock(storeKey, cdc),
+		CmdGetEthBlockAttestations(storeKey, cdc),
+		CmdGetGasPrice(storeKey, cdc),
+		CmdGetGasPriceAttestations(storeKey, cdc),
+		CmdGetCosmosERC20(storeKey, cdc),
+		CmdGetCosmosERC20s(storeKey, cdc),
 	)...)
 
 	return peggyQueryCmd
@@ -97,3 +123,585 @@ func CmdGetValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func CmdGetOutgoingTxBatch(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "outgoing-tx-batch [nonce]",
+		Short: "Get the outgoing tx batch with a particular nonce",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			nonce := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/outgoingTxBatch/%s", storeKey, nonce), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no batch found for nonce %s", nonce)
+			}
+
+			var out types.OutgoingTxBatch
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetPendingOutgoingTxBatches(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pending-outgoing-tx-batches",
+		Short: "Get the outgoing tx batches that were not observed executed on Ethereum yet",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/pendingOutgoingTxBatches", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out []types.OutgoingTxBatch
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetSubmitBatchPayload(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "submit-batch-payload [batch nonce] [new valset nonce]",
+		Short: "Get the contract call arguments and calldata to relay a batch, optionally together with a new valset",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/submitBatchPayload/%s", storeKey, args[0])
+			if len(args) == 2 {
+				route = fmt.Sprintf("custom/%s/updateValsetAndSubmitBatchPayload/%s/%s", storeKey, args[0], args[1])
+			}
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no payload found for batch nonce %s", args[0])
+			}
+
+			var out types.RelayBatchPayload
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetEthAddressRegistration(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "eth-registration [bech32 validator address]",
+		Short: "Get the eth address registration status and power of a bonded validator, or of all of them when no address is given",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			if len(args) == 0 {
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/ethAddressRegistrations", storeKey), nil)
+				if err != nil {
+					return err
+				}
+				var out []types.ValidatorRegistration
+				cdc.MustUnmarshalJSON(res, &out)
+				return cliCtx.PrintOutput(out)
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/ethAddressRegistration/%s", storeKey, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("%s is not a bonded validator", args[0])
+			}
+			var out types.ValidatorRegistration
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetEthAddressHistory(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "eth-address-history [bech32 validator address]",
+		Short: "Get all eth addresses a validator registered, the next registration has to be signed with their count as nonce",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			history, err := queryEthAddressHistory(cliCtx, storeKey, args[0])
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(history)
+		},
+	}
+}
+
+func queryEthAddressHistory(cliCtx context.CLIContext, storeKey string, validator string) ([]types.EthAddressRecord, error) {
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/ethAddressHistory/%s", storeKey, validator), nil)
+	if err != nil {
+		return nil, err
+	}
+	var out []types.EthAddressRecord
+	if err := cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func CmdGetLastObservedValsetNonce(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "last-observed-valset",
+		Short: "Get the nonce of the last valset observed on Ethereum, rotated eth keys are accepted until their valset is",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/lastObservedValsetNonce", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out int64
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetValsetNonce(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "valset-nonce [height]",
+		Short: "Get the nonce of the valset requested at the height, or of the latest valset without height",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/latestValsetNonce", storeKey)
+			if len(args) == 1 {
+				route = fmt.Sprintf("custom/%s/valsetNonceByHeight/%s", storeKey, args[0])
+			}
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+			var out int64
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetValsetRetention(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "valset-retention",
+		Short: "Get the bounds of the valset requests and confirms that are kept, older ones are pruned",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/valsetRetention", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out types.ValsetRetention
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetParams(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Get the params of the peggy module",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params, err := queryParams(cliCtx, storeKey)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(params)
+		},
+	}
+}
+
+// queryParams returns the params of the module
+func queryParams(cliCtx context.CLIContext, storeKey string) (types.Params, error) {
+	var out types.Params
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", storeKey), nil)
+	if err != nil {
+		return out, err
+	}
+	err = cliCtx.Codec.UnmarshalJSON(res, &out)
+	return out, err
+}
+
+func CmdGetPauseState(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause-state",
+		Short: "Get whether the bridge is paused, transfers, batches and deposits are halted while it is",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/pauseState", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out types.PauseState
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetRateLimits(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rate-limits [denom]",
+		Short: "Get the remaining capacity of the rate limits of the denom, or of all denoms without one",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/rateLimits", storeKey)
+			if len(args) == 1 {
+				route = fmt.Sprintf("%s/%s", route, args[0])
+			}
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+			var out []types.RateLimitCapacity
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetTimeLockedTransfers(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "time-locked-transfers",
+		Short: "Get the large transfers to Ethereum that wait in the delay queue and can still be vetoed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/timeLockedTransfers", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out []types.TimeLockedTransfer
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetBlocklist(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "blocklist",
+		Short: "Get the eth and cosmos addresses governance blocked from using the bridge",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/blocklist", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out types.Blocklist
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetHeldDeposits(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "held-deposits [bech32 recipient]",
+		Short: "Get the deposits held for the blocked recipient, or for all recipients without one",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/heldDeposits", storeKey)
+			if len(args) == 1 {
+				route = fmt.Sprintf("%s/%s", route, args[0])
+			}
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+			var out []types.HeldDeposit
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetFeeEstimate(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fee-estimate [denom]",
+		Short: "Get the pool depth and bridge fees of the denom and the lowest fee that makes the next batch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			out, err := queryFeeEstimate(cliCtx, storeKey, args[0])
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetTransferStatus(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "transfer-status [transfer id]",
+		Short: "Get the status of the transfer to Ethereum with the id send-to-eth returned",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			id := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/transferStatus/%s", storeKey, id), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no transfer found for id %s", id)
+			}
+
+			var out types.TransferStatus
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetTransfersBySender(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "transfers-by-sender [bech32 sender]",
+		Short: "Get the status of all transfers to Ethereum from the sender",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/transfersBySender/%s", storeKey, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			var out []types.TransferStatus
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetTransfersByRecipient(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "transfers-by-recipient [eth address]",
+		Short: "Get the status of all transfers to the eth address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/transfersByRecipient/%s", storeKey, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			var out []types.TransferStatus
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetDepositStatus(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deposit-status [deposit id]",
+		Short: "Get the status of the deposit from Ethereum with the id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			id := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/depositStatus/%s", storeKey, id), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no deposit found for id %s", id)
+			}
+
+			var out types.DepositStatus
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetDepositsByRecipient(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deposits-by-recipient [bech32 recipient]",
+		Short: "Get the status of all deposits from Ethereum to the recipient",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/depositsByRecipient/%s", storeKey, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			var out []types.DepositStatus
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetEthBlock(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "eth-block",
+		Short: "Get the latest final Ethereum block as agreed on by the validators",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/ethBlock", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return errors.New("no eth block observed yet")
+			}
+
+			var out types.EthBlock
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetEthBlockAttestations(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "eth-block-attestations",
+		Short: "Get the latest Ethereum block each validator attested",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/ethBlockAttestations", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out []types.EthBlockAttestation
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetGasPrice(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "gas-price",
+		Short: "Get the Ethereum gas price in wei as agreed on by the validators",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/gasPrice", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return errors.New("no gas price observed yet")
+			}
+
+			var out types.GasPrice
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetGasPriceAttestations(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "gas-price-attestations",
+		Short: "Get the latest Ethereum gas price each validator attested",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/gasPriceAttestations", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out []types.GasPriceAttestation
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetCosmosERC20(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "erc20 [denom]",
+		Short: "Get the ERC20 that represents the native denom on Ethereum and the amount locked for it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			denom := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/cosmosERC20/%s", storeKey, denom), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no erc20 deployed for %s", denom)
+			}
+
+			var out types.CosmosERC20
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func CmdGetCosmosERC20s(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "erc20s",
+		Short: "Get the ERC20s of all native denoms",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/cosmosERC20s", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			var out []types.CosmosERC20
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func queryFeeEstimate(cliCtx context.CLIContext, storeKey string, denom string) (types.FeeEstimate, error) {
+	var out types.FeeEstimate
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/feeEstimate/%s", storeKey, denom), nil)
+	if err != nil {
+		return out, err
+	}
+	err = cliCtx.Codec.UnmarshalJSON(res, &out)
+	return out, err
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/althea-net/peggy/module/ethsigner"
+	"github.com/althea-net/peggy/module/x/peggy/client/ethkey"
+	"github.com/althea-net/peggy/module/x/peggy/utils"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	cryptokeys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	flagSignerTLSCert = "signer-tls-cert"
+	flagSignerTLSKey  = "signer-tls-key"
+	flagSignerCA      = "signer-ca"
+	flagListen        = "listen"
+	flagStateFile     = "state-file"
+)
+
+// AddSignerFlags adds the TLS flags that are needed for remote:<host:port> eth key references
+func AddSignerFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagSignerTLSCert, "", "TLS certificate presented to the other side of a remote eth signer connection")
+	cmd.Flags().String(flagSignerTLSKey, "", "key of the --signer-tls-cert certificate")
+	cmd.Flags().String(flagSignerCA, "", "certificate authority the other side of a remote eth signer connection has to be signed by")
+}
+
+// LoadSigner resolves the eth key reference of a command that has the signer flags
+func LoadSigner(cmd *cobra.Command, ref string, kb cryptokeys.Keybase, inBuf *bufio.Reader) (utils.Signer, error) {
+	tlsFiles, err := signerTLSFiles(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return ethkey.LoadSigner(ref, kb, inBuf, tlsFiles)
+}
+
+func signerTLSFiles(cmd *cobra.Command) (ethsigner.TLSFiles, error) {
+	var (
+		files ethsigner.TLSFiles
+		err   error
+	)
+	if files.Cert, err = cmd.Flags().GetString(flagSignerTLSCert); err != nil {
+		return files, err
+	}
+	if files.Key, err = cmd.Flags().GetString(flagSignerTLSKey); err != nil {
+		return files, err
+	}
+	files.CA, err = cmd.Flags().GetString(flagSignerCA)
+	return files, err
+}
+
+// GetEthSignerCmd runs the eth key of a validator as a remote signer for the confirm commands and
+// the orchestrator
+func GetEthSignerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "eth-signer [eth key]",
+		Short: "serve signatures over peggy checkpoints to authenticated clients, refusing to sign a checkpoint nonce twice",
+		Long: `The eth key is keystore:<file> or keyring:<name>. Clients have to present a certificate signed by
+--signer-ca, the server presents --signer-tls-cert. Every signed checkpoint is recorded in --state-file
+before the signature is returned.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			kb, err := ethKeyring(cmd, inBuf)
+			if err != nil {
+				return err
+			}
+			key, err := ethkey.Load(args[0], kb, inBuf)
+			if err != nil {
+				return err
+			}
+			stateFile, err := cmd.Flags().GetString(flagStateFile)
+			if err != nil {
+				return err
+			}
+			signer, err := ethsigner.NewGuardedSigner(utils.LocalSigner{Key: key}, stateFile)
+			if err != nil {
+				return err
+			}
+			tlsFiles, err := signerTLSFiles(cmd)
+			if err != nil {
+				return err
+			}
+			tlsCfg, err := tlsFiles.ServerConfig()
+			if err != nil {
+				return err
+			}
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+			server, err := ethsigner.NewServer(signer, tlsCfg, logger)
+			if err != nil {
+				return err
+			}
+			listen, err := cmd.Flags().GetString(flagListen)
+			if err != nil {
+				return err
+			}
+			logger.Info("eth signer listening", "addr", listen, "eth_address", signer.Address().Hex())
+			return server.ListenAndServe(listen)
+		},
+	}
+	AddSignerFlags(cmd)
+	cmd.Flags().String(flagListen, "127.0.0.1:26660", "address to accept signer connections on")
+	cmd.Flags().String(flagStateFile, "eth_signer_state.json", "file the signed checkpoints are recorded in")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	return cmd
+}
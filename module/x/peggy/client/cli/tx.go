@@ -6,12 +6,15 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/types/errors"
 	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 
+	"github.com/althea-net/peggy/module/x/peggy/client/ethkey"
 	"github.com/althea-net/peggy/module/x/peggy/types"
+	peggyutils "github.com/althea-net/peggy/module/x/peggy/utils"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -31,15 +34,28 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	}
 
 	peggyTxCmd.AddCommand(flags.PostCommands(
-		CmdUpdateEthAddress(cdc),
+		CmdUpdateEthAddress(storeKey, cdc),
+		CmdRotateEthAddress(storeKey, cdc),
 		CmdValsetRequest(cdc),
+		CmdValsetObserved(cdc),
+		CmdPauseVote(cdc),
+		CmdVetoTransfer(cdc),
 		CmdValsetConfirm(storeKey, cdc),
+		CmdSendToEth(storeKey, cdc),
+		CmdRequestBatch(cdc),
+		CmdBatchConfirm(storeKey, cdc),
 		GetUnsafeTestingCmd(storeKey, cdc),
 	)...)
 
 	return peggyTxCmd
 }
 
+// ethKeyRefHelp explains the eth key argument of the signing commands
+const ethKeyRefHelp = `The eth key is a reference, never the key itself:
+  keystore:<file>  a go-ethereum encrypted keystore file, the passphrase is prompted for
+  keyring:<name>   an eth key stored in the keyring with "eth-keys add" or "eth-keys import"
+  remote:<addr>    an eth-signer server, confirm commands only, see the --signer-* flags`
+
 // GetUnsafeTestingCmd
 func GetUnsafeTestingCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	testingTxCmd := &cobra.Command{
@@ -58,10 +74,11 @@ func GetUnsafeTestingCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 }
 
 // GetCmdUpdateEthAddress updates the network about the eth address that you have on record.
-func CmdUpdateEthAddress(cdc *codec.Codec) *cobra.Command {
+func CmdUpdateEthAddress(storeKey string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "update-eth-addr [eth private key]",
+		Use:   "update-eth-addr [eth key]",
 		Short: "update your eth address which will be used for peggy if you are a validator",
+		Long:  ethKeyRefHelp,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -69,37 +86,151 @@ func CmdUpdateEthAddress(cdc *codec.Codec) *cobra.Command {
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
 			cosmosAddr := cliCtx.GetFromAddress()
+			ethAddress, nonce, signature, err := signEthAddressRegistration(cliCtx, storeKey, args[0], txBldr, inBuf)
+			if err != nil {
+				return err
+			}
 
-			privKeyString := args[0][2:]
-
-			// Make Eth Signature over validator address
-			privateKey, err := ethCrypto.HexToECDSA(privKeyString)
+			// Make the message
+			msg := types.NewMsgSetEthAddress(ethAddress, cosmosAddr, nonce, signature)
+			err = msg.ValidateBasic()
 			if err != nil {
-				log.Fatal(err)
+				return err
 			}
 
-			hash := ethCrypto.Keccak256Hash(cosmosAddr) // TODO: Can probably skip the "Hash" struct and use ethCrypto.Keccak256
-			signature, err := ethCrypto.Sign(hash.Bytes(), privateKey)
+			// Send it
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// CmdRotateEthAddress replaces the registered eth address with the next valset request
+func CmdRotateEthAddress(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-eth-addr [new eth key]",
+		Short: "replace your registered eth address, the new one takes effect with the next valset request",
+		Long: `Confirms signed with either key are accepted until the valset with the new address is
+observed on Ethereum, keep the orchestrator with the old key running until then.
+
+` + ethKeyRefHelp,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			cosmosAddr := cliCtx.GetFromAddress()
+			ethAddress, nonce, signature, err := signEthAddressRegistration(cliCtx, storeKey, args[0], txBldr, inBuf)
 			if err != nil {
-				log.Fatal(err)
+				return err
 			}
 
-			// You've got to do all this to get an Eth address from the private key
-			publicKey := privateKey.Public()
-			publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-			if !ok {
-				log.Fatal("error casting public key to ECDSA")
+			msg := types.NewMsgRotateEthAddress(ethAddress, cosmosAddr, nonce, signature)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
 			}
-			ethAddress := ethCrypto.PubkeyToAddress(*publicKeyECDSA).Hex()
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
 
-			// Make the message
-			msg := types.NewMsgSetEthAddress(ethAddress, cosmosAddr, hex.EncodeToString(signature))
-			err = msg.ValidateBasic()
+// signEthAddressRegistration signs the registration of the address of the eth key for the from
+// address, the signature is bound to this chain, its peggy id and the next registration nonce
+func signEthAddressRegistration(cliCtx context.CLIContext, storeKey string, keyRef string, txBldr auth.TxBuilder, inBuf *bufio.Reader) (types.EthAddress, uint64, string, error) {
+	cosmosAddr := cliCtx.GetFromAddress()
+	history, err := queryEthAddressHistory(cliCtx, storeKey, cosmosAddr.String())
+	if err != nil {
+		return nil, 0, "", err
+	}
+	nonce := uint64(len(history))
+	params, err := queryParams(cliCtx, storeKey)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	hash, err := types.EthAddressRegistrationHash(params.PeggyID, cliCtx.ChainID, cosmosAddr, nonce)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	// Make Eth Signature over the registration
+	privateKey, err := ethkey.Load(keyRef, txBldr.Keybase(), inBuf)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	signature, err := ethCrypto.Sign(hash, privateKey)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	// You've got to do all this to get an Eth address from the private key
+	publicKey := privateKey.Public()
+	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, 0, "", fmt.Errorf("error casting public key to ECDSA")
+	}
+	return types.EthAddressFromCommon(ethCrypto.PubkeyToAddress(*publicKeyECDSA)), nonce, hex.EncodeToString(signature), nil
+}
+
+func CmdValsetObserved(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "valset-observed [nonce]",
+		Short: "attest as validator that the valset with the nonce was applied to the Peggy contract",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			nonce, err := strconv.ParseInt(args[0], 10, 64)
 			if err != nil {
 				return err
 			}
+			msg := types.NewMsgValsetObserved(nonce, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
 
-			// Send it
+func CmdPauseVote(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause-vote",
+		Short: "vote as validator to pause the bridge in an emergency, resuming it takes a governance proposal",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgPauseVote(cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func CmdVetoTransfer(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "veto-transfer [id]",
+		Short: "vote as validator to veto the time locked transfer with the id and refund its sender",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgVetoTransfer(id, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -125,9 +256,10 @@ func CmdValsetRequest(cdc *codec.Codec) *cobra.Command {
 }
 
 func CmdValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:   "valset-confirm [nonce] [eth private key]",
+	cmd := &cobra.Command{
+		Use:   "valset-confirm [nonce] [eth key]",
 		Short: "this is used by validators to sign a valset with a particular nonce if it exists",
+		Long:  ethKeyRefHelp,
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -135,10 +267,9 @@ func CmdValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
 			// Make Eth Signature over valset
-			privKeyString := args[1][2:]
-			privateKey, err := ethCrypto.HexToECDSA(privKeyString)
+			signer, err := LoadSigner(cmd, args[1], txBldr.Keybase(), inBuf)
 			if err != nil {
-				log.Fatal(err)
+				return err
 			}
 
 			nonce := args[0]
@@ -150,11 +281,22 @@ func CmdValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
 
 			var valset types.Valset
 			cdc.MustUnmarshalJSON(res, &valset)
-			checkpoint := valset.GetCheckpoint()
+			params, err := queryParams(cliCtx, storeKey)
+			if err != nil {
+				return err
+			}
+			checkpoint, err := valset.GetCheckpoint(params.PeggyID)
+			if err != nil {
+				return err
+			}
 
-			signature, err := ethCrypto.Sign(checkpoint, privateKey)
+			signature, err := signer.SignCheckpoint(peggyutils.SignRequest{
+				Kind:  peggyutils.CheckpointValset,
+				Nonce: valset.Nonce,
+				Hash:  checkpoint,
+			})
 			if err != nil {
-				log.Fatal(err)
+				return err
 			}
 			cosmosAddr := cliCtx.GetFromAddress()
 			// Make the message
@@ -168,6 +310,166 @@ func CmdValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	AddSignerFlags(cmd)
+	return cmd
+}
+
+func CmdSendToEth(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "send-to-eth [eth dest address] [amount] [bridge fee]",
+		Short: "add a transfer to ethereum to the outgoing pool",
+		Long: `Add a transfer to ethereum to the outgoing pool. Without a bridge fee the lowest fee that makes
+the next batch is suggested and used, see the fee-estimate query. The transfer id in the result data
+tracks the transfer, see the transfer-status query.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cosmosAddr := cliCtx.GetFromAddress()
+
+			amount, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				return errors.Wrap(err, "amount")
+			}
+			destination, err := types.NewEthAddress(args[0])
+			if err != nil {
+				return errors.Wrap(err, "destination")
+			}
+
+			var bridgeFee sdk.Coin
+			if len(args) == 3 {
+				if bridgeFee, err = sdk.ParseCoin(args[2]); err != nil {
+					return errors.Wrap(err, "bridge fee")
+				}
+				// the suggestion is best effort, it is not available offline
+				if estimate, err := queryFeeEstimate(cliCtx, storeKey, amount.Denom); err == nil &&
+					bridgeFee.Denom == amount.Denom && bridgeFee.Amount.LT(estimate.NextBatchFee) {
+					cmd.PrintErrf("bridge fee below %s%s, the transfer may not make the next batch\n", estimate.NextBatchFee, amount.Denom)
+				}
+			} else {
+				estimate, err := queryFeeEstimate(cliCtx, storeKey, amount.Denom)
+				if err != nil {
+					return errors.Wrap(err, "fee estimate")
+				}
+				bridgeFee = sdk.NewCoin(amount.Denom, estimate.NextBatchFee)
+				cmd.PrintErrf("using the suggested bridge fee %s, the pool holds %d transfers\n", bridgeFee, estimate.PoolDepth)
+			}
+
+			// Make the message
+			msg := types.NewMsgSendToEth(cosmosAddr, destination, amount, bridgeFee)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func CmdRequestBatch(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "request-batch [denom]",
+		Short: "build a new batch from the outgoing pool for the given denom",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cosmosAddr := cliCtx.GetFromAddress()
+
+			// Make the message
+			msg := types.NewMsgRequestBatch(cosmosAddr, args[0])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+const flagValsetNonce = "valset-nonce"
+
+func CmdBatchConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-confirm [nonce] [eth key]",
+		Short: "this is used by validators to sign a batch, optionally combined with a new valset, if it exists",
+		Long:  ethKeyRefHelp,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			// Make Eth Signature over the batch
+			signer, err := LoadSigner(cmd, args[1], txBldr.Keybase(), inBuf)
+			if err != nil {
+				return err
+			}
+
+			nonce := args[0]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/outgoingTxBatch/%s", storeKey, nonce), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no batch found for nonce %s", nonce)
+			}
+			var batch types.OutgoingTxBatch
+			cdc.MustUnmarshalJSON(res, &batch)
+			params, err := queryParams(cliCtx, storeKey)
+			if err != nil {
+				return err
+			}
+			checkpoint, err := batch.GetCheckpoint(params.PeggyID)
+			if err != nil {
+				return err
+			}
+			kind := peggyutils.CheckpointBatch
+
+			valsetNonce, err := cmd.Flags().GetInt64(flagValsetNonce)
+			if err != nil {
+				return err
+			}
+			if valsetNonce != 0 {
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/valsetRequest/%d", storeKey, valsetNonce), nil)
+				if err != nil {
+					return err
+				}
+				if len(res) == 0 {
+					return fmt.Errorf("no valset request found for nonce %d", valsetNonce)
+				}
+				var valset types.Valset
+				cdc.MustUnmarshalJSON(res, &valset)
+				if checkpoint, err = batch.GetValsetAndBatchCheckpoint(params.PeggyID, valset); err != nil {
+					return err
+				}
+				kind = peggyutils.CheckpointValsetAndBatch
+			}
+
+			signature, err := signer.SignCheckpoint(peggyutils.SignRequest{
+				Kind:        kind,
+				Nonce:       batch.Nonce,
+				ValsetNonce: valsetNonce,
+				Hash:        checkpoint,
+			})
+			if err != nil {
+				return err
+			}
+			cosmosAddr := cliCtx.GetFromAddress()
+			// Make the message
+			msg := types.NewMsgConfirmBatch(batch.Nonce, valsetNonce, cosmosAddr, hex.EncodeToString(signature))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Int64(flagValsetNonce, 0, "sign the batch combined with the valset of this nonce for updateValsetAndSubmitBatch")
+	AddSignerFlags(cmd)
+	return cmd
 }
 
 func CmdUnsafeETHPrivKey() *cobra.Command {
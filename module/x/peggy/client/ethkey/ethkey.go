@@ -0,0 +1,103 @@
+// Package ethkey resolves references to the Ethereum key of a validator so that the key material
+// itself never has to be passed on the command line.
+//
+// A reference is either `keystore:<file>` for a go-ethereum encrypted keystore file, the passphrase
+// is prompted for, or `keyring:<name>` for a secp256k1 key stored in the Cosmos keyring. Signing
+// paths additionally accept `remote:<host:port>` for a key held by an ethsigner server.
+package ethkey
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/althea-net/peggy/module/ethsigner"
+	"github.com/althea-net/peggy/module/x/peggy/utils"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	cryptokeys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+const (
+	KeystorePrefix = "keystore:"
+	KeyringPrefix  = "keyring:"
+	RemotePrefix   = "remote:"
+)
+
+// LoadSigner resolves the reference to a checkpoint signer. Remote references connect to the
+// ethsigner server with the given TLS files, all others are loaded with Load and sign locally.
+func LoadSigner(ref string, kb cryptokeys.Keybase, buf *bufio.Reader, tlsFiles ethsigner.TLSFiles) (utils.Signer, error) {
+	if !strings.HasPrefix(ref, RemotePrefix) {
+		key, err := Load(ref, kb, buf)
+		if err != nil {
+			return nil, err
+		}
+		return utils.LocalSigner{Key: key}, nil
+	}
+	tlsCfg, err := tlsFiles.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return ethsigner.Dial(strings.TrimPrefix(ref, RemotePrefix), tlsCfg)
+}
+
+// Load resolves the key reference. The keybase is only used for keyring references and the reader
+// only to prompt for keystore passphrases.
+func Load(ref string, kb cryptokeys.Keybase, buf *bufio.Reader) (*ecdsa.PrivateKey, error) {
+	switch {
+	case strings.HasPrefix(ref, KeystorePrefix):
+		passphrase, err := input.GetPassword("Enter passphrase to decrypt the eth keystore:", buf)
+		if err != nil {
+			return nil, err
+		}
+		return FromKeystore(strings.TrimPrefix(ref, KeystorePrefix), passphrase)
+	case strings.HasPrefix(ref, KeyringPrefix):
+		return FromKeyring(kb, strings.TrimPrefix(ref, KeyringPrefix))
+	default:
+		return nil, fmt.Errorf("invalid eth key reference %q, expected %s<file> or %s<name>", ref, KeystorePrefix, KeyringPrefix)
+	}
+}
+
+// FromKeystore decrypts a go-ethereum keystore file
+func FromKeystore(path string, passphrase string) (*ecdsa.PrivateKey, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore %s: %w", path, err)
+	}
+	return key.PrivateKey, nil
+}
+
+// FromKeyring reads the eth key stored under the given name. Ethereum and Cosmos use the same curve
+// so the key is kept as a regular secp256k1 entry.
+func FromKeyring(kb cryptokeys.Keybase, name string) (*ecdsa.PrivateKey, error) {
+	if kb == nil {
+		return nil, fmt.Errorf("no keyring to load eth key %s from", name)
+	}
+	privKey, err := kb.ExportPrivateKeyObject(name, keys.DefaultKeyPass)
+	if err != nil {
+		return nil, err
+	}
+	secpKey, ok := privKey.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return nil, fmt.Errorf("key %s is not a secp256k1 key", name)
+	}
+	return ethCrypto.ToECDSA(secpKey[:])
+}
+
+// ImportToKeyring stores the eth key in the keyring under the given name
+func ImportToKeyring(kb cryptokeys.Keybase, name string, key *ecdsa.PrivateKey) error {
+	var secpKey secp256k1.PrivKeySecp256k1
+	copy(secpKey[:], ethCrypto.FromECDSA(key))
+	armor := mintkey.EncryptArmorPrivKey(secpKey, keys.DefaultKeyPass, string(cryptokeys.Secp256k1))
+	return kb.ImportPrivKey(name, armor, keys.DefaultKeyPass)
+}
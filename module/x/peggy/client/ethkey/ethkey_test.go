@@ -0,0 +1,69 @@
+package ethkey
+
+import (
+	"bufio"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	key, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Address:    ethCrypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, "my passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	keystoreFile := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, ioutil.WriteFile(keystoreFile, keyJSON, 0600))
+
+	kb := keys.NewInMemory()
+	require.NoError(t, ImportToKeyring(kb, "eth", key))
+
+	specs := map[string]struct {
+		ref    string
+		input  string
+		expErr bool
+	}{
+		"keystore": {
+			ref:   KeystorePrefix + keystoreFile,
+			input: "my passphrase\n",
+		},
+		"keystore with wrong passphrase": {
+			ref:    KeystorePrefix + keystoreFile,
+			input:  "wrong passphrase\n",
+			expErr: true,
+		},
+		"keyring": {
+			ref: KeyringPrefix + "eth",
+		},
+		"unknown keyring entry": {
+			ref:    KeyringPrefix + "other",
+			expErr: true,
+		},
+		"raw key material": {
+			ref:    "0x" + strings.Repeat("ab", 32),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			loaded, err := Load(spec.ref, kb, bufio.NewReader(strings.NewReader(spec.input)))
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ethCrypto.FromECDSA(key), ethCrypto.FromECDSA(loaded))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package client
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/client/cli"
+	"github.com/althea-net/peggy/module/x/peggy/client/rest"
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+)
+
+// ProposalHandler is the gov client handler of the bridge pause proposal
+var ProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitBridgePauseProposal, rest.ProposalRESTHandler)
+
+// TransferVetoProposalHandler is the gov client handler of the transfer veto proposal
+var TransferVetoProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitTransferVetoProposal, rest.TransferVetoProposalRESTHandler)
+
+// BlocklistProposalHandler is the gov client handler of the blocklist proposal
+var BlocklistProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitBlocklistProposal, rest.BlocklistProposalRESTHandler)
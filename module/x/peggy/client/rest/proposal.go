@@ -0,0 +1,107 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+)
+
+// BridgePauseProposalReq is a governance proposal to pause or resume the bridge
+type BridgePauseProposalReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Deposit     sdk.Coins    `json:"deposit"`
+	Paused      bool         `json:"paused"`
+}
+
+// TransferVetoProposalReq is a governance proposal to veto time locked transfers
+type TransferVetoProposalReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Deposit     sdk.Coins    `json:"deposit"`
+	IDs         []uint64     `json:"ids"`
+}
+
+// BlocklistProposalReq is a governance proposal to block or unblock addresses
+type BlocklistProposalReq struct {
+	BaseReq         rest.BaseReq       `json:"base_req"`
+	Title           string             `json:"title"`
+	Description     string             `json:"description"`
+	Deposit         sdk.Coins          `json:"deposit"`
+	Blocked         bool               `json:"blocked"`
+	EthAddresses    []types.EthAddress `json:"eth_addresses"`
+	CosmosAddresses []sdk.AccAddress   `json:"cosmos_addresses"`
+}
+
+// ProposalRESTHandler is the gov REST route of the bridge pause proposal
+func ProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "bridge_pause",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req BridgePauseProposalReq
+			if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+				return
+			}
+			content := types.NewBridgePauseProposal(req.Title, req.Description, req.Paused)
+			writeProposal(w, cliCtx, req.BaseReq, content, req.Deposit)
+		},
+	}
+}
+
+// TransferVetoProposalRESTHandler is the gov REST route of the transfer veto proposal
+func TransferVetoProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "transfer_veto",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req TransferVetoProposalReq
+			if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+				return
+			}
+			content := types.NewTransferVetoProposal(req.Title, req.Description, req.IDs)
+			writeProposal(w, cliCtx, req.BaseReq, content, req.Deposit)
+		},
+	}
+}
+
+// BlocklistProposalRESTHandler is the gov REST route of the blocklist proposal
+func BlocklistProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "blocklist",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req BlocklistProposalReq
+			if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+				return
+			}
+			content := types.NewBlocklistProposal(req.Title, req.Description, req.Blocked, req.EthAddresses, req.CosmosAddresses)
+			writeProposal(w, cliCtx, req.BaseReq, content, req.Deposit)
+		},
+	}
+}
+
+// writeProposal responds with the unsigned tx that submits the proposal
+func writeProposal(w http.ResponseWriter, cliCtx context.CLIContext, baseReq rest.BaseReq, content gov.Content, deposit sdk.Coins) {
+	baseReq = baseReq.Sanitize()
+	if !baseReq.ValidateBasic(w) {
+		return
+	}
+	fromAddr, err := sdk.AccAddressFromBech32(baseReq.From)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	msg := gov.NewMsgSubmitProposal(content, deposit, fromAddr)
+	if err := msg.ValidateBasic(); err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+}
@@ -11,6 +11,12 @@ import (
 const (
 	nonce                  = "nonce"
 	bech32ValidatorAddress = "bech32ValidatorAddress"
+	valsetNonce            = "valsetNonce"
+	height                 = "height"
+	denom                  = "denom"
+	bech32Address          = "bech32Address"
+	id                     = "id"
+	ethAddress             = "ethAddress"
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
@@ -18,10 +24,41 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string)
 	r.HandleFunc(fmt.Sprintf("/%s/current_valset", storeName), currentValsetHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/valset_request/{%s}", storeName, nonce), getValsetRequestHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/query_valset_confirm", storeName), getValsetConfirmHandler(cliCtx, storeName)).Methods("POST")
-	r.HandleFunc(fmt.Sprintf("/%s/update_ethaddr", storeName), updateEthAddressHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/update_ethaddr", storeName), updateEthAddressHandler(cliCtx, storeName)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/valset_request", storeName), createValsetRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/valset_confirm", storeName), createValsetConfirmHandler(cliCtx, storeName)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/valset_confirm/{%s}", storeName, nonce), allValsetConfirmsHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/valset_requests", storeName), lastValsetRequestsHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/pending_valset_requests/{%s}", storeName, bech32ValidatorAddress), lastValsetRequestsByAddressHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/batch/{%s}", storeName, nonce), getOutgoingTxBatchHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/pending_batches", storeName), pendingOutgoingTxBatchesHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/submit_batch_payload/{%s}", storeName, nonce), submitBatchPayloadHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/update_valset_and_submit_batch_payload/{%s}/{%s}", storeName, nonce, valsetNonce), updateValsetAndSubmitBatchPayloadHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/eth_registrations", storeName), ethAddressRegistrationsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/eth_address_history/{%s}", storeName, bech32ValidatorAddress), ethAddressHistoryHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/latest_valset_nonce", storeName), latestValsetNonceHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/valset_nonce/{%s}", storeName, height), valsetNonceByHeightHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/last_observed_valset_nonce", storeName), lastObservedValsetNonceHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/rate_limits", storeName), rateLimitsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/rate_limits/{%s}", storeName, denom), rateLimitsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/fee_estimate/{%s}", storeName, denom), feeEstimateHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/time_locked_transfers", storeName), timeLockedTransfersHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/blocklist", storeName), blocklistHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/held_deposits", storeName), heldDepositsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/held_deposits/{%s}", storeName, bech32Address), heldDepositsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/transfer_status/{%s}", storeName, id), transferStatusHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/transfers_by_sender/{%s}", storeName, bech32Address), transfersBySenderHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/transfers_by_recipient/{%s}", storeName, ethAddress), transfersByRecipientHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/deposit_status/{%s}", storeName, id), depositStatusHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/deposits_by_recipient/{%s}", storeName, bech32Address), depositsByRecipientHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/eth_block", storeName), ethBlockHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/eth_block_attestations", storeName), ethBlockAttestationsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/gas_price", storeName), gasPriceHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/gas_price_attestations", storeName), gasPriceAttestationsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/erc20/{%s}", storeName, denom), cosmosERC20Handler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/erc20s", storeName), cosmosERC20sHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/params", storeName), paramsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/pause_state", storeName), pauseStateHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/valset_retention", storeName), valsetRetentionHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/eth_registration/{%s}", storeName, bech32ValidatorAddress), ethAddressRegistrationHandler(cliCtx, storeName)).Methods("GET")
 }
@@ -18,11 +18,14 @@ import (
 
 type updateEthAddressReq struct {
 	BaseReq rest.BaseReq `json:"base_req"`
+	Nonce   uint64       `json:"nonce"`
 	EthSig  string       `json:"ethSig"`
 }
 
-// accepts a sig proving that the given Cosmos address is owned by a given ethereum key
-func updateEthAddressHandler(cliCtx context.CLIContext) http.HandlerFunc {
+// accepts a sig proving that the given Cosmos address is owned by a given ethereum key. The sig is
+// over the EthAddressRegistrationHash of the peggy id, the chain id of the base request, the Cosmos
+// address and the nonce, which is the number of earlier registrations of the address.
+func updateEthAddressHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req updateEthAddressReq
 
@@ -36,9 +39,23 @@ func updateEthAddressHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		res, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/params", storeName))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		var params types.Params
+		if err := cliCtx.Codec.UnmarshalJSON(res, &params); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
 		cosmosAddr := cliCtx.GetFromAddress()
-		// the signed message should be the hash of the presented CosmosAddr
-		ethHash := ethCrypto.Keccak256Hash(cosmosAddr)
+		ethHash, err := types.EthAddressRegistrationHash(params.PeggyID, baseReq.ChainID, cosmosAddr, req.Nonce)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
 		ethSig, err := hexUtil.Decode(req.EthSig)
 		if err != nil {
@@ -46,23 +63,22 @@ func updateEthAddressHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 		// we recover the address and public key from the sig
-		ethPubkey, err := ethCrypto.SigToPub(ethHash.Bytes(), ethSig)
+		ethPubkey, err := ethCrypto.SigToPub(ethHash, ethSig)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 		ethPubkeyBytes := ethCrypto.FromECDSAPub(ethPubkey)
 		ethAddr := ethCrypto.PubkeyToAddress(*ethPubkey)
-		correct := ethCrypto.VerifySignature(ethPubkeyBytes, ethHash.Bytes(), ethSig)
+		correct := ethCrypto.VerifySignature(ethPubkeyBytes, ethHash, ethSig)
 		if correct == false {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		// Make the message, we convert the recovered address into a string
-		// so at this point we have verified that this address signed this
-		// cosmos address
-		msg := types.NewMsgSetEthAddress(ethAddr.String(), cosmosAddr, hex.EncodeToString(ethSig))
+		// Make the message, at this point we have verified that this address
+		// signed this cosmos address
+		msg := types.NewMsgSetEthAddress(types.EthAddressFromCommon(ethAddr), cosmosAddr, req.Nonce, hex.EncodeToString(ethSig))
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -130,7 +146,21 @@ func createValsetConfirmHandler(cliCtx context.CLIContext, storeKey string) http
 		}
 		var valset types.Valset
 		cliCtx.Codec.MustUnmarshalJSON(res, &valset)
-		checkpoint := valset.GetCheckpoint()
+		res, _, err = cliCtx.Query(fmt.Sprintf("custom/%s/params", storeKey))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		var params types.Params
+		if err := cliCtx.Codec.UnmarshalJSON(res, &params); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		checkpoint, err := valset.GetCheckpoint(params.PeggyID)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
 		// the signed message should be the hash of the checkpoint at the given nonce
 		ethHash := ethCrypto.Keccak256Hash(checkpoint)
@@ -3,6 +3,7 @@ package peggy
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/althea-net/peggy/module/x/peggy/types"
 	"github.com/althea-net/peggy/module/x/peggy/utils"
@@ -30,6 +31,20 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgBatchInChain(ctx, keeper, msg)
 		case MsgEthDeposit:
 			return handleMsgEthDeposit(ctx, keeper, msg)
+		case MsgRotateEthAddress:
+			return handleMsgRotateEthAddress(ctx, keeper, msg)
+		case MsgValsetObserved:
+			return handleMsgValsetObserved(ctx, keeper, msg)
+		case MsgEthBlockObserved:
+			return handleMsgEthBlockObserved(ctx, keeper, msg)
+		case MsgGasPriceObserved:
+			return handleMsgGasPriceObserved(ctx, keeper, msg)
+		case MsgERC20Deployed:
+			return handleMsgERC20Deployed(ctx, keeper, msg)
+		case MsgPauseVote:
+			return handleMsgPauseVote(ctx, keeper, msg)
+		case MsgVetoTransfer:
+			return handleMsgVetoTransfer(ctx, keeper, msg)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("Unrecognized Peggy Msg type: %v", msg.Type()))
 		}
@@ -37,8 +52,16 @@ func NewHandler(keeper Keeper) sdk.Handler {
 }
 
 func handleMsgValsetRequest(ctx sdk.Context, keeper Keeper, msg types.MsgValsetRequest) (*sdk.Result, error) {
-	keeper.SetValsetRequest(ctx)
-	return &sdk.Result{}, nil
+	if err := keeper.ApplyValsetRequestPolicy(ctx, msg.Requester); err != nil {
+		return nil, err
+	}
+	valset, err := keeper.SetValsetRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.Result{
+		Data: sdk.Uint64ToBigEndian(uint64(valset.Nonce)),
+	}, nil
 }
 
 func handleMsgValsetConfirm(ctx sdk.Context, keeper Keeper, msg MsgValsetConfirm) (*sdk.Result, error) {
@@ -48,57 +71,272 @@ func handleMsgValsetConfirm(ctx sdk.Context, keeper Keeper, msg MsgValsetConfirm
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown nonce")
 	}
 
-	checkpoint := valset.GetCheckpoint()
-	ethAddress := keeper.GetEthAddress(ctx, msg.Validator)
-	if len(ethAddress) == 0 {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty eth address")
+	checkpoint, err := valset.GetCheckpoint(keeper.GetParams(ctx).PeggyID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validateConfirmSig(ctx, keeper, msg.Validator, checkpoint, msg.Signature); err != nil {
+		return nil, sdkerrors.Wrap(err, "Failed to validate Checkpoint Sig")
 	}
 
-	sigBytes, hexErr := hex.DecodeString(msg.Signature)
+	// Save valset confirmation
+	keeper.SetValsetConfirm(ctx, msg)
+	return &sdk.Result{}, nil
+}
+
+// validateConfirmSig checks that the signature over the checkpoint was made with one of the eth keys
+// the confirms of the validator are accepted from and returns the eth address of that key
+func validateConfirmSig(ctx sdk.Context, keeper Keeper, validator sdk.AccAddress, checkpoint []byte, signature string) (types.EthAddress, error) {
+	ethAddresses := keeper.GetSigningEthAddresses(ctx, validator)
+	if len(ethAddresses) == 0 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty eth address")
+	}
+	sigBytes, hexErr := hex.DecodeString(signature)
 	if hexErr != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Signature hex decoding error")
 	}
-	err := utils.ValidateEthSig(checkpoint, sigBytes, ethAddress)
+	for _, ethAddress := range ethAddresses {
+		if utils.ValidateEthSig(checkpoint, sigBytes, ethAddress.Address()) == nil {
+			return ethAddress, nil
+		}
+	}
+	return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "signature does not match an eth address of the validator")
+}
+
+func handleMsgSetEthAddress(ctx sdk.Context, keeper Keeper, msg MsgSetEthAddress) (*sdk.Result, error) {
+	if !keeper.GetEthAddress(ctx, msg.Validator).Empty() {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "eth address registered already, rotate it with MsgRotateEthAddress")
+	}
+	if err := validateEthAddressRegistration(ctx, keeper, msg.Validator, msg.Address, msg.Nonce, msg.Signature); err != nil {
+		return nil, err
+	}
+
+	keeper.SetEthAddress(ctx, msg.Validator, msg.Address)
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRotateEthAddress(ctx sdk.Context, keeper Keeper, msg MsgRotateEthAddress) (*sdk.Result, error) {
+	current := keeper.GetEthAddress(ctx, msg.Validator)
+	if current.Empty() {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "no eth address registered, register one with MsgSetEthAddress")
+	}
+	if msg.Address.Equals(current) {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "eth address is the current one")
+	}
+	if err := validateEthAddressRegistration(ctx, keeper, msg.Validator, msg.Address, msg.Nonce, msg.Signature); err != nil {
+		return nil, err
+	}
+
+	keeper.SetPendingEthAddress(ctx, msg.Validator, msg.Address)
+	return &sdk.Result{}, nil
+}
+
+// validateEthAddressRegistration checks that the key of the eth address signed its registration for
+// the validator with the next registration nonce and that no other validator claimed it
+func validateEthAddressRegistration(ctx sdk.Context, keeper Keeper, validator sdk.AccAddress, ethAddress types.EthAddress, nonce uint64, signature string) error {
+	if expected := keeper.GetEthAddressNonce(ctx, validator); nonce != expected {
+		return sdkerrors.Wrapf(types.ErrInvalid, "registration nonce %d, expected %d", nonce, expected)
+	}
+	hash, err := types.EthAddressRegistrationHash(keeper.GetParams(ctx).PeggyID, ctx.ChainID(), validator, nonce)
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Failed to validate Checkpoint Sig")
+		return err
+	}
+	sigBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Signature hex decoding error")
+	}
+	if err := utils.ValidateEthSig(hash, sigBytes, ethAddress.Address()); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "signature does not match eth address")
 	}
+	if owner := keeper.GetEthAddressOwner(ctx, ethAddress); owner != nil && !owner.Equals(validator) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "eth address %s claimed by another validator", ethAddress)
+	}
+	return nil
+}
 
-	// Save valset confirmation
-	keeper.SetValsetConfirm(ctx, msg)
+func handleMsgValsetObserved(ctx sdk.Context, keeper Keeper, msg MsgValsetObserved) (*sdk.Result, error) {
+	if keeper.GetValsetRequest(ctx, msg.Nonce) == nil {
+		return nil, sdkerrors.Wrap(types.ErrUnknown, "valset nonce")
+	}
+	if keeper.GetValidatorRegistration(ctx, sdk.ValAddress(msg.Validator)) == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bonded validator")
+	}
+	keeper.SetValsetObservation(ctx, msg)
 	return &sdk.Result{}, nil
 }
 
-func handleMsgSetEthAddress(ctx sdk.Context, keeper Keeper, msg MsgSetEthAddress) (*sdk.Result, error) {
-	keeper.SetEthAddress(ctx, msg.Validator, msg.Address)
+func handleMsgEthBlockObserved(ctx sdk.Context, keeper Keeper, msg MsgEthBlockObserved) (*sdk.Result, error) {
+	if keeper.GetValidatorRegistration(ctx, sdk.ValAddress(msg.Validator)) == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bonded validator")
+	}
+	if err := keeper.SetEthBlockAttestation(ctx, msg); err != nil {
+		return nil, err
+	}
 	return &sdk.Result{}, nil
 }
 
-func handleMsgSendToEth(ctx sdk.Context, keeper Keeper, msg MsgSendToEth) (*sdk.Result, error) {
-	// TODO add this transcation to the Peggy Tx Pool
+func handleMsgGasPriceObserved(ctx sdk.Context, keeper Keeper, msg MsgGasPriceObserved) (*sdk.Result, error) {
+	if keeper.GetValidatorRegistration(ctx, sdk.ValAddress(msg.Validator)) == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bonded validator")
+	}
+	keeper.SetGasPriceAttestation(ctx, msg)
 	return &sdk.Result{}, nil
 }
 
-func handleMsgRequestBatch(ctx sdk.Context, keeper Keeper, msg MsgRequestBatch) (*sdk.Result, error) {
-	// TODO perform the batch creation process here, including pulling transactions out of
-	// the Peggy Tx Pool and bundling them into transactions
+func handleMsgERC20Deployed(ctx sdk.Context, keeper Keeper, msg MsgERC20Deployed) (*sdk.Result, error) {
+	if keeper.GetValidatorRegistration(ctx, sdk.ValAddress(msg.Validator)) == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bonded validator")
+	}
+	erc20, err := keeper.ClaimERC20Deployed(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if erc20 == nil {
+		return &sdk.Result{}, nil
+	}
+	return &sdk.Result{
+		Log: fmt.Sprintf("%s is represented by %s", erc20.Denom, erc20.TokenContract),
+	}, nil
+}
+
+func handleMsgPauseVote(ctx sdk.Context, keeper Keeper, msg MsgPauseVote) (*sdk.Result, error) {
+	if keeper.GetValidatorRegistration(ctx, sdk.ValAddress(msg.Validator)) == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bonded validator")
+	}
+	if err := keeper.AssertNotPaused(ctx); err != nil {
+		return nil, err
+	}
+	keeper.SetPauseVote(ctx, msg.Validator)
 	return &sdk.Result{}, nil
 }
 
+func handleMsgVetoTransfer(ctx sdk.Context, keeper Keeper, msg MsgVetoTransfer) (*sdk.Result, error) {
+	if keeper.GetValidatorRegistration(ctx, sdk.ValAddress(msg.Validator)) == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bonded validator")
+	}
+	if _, err := keeper.SetTransferVeto(ctx, msg.ID, msg.Validator); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSendToEth(ctx sdk.Context, keeper Keeper, msg MsgSendToEth) (*sdk.Result, error) {
+	if err := keeper.AssertNotPaused(ctx); err != nil {
+		return nil, err
+	}
+	if err := keeper.AssertNotBlocked(ctx, msg.Sender, msg.DestAddress); err != nil {
+		return nil, err
+	}
+	if err := keeper.AssertBridgeable(ctx, msg.Send.Denom); err != nil {
+		return nil, err
+	}
+	// the dust below one unit of the ERC20 is not taken from the sender
+	send, dust := keeper.SplitDust(ctx, msg.Send)
+	if !send.IsPositive() {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "%s is below one unit of the erc20", msg.Send)
+	}
+	fee := msg.BridgeFee
+	if fee.Denom == send.Denom {
+		var feeDust sdk.Coin
+		fee, feeDust = keeper.SplitDust(ctx, fee)
+		dust = dust.Add(feeDust)
+	}
+	if err := keeper.CheckBridgeFee(ctx, send, fee); err != nil {
+		return nil, err
+	}
+	// fees in other denoms do not leave Cosmos
+	outflow := send
+	if fee.Denom == send.Denom {
+		outflow = outflow.Add(fee)
+	}
+	if err := keeper.ConsumeRateLimit(ctx, types.RateLimitOutflow, outflow); err != nil {
+		return nil, err
+	}
+	if err := keeper.EscrowTransfer(ctx, msg.Sender, send, fee); err != nil {
+		return nil, err
+	}
+	var logs []string
+	if dust.IsPositive() {
+		logs = append(logs, fmt.Sprintf("dust %s not sent", dust))
+	}
+	if keeper.IsTimeLocked(ctx, send) {
+		locked := keeper.AddTimeLockedTransfer(ctx, msg.Sender, msg.DestAddress, send, fee)
+		logs = append(logs, fmt.Sprintf("time locked transfer %d until height %d", locked.Transfer.ID, locked.ReleaseHeight))
+		return &sdk.Result{
+			Data: sdk.Uint64ToBigEndian(locked.Transfer.TransferID),
+			Log:  strings.Join(logs, "; "),
+		}, nil
+	}
+	tx := keeper.AddToOutgoingPool(ctx, msg.Sender, msg.DestAddress, send, fee)
+	return &sdk.Result{
+		Data: sdk.Uint64ToBigEndian(tx.TransferID),
+		Log:  strings.Join(logs, "; "),
+	}, nil
+}
+
+func handleMsgRequestBatch(ctx sdk.Context, keeper Keeper, msg MsgRequestBatch) (*sdk.Result, error) {
+	batch, err := keeper.BuildOutgoingTXBatch(ctx, msg.Denom)
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.Result{
+		Data: sdk.Uint64ToBigEndian(uint64(batch.Nonce)),
+	}, nil
+}
+
 func handleMsgConfirmBatch(ctx sdk.Context, keeper Keeper, msg MsgConfirmBatch) (*sdk.Result, error) {
-	// TODO add batch confirmation to the store, and if this confirmation means the batch counts as
-	// `observed` (confirmations from 66% of the active voting power exist as of this block) then consider
-	// the batch completed.
+	// Check that the signature is valid for the batch, or the batch combined with the new valset
+	batch := keeper.GetOutgoingTXBatch(ctx, msg.Nonce)
+	if batch == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown batch nonce")
+	}
+
+	peggyID := keeper.GetParams(ctx).PeggyID
+	checkpoint, err := batch.GetCheckpoint(peggyID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.ValsetNonce != 0 {
+		valset := keeper.GetValsetRequest(ctx, msg.ValsetNonce)
+		if valset == nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown valset nonce")
+		}
+		if valset.Nonce <= batch.ValsetNonce {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "valset nonce must be greater than the batch valset nonce")
+		}
+		if checkpoint, err = batch.GetValsetAndBatchCheckpoint(peggyID, *valset); err != nil {
+			return nil, err
+		}
+	}
+
+	ethSigner, err := validateConfirmSig(ctx, keeper, msg.Validator, checkpoint, msg.Signature)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "Failed to validate Batch Checkpoint Sig")
+	}
+
+	keeper.SetBatchConfirm(ctx, msg, ethSigner)
+	keeper.UpdateBatchSigned(ctx, msg.Nonce, msg.ValsetNonce)
 	return &sdk.Result{}, nil
 }
 
 func handleMsgBatchInChain(ctx sdk.Context, keeper Keeper, msg MsgBatchInChain) (*sdk.Result, error) {
-	// TODO add batch confirmation to the store, and if this confirmation means the batch counts as
-	// `observed` (confirmations from 66% of the active voting power exist as of this block) then consider
-	// the batch completed.
+	if keeper.GetValidatorRegistration(ctx, sdk.ValAddress(msg.Validator)) == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bonded validator")
+	}
+	if _, err := keeper.SetBatchInChain(ctx, msg.Nonce, msg.Validator); err != nil {
+		return nil, err
+	}
 	return &sdk.Result{}, nil
 }
 
 func handleMsgEthDeposit(ctx sdk.Context, keeper Keeper, msg MsgEthDeposit) (*sdk.Result, error) {
-	// TODO issue tokens from the store of the appropriate denom once this deposit counts as `observed`
-	return &sdk.Result{}, nil
+	if keeper.GetValidatorRegistration(ctx, sdk.ValAddress(msg.Validator)) == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bonded validator")
+	}
+	// claims are recorded while the bridge is paused, only the mint of the observed deposit waits
+	deposit := keeper.ClaimDeposit(ctx, msg)
+	return &sdk.Result{
+		Data: sdk.Uint64ToBigEndian(deposit.ID),
+		Log:  fmt.Sprintf("deposit %d %s", deposit.ID, deposit.Status),
+	}, nil
 }
@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SetEthAddressBlocked adds the eth address to the blocklist or removes it from it
+func (k Keeper) SetEthAddressBlocked(ctx sdk.Context, ethAddr types.EthAddress, blocked bool) {
+	setBlocked(ctx.KVStore(k.storeKey), types.GetBlockedEthAddressKey(ethAddr), blocked)
+}
+
+// IsEthAddressBlocked returns true when the eth address is on the blocklist
+func (k Keeper) IsEthAddressBlocked(ctx sdk.Context, ethAddr types.EthAddress) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetBlockedEthAddressKey(ethAddr))
+}
+
+// SetCosmosAddressBlocked adds the cosmos address to the blocklist or removes it from it. Deposits
+// held for the address stay held until they are released with ReleaseHeldDeposits.
+func (k Keeper) SetCosmosAddressBlocked(ctx sdk.Context, addr sdk.AccAddress, blocked bool) {
+	setBlocked(ctx.KVStore(k.storeKey), types.GetBlockedCosmosKey(addr), blocked)
+}
+
+// IsCosmosAddressBlocked returns true when the cosmos address is on the blocklist
+func (k Keeper) IsCosmosAddressBlocked(ctx sdk.Context, addr sdk.AccAddress) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetBlockedCosmosKey(addr))
+}
+
+// AssertNotBlocked returns types.ErrBlocked when the sender or the destination of a transfer to
+// Ethereum is on the blocklist
+func (k Keeper) AssertNotBlocked(ctx sdk.Context, sender sdk.AccAddress, destAddress types.EthAddress) error {
+	if k.IsCosmosAddressBlocked(ctx, sender) {
+		return sdkerrors.Wrapf(types.ErrBlocked, "sender %s", sender)
+	}
+	if k.IsEthAddressBlocked(ctx, destAddress) {
+		return sdkerrors.Wrapf(types.ErrBlocked, "destination %s", destAddress)
+	}
+	return nil
+}
+
+// GetBlocklist returns the blocked addresses in the order of their bytes
+func (k Keeper) GetBlocklist(ctx sdk.Context) types.Blocklist {
+	store := ctx.KVStore(k.storeKey)
+	res := types.Blocklist{
+		EthAddresses:    []types.EthAddress{},
+		CosmosAddresses: []sdk.AccAddress{},
+	}
+	for _, key := range prefixKeys(store, types.BlockedEthAddressKey) {
+		res.EthAddresses = append(res.EthAddresses, types.EthAddress(key))
+	}
+	for _, key := range prefixKeys(store, types.BlockedCosmosKey) {
+		res.CosmosAddresses = append(res.CosmosAddresses, sdk.AccAddress(key))
+	}
+	return res
+}
+
+// HoldDeposit records a deposit that was minted into the module account for a blocked recipient
+func (k Keeper) HoldDeposit(ctx sdk.Context, depositID uint64, recipient sdk.AccAddress, amount sdk.Coin) types.HeldDeposit {
+	held := types.HeldDeposit{
+		ID:        k.autoIncrementID(ctx, types.KeyLastHeldDepositID),
+		Recipient: recipient,
+		Amount:    amount,
+		Height:    ctx.BlockHeight(),
+		DepositID: depositID,
+	}
+	k.setHeldDeposit(ctx, held)
+	return held
+}
+
+func (k Keeper) setHeldDeposit(ctx sdk.Context, held types.HeldDeposit) {
+	ctx.KVStore(k.storeKey).Set(types.GetHeldDepositKey(held.Recipient, held.ID), k.cdc.MustMarshalBinaryBare(held))
+}
+
+// GetHeldDeposits returns the deposits held for the recipient, or for all recipients with an empty
+// one, in the order of recipient and ID
+func (k Keeper) GetHeldDeposits(ctx sdk.Context, recipient sdk.AccAddress) []types.HeldDeposit {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetHeldDepositPrefix(recipient))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	res := []types.HeldDeposit{}
+	for ; iter.Valid(); iter.Next() {
+		var held types.HeldDeposit
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &held)
+		res = append(res, held)
+	}
+	return res
+}
+
+// ReleaseHeldDeposits pays the deposits held for the recipient out to it. It returns
+// types.ErrBlocked while the recipient is still on the blocklist.
+func (k Keeper) ReleaseHeldDeposits(ctx sdk.Context, recipient sdk.AccAddress) error {
+	if k.IsCosmosAddressBlocked(ctx, recipient) {
+		return sdkerrors.Wrapf(types.ErrBlocked, "recipient %s", recipient)
+	}
+	held := k.GetHeldDeposits(ctx, recipient)
+	if len(held) == 0 {
+		return nil
+	}
+	store := ctx.KVStore(k.storeKey)
+	amount := sdk.NewCoins()
+	for _, h := range held {
+		store.Delete(types.GetHeldDepositKey(recipient, h.ID))
+		amount = amount.Add(h.Amount)
+		if deposit := k.GetDepositStatus(ctx, h.DepositID); deposit != nil {
+			deposit.Status = types.DepositMinted
+			deposit.HeldDepositID = 0
+			deposit.Height = ctx.BlockHeight()
+			k.setDepositStatus(ctx, *deposit)
+		}
+	}
+	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, amount)
+}
+
+func setBlocked(store sdk.KVStore, key []byte, blocked bool) {
+	if blocked {
+		store.Set(key, []byte{1})
+	} else {
+		store.Delete(key)
+	}
+}
+
+// prefixKeys returns the keys under the prefix without it
+func prefixKeys(store sdk.KVStore, keyPrefix []byte) [][]byte {
+	iter := prefix.NewStore(store, keyPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	return keys
+}
@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocklist(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	sender := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	other := sdk.AccAddress(bytes.Repeat([]byte{8}, sdk.AddrLen))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	blockedDest := ethAddr("0x7c2C195CD6D34B8F845992d380aADB2730bB9C6F")
+	_, err := keepers.BankKeeper.AddCoins(ctx, sender, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 5000)))
+	require.NoError(t, err)
+
+	// transfers in the pool to an address blocked later on stay in the pool
+	send, fee := sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 10)
+	AddEscrowedTransfer(t, k, ctx, sender, blockedDest, send, fee)
+	AddEscrowedTransfer(t, k, ctx, sender, dest, send, fee)
+
+	k.SetEthAddressBlocked(ctx, blockedDest, true)
+	k.SetCosmosAddressBlocked(ctx, other, true)
+	assert.Equal(t, types.Blocklist{
+		EthAddresses:    []types.EthAddress{blockedDest},
+		CosmosAddresses: []sdk.AccAddress{other},
+	}, k.GetBlocklist(ctx))
+
+	assert.NoError(t, k.AssertNotBlocked(ctx, sender, dest))
+	err = k.AssertNotBlocked(ctx, sender, blockedDest)
+	assert.True(t, types.ErrBlocked.Is(err), err)
+	err = k.AssertNotBlocked(ctx, other, dest)
+	assert.True(t, types.ErrBlocked.Is(err), err)
+
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	require.Len(t, batch.Elements, 1)
+	assert.Equal(t, dest, batch.Elements[0].DestAddress)
+	_, err = k.BuildOutgoingTXBatch(ctx, "mytoken")
+	assert.True(t, types.ErrEmpty.Is(err), err)
+
+	k.SetEthAddressBlocked(ctx, blockedDest, false)
+	batch, err = k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	assert.Equal(t, blockedDest, batch.Elements[0].DestAddress)
+}
+
+func TestMintDepositHeldForBlockedRecipient(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	blocked := sdk.AccAddress(bytes.Repeat([]byte{8}, sdk.AddrLen))
+	k.SetCosmosAddressBlocked(ctx, blocked, true)
+
+	held, err := k.MintDeposit(ctx, 0, recipient, sdk.NewInt64Coin("mytoken", 100))
+	require.NoError(t, err)
+	assert.Nil(t, held)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 100)), keepers.BankKeeper.GetCoins(ctx, recipient))
+
+	for _, amount := range []int64{200, 300} {
+		held, err = k.MintDeposit(ctx, 0, blocked, sdk.NewInt64Coin("mytoken", amount))
+		require.NoError(t, err)
+		require.NotNil(t, held)
+	}
+	assert.Equal(t, uint64(2), held.ID)
+	assert.True(t, keepers.BankKeeper.GetCoins(ctx, blocked).IsZero())
+	assert.Len(t, k.GetHeldDeposits(ctx, blocked), 2)
+	assert.Len(t, k.GetHeldDeposits(ctx, nil), 2)
+	assert.Empty(t, k.GetHeldDeposits(ctx, recipient))
+
+	err = k.ReleaseHeldDeposits(ctx, blocked)
+	assert.True(t, types.ErrBlocked.Is(err), err)
+
+	k.SetCosmosAddressBlocked(ctx, blocked, false)
+	require.NoError(t, k.ReleaseHeldDeposits(ctx, blocked))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 500)), keepers.BankKeeper.GetCoins(ctx, blocked))
+	assert.Empty(t, k.GetHeldDeposits(ctx, nil))
+}
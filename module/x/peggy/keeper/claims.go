@@ -0,0 +1,187 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxDepositsMintedPerBlock bounds the observed deposits the end blocker retries in one block
+const maxDepositsMintedPerBlock = 20
+
+// isTransientMintError returns true when the mint of a deposit failed for a reason that passes,
+// the bridge is paused or the mint rate limit of the denom is used up
+func isTransientMintError(err error) bool {
+	return types.ErrPaused.Is(err) || types.ErrRateLimited.Is(err)
+}
+
+// GetDepositStatus returns the status of the deposit from Ethereum with the id or nil
+func (k Keeper) GetDepositStatus(ctx sdk.Context, id uint64) *types.DepositStatus {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetDepositStatusKey(id))
+	if bz == nil {
+		return nil
+	}
+	var status types.DepositStatus
+	k.cdc.MustUnmarshalBinaryBare(bz, &status)
+	return &status
+}
+
+// ClaimDeposit records the claim of the validator that the deposit happened on Ethereum. Claims
+// are recorded while the bridge is paused. Once the claiming validators hold a quorum of the
+// bonded power the deposit is observed and minted. When minting fails for a transient reason the
+// deposit stays observed and MintObservedDeposits tries again, otherwise it failed.
+func (k Keeper) ClaimDeposit(ctx sdk.Context, msg types.MsgEthDeposit) types.DepositStatus {
+	store := ctx.KVStore(k.storeKey)
+	hashKey := types.GetDepositByClaimHashKey(types.DepositClaimHash(msg))
+	var deposit types.DepositStatus
+	if bz := store.Get(hashKey); bz != nil {
+		deposit = *k.GetDepositStatus(ctx, binary.BigEndian.Uint64(bz))
+	} else {
+		deposit = types.DepositStatus{
+			ID:        k.autoIncrementID(ctx, types.KeyLastDepositID),
+			EthTxHash: common.HexToHash(msg.EthTxHash).Hex(),
+			LogIndex:  msg.LogIndex,
+			Recipient: msg.Destination,
+			Amount:    msg.Amount,
+			Status:    types.DepositClaimed,
+			Height:    ctx.BlockHeight(),
+		}
+		store.Set(hashKey, sdk.Uint64ToBigEndian(deposit.ID))
+		store.Set(types.GetDepositByRecipientKey(deposit.Recipient, deposit.ID), []byte{1})
+	}
+	store.Set(types.GetDepositClaimKey(deposit.ID, msg.Validator), []byte{1})
+
+	if deposit.Status == types.DepositClaimed {
+		var power int64
+		for _, r := range k.GetValidatorRegistrations(ctx) {
+			if store.Has(types.GetDepositClaimKey(deposit.ID, sdk.AccAddress(r.Validator))) {
+				power += r.NormalizedPower
+			}
+		}
+		if types.HasQuorum(power) {
+			deposit.Status = types.DepositObserved
+			deposit.Height = ctx.BlockHeight()
+			store.Set(types.GetObservedDepositKey(deposit.ID), []byte{1})
+			k.mintObservedDeposit(ctx, &deposit)
+		}
+	}
+	k.setDepositStatus(ctx, deposit)
+	return deposit
+}
+
+// MintObservedDeposits tries again to mint up to maxDepositsMintedPerBlock of the deposits that
+// were observed but could not be minted. It continues after the deposit it tried last in the
+// previous block and wraps around at the end of the queue, so that deposits that keep hitting the
+// rate limit do not hold back the ones behind them. Nothing is tried while the bridge is paused.
+func (k Keeper) MintObservedDeposits(ctx sdk.Context) {
+	if k.GetPauseState(ctx).Paused {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	var cursor uint64
+	if bz := store.Get(types.KeyDepositMintCursor); bz != nil {
+		cursor = binary.BigEndian.Uint64(bz)
+	}
+	prefixStore := prefix.NewStore(store, types.ObservedDepositKey)
+	ids := observedDepositIDs(prefixStore, sdk.Uint64ToBigEndian(cursor+1), nil, maxDepositsMintedPerBlock)
+	if len(ids) < maxDepositsMintedPerBlock {
+		ids = append(ids, observedDepositIDs(prefixStore, nil, sdk.Uint64ToBigEndian(cursor+1), maxDepositsMintedPerBlock-len(ids))...)
+	}
+	for _, id := range ids {
+		deposit := k.GetDepositStatus(ctx, id)
+		k.mintObservedDeposit(ctx, deposit)
+		k.setDepositStatus(ctx, *deposit)
+	}
+	if len(ids) != 0 {
+		store.Set(types.KeyDepositMintCursor, sdk.Uint64ToBigEndian(ids[len(ids)-1]))
+	}
+}
+
+// observedDepositIDs returns up to limit IDs of the queue of observed deposits in [start, end)
+func observedDepositIDs(prefixStore prefix.Store, start, end []byte, limit int) []uint64 {
+	var ids []uint64
+	iter := prefixStore.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid() && len(ids) < limit; iter.Next() {
+		ids = append(ids, binary.BigEndian.Uint64(iter.Key()))
+	}
+	return ids
+}
+
+// mintObservedDeposit converts the deposit to units of the denom and mints it in a cached
+// context, so that a failed mint does not undo the claim that observed it. The dust below one unit
+// of the denom stays in the contract. A minted deposit leaves the queue of observed deposits, as
+// does one that failed for a reason that does not pass.
+func (k Keeper) mintObservedDeposit(ctx sdk.Context, deposit *types.DepositStatus) {
+	denom := deposit.Amount.Denom
+	amount, dust := k.GetParams(ctx).GetTokenDecimals(denom).ToCosmos(deposit.Amount.Amount)
+	cacheCtx, write := ctx.CacheContext()
+	var held *types.HeldDeposit
+	if amount.IsPositive() {
+		var err error
+		held, err = k.MintDeposit(cacheCtx, deposit.ID, deposit.Recipient, sdk.NewCoin(denom, amount))
+		if err != nil {
+			k.Logger(ctx).Info("deposit not minted", "id", deposit.ID, "err", err)
+			if isTransientMintError(err) {
+				return
+			}
+			ctx.KVStore(k.storeKey).Delete(types.GetObservedDepositKey(deposit.ID))
+			deposit.Status = types.DepositFailed
+			deposit.Error = err.Error()
+			deposit.Height = ctx.BlockHeight()
+			return
+		}
+	}
+	k.addDust(cacheCtx, denom, sdk.ZeroInt(), dust)
+	write()
+	ctx.KVStore(k.storeKey).Delete(types.GetObservedDepositKey(deposit.ID))
+	deposit.Minted = sdk.NewCoin(denom, amount)
+	deposit.Status = types.DepositMinted
+	if held != nil {
+		deposit.Status = types.DepositHeld
+		deposit.HeldDepositID = held.ID
+	}
+	deposit.Height = ctx.BlockHeight()
+}
+
+func (k Keeper) setDepositStatus(ctx sdk.Context, deposit types.DepositStatus) {
+	ctx.KVStore(k.storeKey).Set(types.GetDepositStatusKey(deposit.ID), k.cdc.MustMarshalBinaryBare(deposit))
+}
+
+// SetBatchInChain records the attestation of the validator that the batch was executed on
+// Ethereum, it returns true once the attesting validators hold a quorum of the bonded power.
+// Attestations are recorded while the bridge is paused. The transfers of the batch are submitted
+// with the first attestation and observed with the quorum, which also invalidates the batches the
+// execution superseded.
+func (k Keeper) SetBatchInChain(ctx sdk.Context, batchNonce int64, validator sdk.AccAddress) (bool, error) {
+	batch := k.GetOutgoingTXBatch(ctx, batchNonce)
+	if batch == nil {
+		return false, sdkerrors.Wrapf(types.ErrUnknown, "batch %d", batchNonce)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetBatchInChainKey(batchNonce, validator), []byte{1})
+	if !types.HasQuorum(k.batchInChainPower(ctx, batchNonce)) {
+		k.updateBatchStatus(ctx, *batch, types.TransferSubmitted)
+		return false, nil
+	}
+	if err := k.observeBatch(ctx, *batch); err != nil {
+		return false, err
+	}
+	k.updateBatchStatus(ctx, *batch, types.TransferObserved)
+	return true, nil
+}
+
+// batchInChainPower returns the normalized power of the validators that attested the batch
+func (k Keeper) batchInChainPower(ctx sdk.Context, batchNonce int64) int64 {
+	store := ctx.KVStore(k.storeKey)
+	var power int64
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if store.Has(types.GetBatchInChainKey(batchNonce, sdk.AccAddress(r.Validator))) {
+			power += r.NormalizedPower
+		}
+	}
+	return power
+}
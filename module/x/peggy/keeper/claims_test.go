@@ -0,0 +1,193 @@
+package keeper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimDeposit(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	var validators []sdk.AccAddress
+	var valAddrs []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		valAddrs = append(valAddrs, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+		validators = append(validators, sdk.AccAddress(valAddrs[i]))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(valAddrs...)
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	txHash := "0x35d2fd082787280e325543086c269c912becb598fd43bb58fae254bb8efd9a16"
+	amount := sdk.NewInt64Coin("mytoken", 100)
+
+	// two of four validators do not observe the deposit, the third one mints it
+	for i, validator := range validators[:3] {
+		deposit := k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, amount, txHash, 0))
+		assert.Equal(t, uint64(1), deposit.ID)
+		expected := types.DepositClaimed
+		if i == 2 {
+			expected = types.DepositMinted
+		}
+		assert.Equal(t, expected, deposit.Status)
+	}
+	// a late claim does not mint again, the case of the tx hash does not matter
+	deposit := k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validators[3], recipient, amount, "0x"+strings.ToUpper(txHash[2:]), 0))
+	assert.Equal(t, uint64(1), deposit.ID)
+	assert.Equal(t, types.DepositMinted, deposit.Status)
+	assert.Equal(t, sdk.NewCoins(amount), keepers.BankKeeper.GetCoins(ctx, recipient))
+
+	// a claim of the same event with a different amount is another deposit
+	deposit = k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validators[0], recipient, sdk.NewInt64Coin("mytoken", 1000), txHash, 0))
+	assert.Equal(t, uint64(2), deposit.ID)
+	assert.Equal(t, types.DepositClaimed, deposit.Status)
+
+	// claims are recorded while the bridge is paused, the observed deposit is minted by the end
+	// blocker once it resumed
+	ctx = ctx.WithBlockHeight(10)
+	k.SetPaused(ctx, true, types.PauseSourceGovernance)
+	for _, validator := range validators[:3] {
+		deposit = k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, amount, txHash, 1))
+	}
+	assert.Equal(t, types.DepositObserved, deposit.Status)
+	k.MintObservedDeposits(ctx)
+	assert.Equal(t, types.DepositObserved, k.GetDepositStatus(ctx, deposit.ID).Status)
+
+	ctx = ctx.WithBlockHeight(20)
+	k.SetPaused(ctx, false, types.PauseSourceGovernance)
+	k.MintObservedDeposits(ctx)
+	assert.Equal(t, &types.DepositStatus{
+		ID:        3,
+		EthTxHash: txHash,
+		LogIndex:  1,
+		Recipient: recipient,
+		Amount:    amount,
+		Minted:    amount,
+		Status:    types.DepositMinted,
+		Height:    20,
+	}, k.GetDepositStatus(ctx, deposit.ID))
+	assert.Equal(t, sdk.NewCoins(amount.Add(amount)), keepers.BankKeeper.GetCoins(ctx, recipient))
+
+	// a minted deposit is not minted again
+	k.MintObservedDeposits(ctx)
+	assert.Equal(t, sdk.NewCoins(amount.Add(amount)), keepers.BankKeeper.GetCoins(ctx, recipient))
+
+	// a deposit beyond the mint rate limit is minted once the window rolled on
+	params := k.GetParams(ctx)
+	params.RateLimits = []types.RateLimit{
+		{Denom: "mytoken", WindowBlocks: 10, MaxOutflow: sdk.ZeroInt(), MaxMint: sdk.NewInt(150)},
+	}
+	k.SetParams(ctx, params)
+	for _, logIndex := range []uint64{2, 3} {
+		for _, validator := range validators[:3] {
+			deposit = k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, amount, txHash, logIndex))
+		}
+	}
+	assert.Equal(t, types.DepositObserved, deposit.Status)
+	ctx = ctx.WithBlockHeight(29)
+	k.MintObservedDeposits(ctx)
+	assert.Equal(t, types.DepositObserved, k.GetDepositStatus(ctx, deposit.ID).Status)
+	ctx = ctx.WithBlockHeight(30)
+	k.MintObservedDeposits(ctx)
+	assert.Equal(t, types.DepositMinted, k.GetDepositStatus(ctx, deposit.ID).Status)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 400)), keepers.BankKeeper.GetCoins(ctx, recipient))
+
+	// a deposit to a blocked recipient is held until it is released
+	ctx = ctx.WithBlockHeight(40)
+	blocked := sdk.AccAddress(bytes.Repeat([]byte{8}, sdk.AddrLen))
+	k.SetCosmosAddressBlocked(ctx, blocked, true)
+	for _, validator := range validators[:3] {
+		deposit = k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, blocked, amount, txHash, 4))
+	}
+	assert.Equal(t, types.DepositHeld, deposit.Status)
+	assert.Equal(t, uint64(1), deposit.HeldDepositID)
+	held := k.GetHeldDeposits(ctx, blocked)
+	require.Len(t, held, 1)
+	assert.Equal(t, deposit.ID, held[0].DepositID)
+
+	k.SetCosmosAddressBlocked(ctx, blocked, false)
+	require.NoError(t, k.ReleaseHeldDeposits(ctx, blocked))
+	deposit = *k.GetDepositStatus(ctx, deposit.ID)
+	assert.Equal(t, types.DepositMinted, deposit.Status)
+	assert.Zero(t, deposit.HeldDepositID)
+	assert.Equal(t, sdk.NewCoins(amount), keepers.BankKeeper.GetCoins(ctx, blocked))
+}
+
+func TestMintObservedDeposits(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	var validators []sdk.AccAddress
+	var valAddrs []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		valAddrs = append(valAddrs, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+		validators = append(validators, sdk.AccAddress(valAddrs[i]))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(valAddrs...)
+	params := k.GetParams(ctx)
+	params.NativeTokens = []types.NativeToken{{Denom: "other", Metadata: types.ERC20Metadata{Name: "Other", Symbol: "OTH", Decimals: 6}}}
+	params.RateLimits = []types.RateLimit{
+		{Denom: "mytoken", WindowBlocks: 1000, MaxOutflow: sdk.ZeroInt(), MaxMint: sdk.NewInt(100)},
+	}
+	k.SetParams(ctx, params)
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	txHash := "0x35d2fd082787280e325543086c269c912becb598fd43bb58fae254bb8efd9a16"
+
+	// deposits 1 to 21 hit the rate limit after the first one, 22 has no limit and 23 is of a
+	// native denom without ERC20
+	k.SetPaused(ctx, true, types.PauseSourceGovernance)
+	claim := func(logIndex uint64, amount sdk.Coin) {
+		for _, validator := range validators[:3] {
+			k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, amount, txHash, logIndex))
+		}
+	}
+	for i := uint64(0); i < 21; i++ {
+		claim(i, sdk.NewInt64Coin("mytoken", 100))
+	}
+	claim(21, sdk.NewInt64Coin("thirdtoken", 100))
+	claim(22, sdk.NewInt64Coin("other", 100))
+	k.MintObservedDeposits(ctx)
+	assert.Equal(t, types.DepositObserved, k.GetDepositStatus(ctx, 1).Status)
+
+	k.SetPaused(ctx, false, types.PauseSourceGovernance)
+	ctx = ctx.WithBlockHeight(1)
+	k.MintObservedDeposits(ctx)
+	assert.Equal(t, types.DepositMinted, k.GetDepositStatus(ctx, 1).Status)
+	assert.Equal(t, types.DepositObserved, k.GetDepositStatus(ctx, 20).Status)
+	assert.Equal(t, types.DepositObserved, k.GetDepositStatus(ctx, 22).Status)
+
+	// the next block continues behind the rate limited deposits
+	ctx = ctx.WithBlockHeight(2)
+	k.MintObservedDeposits(ctx)
+	assert.Equal(t, types.DepositObserved, k.GetDepositStatus(ctx, 21).Status)
+	assert.Equal(t, types.DepositMinted, k.GetDepositStatus(ctx, 22).Status)
+	failed := k.GetDepositStatus(ctx, 23)
+	assert.Equal(t, types.DepositFailed, failed.Status)
+	assert.Contains(t, failed.Error, "no erc20 deployed for other")
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetObservedDepositKey(23)))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("thirdtoken", 100)), keepers.BankKeeper.GetCoins(ctx, recipient))
+}
+
+func TestSetBatchInChain(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var validators []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		validators = append(validators, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(validators...)
+	AddEscrowedTransfer(t, k, ctx, sdk.AccAddress(validators[0]), ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"), sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 1))
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+
+	_, err = k.SetBatchInChain(ctx, batch.Nonce+1, sdk.AccAddress(validators[0]))
+	assert.True(t, types.ErrUnknown.Is(err), err)
+
+	// attestations are recorded while the bridge is paused
+	k.SetPaused(ctx, true, types.PauseSourceGovernance)
+	for i, validator := range validators[:3] {
+		observed, err := k.SetBatchInChain(ctx, batch.Nonce, sdk.AccAddress(validator))
+		require.NoError(t, err)
+		assert.Equal(t, i == 2, observed)
+	}
+}
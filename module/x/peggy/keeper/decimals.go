@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SplitDust splits the coin into the part that can be sent to Ethereum and the dust below one
+// unit of the ERC20 of its denom
+func (k Keeper) SplitDust(ctx sdk.Context, coin sdk.Coin) (send sdk.Coin, dust sdk.Coin) {
+	_, rem := k.GetParams(ctx).GetTokenDecimals(coin.Denom).ToERC20(coin.Amount)
+	return sdk.NewCoin(coin.Denom, coin.Amount.Sub(rem)), sdk.NewCoin(coin.Denom, rem)
+}
+
+// GetTokenDust returns the dust kept of the denom, zero when there is none
+func (k Keeper) GetTokenDust(ctx sdk.Context, denom string) types.TokenDust {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetTokenDustKey(denom))
+	if bz == nil {
+		return types.TokenDust{Denom: denom, Cosmos: sdk.ZeroInt(), ERC20: sdk.ZeroInt()}
+	}
+	var dust types.TokenDust
+	k.cdc.MustUnmarshalBinaryBare(bz, &dust)
+	return dust
+}
+
+// GetTokenDusts returns the dust of all denoms that have any in the order of the denoms
+func (k Keeper) GetTokenDusts(ctx sdk.Context) []types.TokenDust {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.TokenDustKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	res := []types.TokenDust{}
+	for ; iter.Valid(); iter.Next() {
+		var dust types.TokenDust
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &dust)
+		res = append(res, dust)
+	}
+	return res
+}
+
+// addDust keeps the dust of transfers, in units of the denom, and of deposits, in units of the
+// ERC20, as a module fee
+func (k Keeper) addDust(ctx sdk.Context, denom string, cosmos sdk.Int, erc20 sdk.Int) {
+	if cosmos.IsZero() && erc20.IsZero() {
+		return
+	}
+	dust := k.GetTokenDust(ctx, denom)
+	dust.Cosmos = dust.Cosmos.Add(cosmos)
+	dust.ERC20 = dust.ERC20.Add(erc20)
+	ctx.KVStore(k.storeKey).Set(types.GetTokenDustKey(denom), k.cdc.MustMarshalBinaryBare(dust))
+}
@@ -0,0 +1,111 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenDecimals(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	var validators []sdk.AccAddress
+	var valAddrs []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		valAddrs = append(valAddrs, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+		validators = append(validators, sdk.AccAddress(valAddrs[i]))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(valAddrs...)
+	params := k.GetParams(ctx)
+	params.TokenDecimals = []types.TokenDecimals{
+		{Denom: "mytoken", CosmosDecimals: 6, ERC20Decimals: 18},
+		{Denom: "fine", CosmosDecimals: 18, ERC20Decimals: 6},
+	}
+	k.SetParams(ctx, params)
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	txHash := "0x35d2fd082787280e325543086c269c912becb598fd43bb58fae254bb8efd9a16"
+
+	// deposits are claimed in units of the ERC20 and minted in units of the denom, the rest stays
+	// in the contract as dust
+	amount, ok := sdk.NewIntFromString("1500000000000000007")
+	require.True(t, ok)
+	var deposit types.DepositStatus
+	for _, validator := range validators[:3] {
+		deposit = k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, sdk.NewCoin("mytoken", amount), txHash, 0))
+	}
+	assert.Equal(t, types.DepositMinted, deposit.Status)
+	assert.Equal(t, sdk.NewInt64Coin("mytoken", 1500000), deposit.Minted)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 1500000)), keepers.BankKeeper.GetCoins(ctx, recipient))
+	assert.Equal(t, types.TokenDust{Denom: "mytoken", Cosmos: sdk.ZeroInt(), ERC20: sdk.NewInt(7)}, k.GetTokenDust(ctx, "mytoken"))
+
+	// a deposit below one unit of the denom mints nothing
+	for _, validator := range validators[:3] {
+		deposit = k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, sdk.NewInt64Coin("mytoken", 999), txHash, 1))
+	}
+	assert.Equal(t, types.DepositMinted, deposit.Status)
+	assert.True(t, deposit.Minted.IsZero())
+	assert.Equal(t, sdk.NewInt(1006), k.GetTokenDust(ctx, "mytoken").ERC20)
+
+	// transfers are split into what the ERC20 can represent and the dust
+	send, dust := k.SplitDust(ctx, sdk.NewInt64Coin("fine", 3000000000000123))
+	assert.Equal(t, sdk.NewInt64Coin("fine", 3000000000000000), send)
+	assert.Equal(t, sdk.NewInt64Coin("fine", 123), dust)
+	send, dust = k.SplitDust(ctx, sdk.NewInt64Coin("mytoken", 123))
+	assert.Equal(t, sdk.NewInt64Coin("mytoken", 123), send)
+	assert.True(t, dust.IsZero())
+
+	// batches pay out in units of the ERC20, the dust of transfers pooled before the decimals
+	// were set is kept as a module fee
+	coins := sdk.NewCoins(sdk.NewInt64Coin("fine", 5000000000000130))
+	require.NoError(t, keepers.SupplyKeeper.MintCoins(ctx, types.ModuleName, coins))
+	require.NoError(t, keepers.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, coins))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	require.NoError(t, k.EscrowTransfer(ctx, recipient, sdk.NewInt64Coin("fine", 3000000000000123), sdk.NewInt64Coin("fine", 2000000000000007)))
+	k.AddToOutgoingPool(ctx, recipient, dest, sdk.NewInt64Coin("fine", 3000000000000123), sdk.NewInt64Coin("fine", 2000000000000007))
+	batch, err := k.BuildOutgoingTXBatch(ctx, "fine")
+	require.NoError(t, err)
+	assert.Equal(t, params.TokenDecimals[1], batch.Decimals)
+	erc20Amount, erc20Fee := batch.ERC20Amounts(batch.Elements[0])
+	assert.Equal(t, sdk.NewInt(3000), erc20Amount)
+	assert.Equal(t, sdk.NewInt(2000), erc20Fee)
+	assert.Equal(t, sdk.NewInt(130), k.GetTokenDust(ctx, "fine").Cosmos)
+
+	// later changes of the decimals do not change the batch
+	params.TokenDecimals = nil
+	k.SetParams(ctx, params)
+	stored := k.GetOutgoingTXBatch(ctx, batch.Nonce)
+	require.NotNil(t, stored)
+	erc20Amount, _ = stored.ERC20Amounts(stored.Elements[0])
+	assert.Equal(t, sdk.NewInt(3000), erc20Amount)
+	msg, broken := TokenDecimalsInvariant(k)(ctx)
+	assert.False(t, broken, msg)
+}
+
+func TestDecimalsInvariants(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	k.setCosmosERC20(ctx, types.CosmosERC20{
+		Denom:         "stake",
+		TokenContract: ethAddr("0x7c2C195CD6D34B8F845992d380aADB2730bB9C6F"),
+		Metadata:      types.ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 6},
+		Locked:        sdk.ZeroInt(),
+	})
+	msg, broken := NativeLockedInvariant(k)(ctx)
+	assert.False(t, broken, msg)
+	msg, broken = TokenDecimalsInvariant(k)(ctx)
+	assert.False(t, broken, msg)
+
+	// locked coins the module account does not hold
+	k.lockNative(ctx, sdk.NewInt64Coin("stake", 10))
+	_, broken = NativeLockedInvariant(k)(ctx)
+	assert.True(t, broken)
+
+	// decimals that do not match the token
+	params := k.GetParams(ctx)
+	params.TokenDecimals = []types.TokenDecimals{{Denom: "stake", CosmosDecimals: 6, ERC20Decimals: 18}}
+	k.SetParams(ctx, params)
+	_, broken = TokenDecimalsInvariant(k)(ctx)
+	assert.True(t, broken)
+}
@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MintDeposit mints an observed deposit from Ethereum into the module account and passes it on to
+// the recipient. Deposits of the ERC20 of a native denom unlock the coins in the module account
+// instead. The amount has to pass the mint rate limit of its denom. A deposit to a blocked
+// recipient stays in the module account as a held deposit, which is returned then. The depositID
+// is the ID of the DepositStatus of the deposit.
+func (k Keeper) MintDeposit(ctx sdk.Context, depositID uint64, recipient sdk.AccAddress, amount sdk.Coin) (*types.HeldDeposit, error) {
+	if err := k.AssertNotPaused(ctx); err != nil {
+		return nil, err
+	}
+	if err := k.AssertBridgeable(ctx, amount.Denom); err != nil {
+		return nil, err
+	}
+	if err := k.ConsumeRateLimit(ctx, types.RateLimitMint, amount); err != nil {
+		return nil, err
+	}
+	if erc20 := k.GetCosmosERC20(ctx, amount.Denom); erc20 != nil {
+		if err := k.unlockNative(ctx, *erc20, amount); err != nil {
+			return nil, err
+		}
+	} else if err := k.supplyKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(amount)); err != nil {
+		return nil, err
+	}
+	if k.IsCosmosAddressBlocked(ctx, recipient) {
+		held := k.HoldDeposit(ctx, depositID, recipient, amount)
+		return &held, nil
+	}
+	return nil, k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, sdk.NewCoins(amount))
+}
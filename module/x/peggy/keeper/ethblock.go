@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// GetEthBlock returns the chain's view of the latest final Ethereum block or nil before validators
+// with a quorum of the power attested one
+func (k Keeper) GetEthBlock(ctx sdk.Context) *types.EthBlock {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyEthBlock)
+	if bz == nil {
+		return nil
+	}
+	var block types.EthBlock
+	k.cdc.MustUnmarshalBinaryBare(bz, &block)
+	return &block
+}
+
+// SetEthBlockAttestation records the latest final Ethereum block the validator observed and moves
+// the chain's view of Ethereum forward to the power weighted median of the attestations of the
+// bonded validators. An attestation below the previous one of the validator is rejected.
+func (k Keeper) SetEthBlockAttestation(ctx sdk.Context, msg types.MsgEthBlockObserved) error {
+	if prev := k.GetEthBlockAttestation(ctx, msg.Validator); prev != nil && msg.Number < prev.Number {
+		return sdkerrors.Wrapf(types.ErrInvalid, "eth block %d below the attested %d", msg.Number, prev.Number)
+	}
+	attestation := types.EthBlockAttestation{
+		Validator: msg.Validator,
+		Number:    msg.Number,
+		Hash:      msg.Hash,
+		Timestamp: msg.Timestamp,
+		Height:    ctx.BlockHeight(),
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetEthBlockAttestationKey(msg.Validator), k.cdc.MustMarshalBinaryBare(attestation))
+	k.updateEthBlock(ctx)
+	return nil
+}
+
+// GetEthBlockAttestation returns the latest Ethereum block the validator attested or nil
+func (k Keeper) GetEthBlockAttestation(ctx sdk.Context, validator sdk.AccAddress) *types.EthBlockAttestation {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetEthBlockAttestationKey(validator))
+	if bz == nil {
+		return nil
+	}
+	var attestation types.EthBlockAttestation
+	k.cdc.MustUnmarshalBinaryBare(bz, &attestation)
+	return &attestation
+}
+
+// GetEthBlockAttestations returns the latest attestations of all validators, bonded or not, in
+// the order of their addresses
+func (k Keeper) GetEthBlockAttestations(ctx sdk.Context) []types.EthBlockAttestation {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.EthBlockAttestationKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	res := []types.EthBlockAttestation{}
+	for ; iter.Valid(); iter.Next() {
+		var attestation types.EthBlockAttestation
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &attestation)
+		res = append(res, attestation)
+	}
+	return res
+}
+
+// updateEthBlock moves the view of Ethereum to the power weighted median of the attestations of the
+// bonded validators once they hold a quorum of the power, see attestedMedian
+func (k Keeper) updateEthBlock(ctx sdk.Context) {
+	var attestations []types.EthBlockAttestation
+	i, ok := k.attestedMedian(ctx, func(validator sdk.AccAddress) (int64, bool) {
+		a := k.GetEthBlockAttestation(ctx, validator)
+		if a == nil {
+			return 0, false
+		}
+		attestations = append(attestations, *a)
+		return a.Height, true
+	}, func(i, j int) bool {
+		return attestations[i].Number < attestations[j].Number
+	})
+	if !ok {
+		return
+	}
+	median := attestations[i]
+	if current := k.GetEthBlock(ctx); current != nil && median.Number <= current.Number {
+		return
+	}
+	block := types.EthBlock{
+		Number:    median.Number,
+		Hash:      median.Hash,
+		Timestamp: median.Timestamp,
+		Height:    ctx.BlockHeight(),
+	}
+	ctx.KVStore(k.storeKey).Set(types.KeyEthBlock, k.cdc.MustMarshalBinaryBare(block))
+}
+
+// attestedMedian returns the index of the power weighted median of the latest attestations of the
+// bonded validators, leaving out the ones older than AttestationExpiryBlocks. attestation looks up
+// the attestation of a validator and returns its height, the attestations are indexed in the order
+// it found them. less orders two attestations by their value, ties are broken by the validator
+// address. The median is the first attestation at which the cumulative power reaches half of the
+// attesting power, so validators attesting a value above it can not move it on their own. It
+// returns false while the attesting validators hold no quorum of the power.
+func (k Keeper) attestedMedian(ctx sdk.Context, attestation func(validator sdk.AccAddress) (int64, bool), less func(i, j int) bool) (int, bool) {
+	type weighted struct {
+		index     int
+		validator sdk.AccAddress
+		power     int64
+	}
+	expiry := int64(k.GetParams(ctx).AttestationExpiryBlocks)
+	var attested []weighted
+	var found int
+	var total int64
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if r.NormalizedPower <= 0 {
+			continue
+		}
+		validator := sdk.AccAddress(r.Validator)
+		height, ok := attestation(validator)
+		if !ok {
+			continue
+		}
+		found++
+		if ctx.BlockHeight()-height > expiry {
+			continue
+		}
+		attested = append(attested, weighted{found - 1, validator, r.NormalizedPower})
+		total += r.NormalizedPower
+	}
+	if !types.HasQuorum(total) {
+		return 0, false
+	}
+	sort.Slice(attested, func(i, j int) bool {
+		if less(attested[i].index, attested[j].index) {
+			return true
+		}
+		if less(attested[j].index, attested[i].index) {
+			return false
+		}
+		return bytes.Compare(attested[i].validator, attested[j].validator) < 0
+	})
+	var cumulative int64
+	for _, w := range attested {
+		cumulative += w.power
+		if 2*cumulative >= total {
+			return w.index, true
+		}
+	}
+	return attested[len(attested)-1].index, true
+}
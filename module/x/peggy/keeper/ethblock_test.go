@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthBlockMedian(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var validators []sdk.AccAddress
+	var valAddrs []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		valAddrs = append(valAddrs, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+		validators = append(validators, sdk.AccAddress(valAddrs[i]))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(valAddrs...)
+	ctx = ctx.WithBlockHeight(10)
+	attest := func(validator sdk.AccAddress, number uint64) error {
+		hash := fmt.Sprintf("0x%064x", number)
+		return k.SetEthBlockAttestation(ctx, types.NewMsgEthBlockObserved(number, hash, 1600000000+number, validator))
+	}
+
+	// two of four validators are no quorum, with the third one the view moves to the median
+	require.NoError(t, attest(validators[0], 100))
+	require.NoError(t, attest(validators[1], 110))
+	assert.Nil(t, k.GetEthBlock(ctx))
+	require.NoError(t, attest(validators[2], 90))
+	assert.Equal(t, &types.EthBlock{
+		Number:    100,
+		Hash:      fmt.Sprintf("0x%064x", 100),
+		Timestamp: 1600000100,
+		Height:    10,
+	}, k.GetEthBlock(ctx))
+
+	// a single validator far ahead does not move the view
+	ctx = ctx.WithBlockHeight(11)
+	require.NoError(t, attest(validators[3], 1000))
+	assert.Equal(t, uint64(100), k.GetEthBlock(ctx).Number)
+	assert.Equal(t, int64(10), k.GetEthBlock(ctx).Height)
+
+	ctx = ctx.WithBlockHeight(12)
+	require.NoError(t, attest(validators[2], 120))
+	assert.Equal(t, uint64(110), k.GetEthBlock(ctx).Number)
+	assert.Equal(t, int64(12), k.GetEthBlock(ctx).Height)
+
+	// attestations do not go back, and the view does not either
+	err := attest(validators[0], 50)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	require.NoError(t, attest(validators[0], 100))
+
+	// attestations of validators that are no longer bonded do not count
+	k.StakingKeeper = NewStakingKeeperMock(valAddrs[:3]...)
+	require.NoError(t, attest(validators[1], 115))
+	assert.Equal(t, uint64(115), k.GetEthBlock(ctx).Number)
+	assert.Len(t, k.GetEthBlockAttestations(ctx), 4)
+}
@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"sort"
+	"time"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RecentBatchWaits is the number of batches per denom whose waits are kept for fee estimates
+const RecentBatchWaits = 10
+
+// FeeEstimatePercentiles are the percentiles of the fees in the pool a fee estimate reports
+var FeeEstimatePercentiles = []uint64{10, 25, 50, 75, 90}
+
+// GetFeeEstimate describes the pool of the denom for users choosing a bridge fee. Transfers that
+// can not go into a batch because of the blocklist are not counted.
+func (k Keeper) GetFeeEstimate(ctx sdk.Context, denom string) types.FeeEstimate {
+	params := k.GetParams(ctx)
+	candidates := k.batchCandidates(ctx, params, denom)
+	estimate := types.FeeEstimate{
+		Denom:          denom,
+		PoolDepth:      uint64(len(candidates)),
+		PoolAmount:     sdk.ZeroInt(),
+		MinFee:         params.GetMinBridgeFee(denom),
+		FeePercentiles: []types.FeePercentile{},
+		MaxBatchSize:   OutgoingTxBatchSize,
+	}
+
+	// candidates are ordered by descending fee value
+	fees := make([]sdk.Int, len(candidates))
+	for i, tx := range candidates {
+		estimate.PoolAmount = estimate.PoolAmount.Add(tx.Amount.Amount)
+		fees[len(candidates)-1-i] = bridgeFeeValue(params, tx)
+	}
+	if len(fees) != 0 {
+		for _, p := range FeeEstimatePercentiles {
+			// nearest rank
+			rank := (p*uint64(len(fees)) + 99) / 100
+			estimate.FeePercentiles = append(estimate.FeePercentiles, types.FeePercentile{Percentile: p, Fee: fees[rank-1]})
+		}
+	}
+
+	// the next batch shares the base gas with the pool, up to a full batch
+	estimate.GasFee = sdk.ZeroInt()
+	weiPerUnit, ok := params.GetEthValue(denom)
+	if gasPrice := k.GetGasPrice(ctx); gasPrice != nil && ok {
+		n := len(candidates) + 1
+		if n > OutgoingTxBatchSize {
+			n = OutgoingTxBatchSize
+		}
+		cost := params.BatchGasCost(gasPrice.Price, n)
+		estimate.GasFee = cost.Quo(weiPerUnit).Ceil().TruncateInt()
+		// the division rounds, the fee has to pass the same check as in BuildOutgoingTXBatch
+		if weiPerUnit.MulInt(estimate.GasFee).LT(cost) {
+			estimate.GasFee = estimate.GasFee.AddRaw(1)
+		}
+	}
+
+	// a new transfer has to beat the last one that makes a full batch, ties go to the older one
+	estimate.NextBatchFee = sdk.MaxInt(estimate.MinFee, estimate.GasFee)
+	if len(candidates) >= OutgoingTxBatchSize {
+		beat := bridgeFeeValue(params, candidates[OutgoingTxBatchSize-1]).AddRaw(1)
+		if beat.GT(estimate.NextBatchFee) {
+			estimate.NextBatchFee = beat
+		}
+	}
+
+	waits := k.GetBatchWaits(ctx, denom)
+	medians := make([]time.Duration, len(waits))
+	for i, w := range waits {
+		medians[i] = w.MedianWait
+	}
+	estimate.MedianBatchWait = medianDuration(medians)
+	estimate.RecentBatches = uint64(len(waits))
+	return estimate
+}
+
+// GetBatchWaits returns the waits of the recent batches of the denom, oldest first
+func (k Keeper) GetBatchWaits(ctx sdk.Context, denom string) []types.BatchWait {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetBatchWaitPrefix(denom))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	res := []types.BatchWait{}
+	for ; iter.Valid(); iter.Next() {
+		var wait types.BatchWait
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &wait)
+		res = append(res, wait)
+	}
+	return res
+}
+
+// setBatchWait stores the median of the waits of the transfers of the batch and drops the waits
+// of the batches before the RecentBatchWaits last ones. Transfers that entered the pool before
+// their time was recorded have no wait, batches of only these are skipped.
+func (k Keeper) setBatchWait(ctx sdk.Context, denom string, batchNonce int64, waits []time.Duration) {
+	if len(waits) == 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	wait := types.BatchWait{
+		BatchNonce: batchNonce,
+		Height:     ctx.BlockHeight(),
+		MedianWait: medianDuration(waits),
+	}
+	store.Set(types.GetBatchWaitKey(denom, batchNonce), k.cdc.MustMarshalBinaryBare(wait))
+
+	prefixStore := prefix.NewStore(store, types.GetBatchWaitPrefix(denom))
+	var keys [][]byte
+	iter := prefixStore.ReverseIterator(nil, nil)
+	for i := 0; iter.Valid(); iter.Next() {
+		if i++; i > RecentBatchWaits {
+			keys = append(keys, iter.Key())
+		}
+	}
+	iter.Close()
+	for _, key := range keys {
+		prefixStore.Delete(key)
+	}
+}
+
+// medianDuration returns the median of the durations, zero without any
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFeeEstimate(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	params := k.GetParams(ctx)
+	params.MinBridgeFees = []types.MinBridgeFee{{Denom: "mytoken", MinFee: sdk.NewInt(5)}}
+	k.SetParams(ctx, params)
+	sender := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+
+	estimate := k.GetFeeEstimate(ctx, "mytoken")
+	assert.Equal(t, uint64(0), estimate.PoolDepth)
+	assert.Empty(t, estimate.FeePercentiles)
+	assert.Equal(t, int64(5), estimate.NextBatchFee.Int64())
+
+	// fees 10 to 119, ten more transfers than fit into a batch
+	start := ctx.BlockTime()
+	for i := 0; i < OutgoingTxBatchSize+10; i++ {
+		AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 1), sdk.NewInt64Coin("mytoken", int64(10+i)))
+	}
+	estimate = k.GetFeeEstimate(ctx, "mytoken")
+	assert.Equal(t, uint64(OutgoingTxBatchSize+10), estimate.PoolDepth)
+	assert.Equal(t, int64(OutgoingTxBatchSize+10), estimate.PoolAmount.Int64())
+	require.Len(t, estimate.FeePercentiles, len(FeeEstimatePercentiles))
+	assert.Equal(t, types.FeePercentile{Percentile: 10, Fee: sdk.NewInt(20)}, estimate.FeePercentiles[0])
+	assert.Equal(t, int64(64), estimate.FeePercentiles[2].Fee.Int64())
+	// the hundredth highest fee is 20
+	assert.Equal(t, int64(21), estimate.NextBatchFee.Int64())
+	assert.Equal(t, uint64(0), estimate.RecentBatches)
+
+	for i, wait := range []time.Duration{time.Hour, 3 * time.Hour} {
+		ctx = ctx.WithBlockTime(start.Add(wait))
+		if i == 1 {
+			AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 1), sdk.NewInt64Coin("mytoken", 1))
+		}
+		_, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+		require.NoError(t, err)
+	}
+	waits := k.GetBatchWaits(ctx, "mytoken")
+	require.Len(t, waits, 2)
+	assert.Equal(t, time.Hour, waits[0].MedianWait)
+	// ten transfers waited three hours, the one added just now did not wait
+	assert.Equal(t, 3*time.Hour, waits[1].MedianWait)
+	estimate = k.GetFeeEstimate(ctx, "mytoken")
+	assert.Equal(t, 2*time.Hour, estimate.MedianBatchWait)
+	assert.Equal(t, uint64(2), estimate.RecentBatches)
+}
+
+func TestBatchWaitsPruned(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	sender := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	for i := 0; i < RecentBatchWaits+3; i++ {
+		AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 1), sdk.NewInt64Coin("mytoken", 1))
+		_, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+		require.NoError(t, err)
+	}
+	waits := k.GetBatchWaits(ctx, "mytoken")
+	require.Len(t, waits, RecentBatchWaits)
+	assert.Equal(t, int64(4), waits[0].BatchNonce)
+}
@@ -0,0 +1,32 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// CheckBridgeFee returns types.ErrInvalid when the fee is in a denom that is not whitelisted for
+// the amount and types.ErrFeeTooLow when its value is below the minimum fee of the denom
+func (k Keeper) CheckBridgeFee(ctx sdk.Context, amount sdk.Coin, fee sdk.Coin) error {
+	params := k.GetParams(ctx)
+	value, ok := params.BridgeFeeValue(amount.Denom, fee)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrInvalid, "bridge fee denom %s for %s", fee.Denom, amount.Denom)
+	}
+	if min := params.GetMinBridgeFee(amount.Denom); value.LT(min) {
+		return sdkerrors.Wrapf(types.ErrFeeTooLow, "%s is worth %s%s, the minimum is %s%s",
+			fee, value, amount.Denom, min, amount.Denom)
+	}
+	return nil
+}
+
+// bridgeFeeValue returns what the fee of the transfer counts as in its denom, zero for a fee in a
+// denom that is no longer whitelisted
+func bridgeFeeValue(params types.Params, tx types.OutgoingTx) sdk.Int {
+	value, ok := params.BridgeFeeValue(tx.Amount.Denom, tx.BridgeFee)
+	if !ok {
+		return sdk.ZeroInt()
+	}
+	return value
+}
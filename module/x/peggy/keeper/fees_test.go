@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBridgeFee(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	params := k.GetParams(ctx)
+	params.MinBridgeFees = []types.MinBridgeFee{{Denom: "mytoken", MinFee: sdk.NewInt(10)}}
+	params.FeeDenoms = []types.FeeDenom{{Denom: "stake", TransferDenom: "mytoken", Rate: sdk.NewDec(2)}}
+	k.SetParams(ctx, params)
+
+	amount := sdk.NewInt64Coin("mytoken", 100)
+	assert.NoError(t, k.CheckBridgeFee(ctx, amount, sdk.NewInt64Coin("mytoken", 10)))
+	assert.NoError(t, k.CheckBridgeFee(ctx, amount, sdk.NewInt64Coin("stake", 5)))
+	err := k.CheckBridgeFee(ctx, amount, sdk.NewInt64Coin("mytoken", 9))
+	assert.True(t, types.ErrFeeTooLow.Is(err), err)
+	err = k.CheckBridgeFee(ctx, amount, sdk.NewInt64Coin("stake", 4))
+	assert.True(t, types.ErrFeeTooLow.Is(err), err)
+	err = k.CheckBridgeFee(ctx, amount, sdk.NewInt64Coin("unknown", 100))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	// denoms without a minimum take any fee
+	assert.NoError(t, k.CheckBridgeFee(ctx, sdk.NewInt64Coin("othertoken", 100), sdk.NewInt64Coin("othertoken", 0)))
+}
+
+func TestBuildOutgoingTXBatchWithFeeDenoms(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	params := k.GetParams(ctx)
+	params.FeeDenoms = []types.FeeDenom{{Denom: "stake", TransferDenom: "mytoken", Rate: sdk.NewDec(2)}}
+	k.SetParams(ctx, params)
+	sender := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	for _, fee := range []sdk.Coin{sdk.NewInt64Coin("mytoken", 3), sdk.NewInt64Coin("stake", 2), sdk.NewInt64Coin("mytoken", 5)} {
+		AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 100), fee)
+	}
+
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	require.Len(t, batch.Elements, 3)
+	assert.Equal(t, sdk.NewInt64Coin("mytoken", 8), batch.TotalFee)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 2)), batch.RelayerFees)
+	// the contract does not pay out the fee in the other denom
+	assert.True(t, batch.Elements[1].EthFee().IsZero())
+
+	// the escrowed fee in the other denom goes to the fee collector once the batch is observed
+	require.NoError(t, k.observeBatch(ctx, *batch))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 2)), keepers.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins())
+	assert.True(t, keepers.SupplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins().AmountOf("stake").IsZero())
+}
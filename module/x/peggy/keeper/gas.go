@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetGasPrice returns the chain's view of the Ethereum gas price or nil before validators with a
+// quorum of the power attested one
+func (k Keeper) GetGasPrice(ctx sdk.Context) *types.GasPrice {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyGasPrice)
+	if bz == nil {
+		return nil
+	}
+	var price types.GasPrice
+	k.cdc.MustUnmarshalBinaryBare(bz, &price)
+	return &price
+}
+
+// SetGasPriceAttestation records the gas price the validator observed on Ethereum and updates the
+// chain's view to the power weighted median of the attestations of the bonded validators. Unlike
+// the Ethereum block the gas price moves both ways.
+func (k Keeper) SetGasPriceAttestation(ctx sdk.Context, msg types.MsgGasPriceObserved) {
+	attestation := types.GasPriceAttestation{
+		Validator: msg.Validator,
+		Price:     msg.GasPrice,
+		Height:    ctx.BlockHeight(),
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetGasPriceAttestationKey(msg.Validator), k.cdc.MustMarshalBinaryBare(attestation))
+	k.updateGasPrice(ctx)
+}
+
+// GetGasPriceAttestation returns the latest gas price the validator attested or nil
+func (k Keeper) GetGasPriceAttestation(ctx sdk.Context, validator sdk.AccAddress) *types.GasPriceAttestation {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetGasPriceAttestationKey(validator))
+	if bz == nil {
+		return nil
+	}
+	var attestation types.GasPriceAttestation
+	k.cdc.MustUnmarshalBinaryBare(bz, &attestation)
+	return &attestation
+}
+
+// GetGasPriceAttestations returns the latest attestations of all validators, bonded or not, in the
+// order of their addresses
+func (k Keeper) GetGasPriceAttestations(ctx sdk.Context) []types.GasPriceAttestation {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GasPriceAttestationKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	res := []types.GasPriceAttestation{}
+	for ; iter.Valid(); iter.Next() {
+		var attestation types.GasPriceAttestation
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &attestation)
+		res = append(res, attestation)
+	}
+	return res
+}
+
+// updateGasPrice sets the gas price to the power weighted median of the attestations of the bonded
+// validators once they hold a quorum of the power, see attestedMedian
+func (k Keeper) updateGasPrice(ctx sdk.Context) {
+	var attestations []types.GasPriceAttestation
+	i, ok := k.attestedMedian(ctx, func(validator sdk.AccAddress) (int64, bool) {
+		a := k.GetGasPriceAttestation(ctx, validator)
+		if a == nil {
+			return 0, false
+		}
+		attestations = append(attestations, *a)
+		return a.Height, true
+	}, func(i, j int) bool {
+		return attestations[i].Price.LT(attestations[j].Price)
+	})
+	if !ok {
+		return
+	}
+	median := attestations[i]
+	if current := k.GetGasPrice(ctx); current != nil && current.Price.Equal(median.Price) {
+		return
+	}
+	price := types.GasPrice{
+		Price:  median.Price,
+		Height: ctx.BlockHeight(),
+	}
+	ctx.KVStore(k.storeKey).Set(types.KeyGasPrice, k.cdc.MustMarshalBinaryBare(price))
+}
+
+// profitableTransfers drops the transfers, from the lowest fee on, whose fees do not cover their
+// share of the gas of a batch with the remaining transfers. The transfers are sorted by fee value
+// descending. Without a gas price or an eth value of the denom all transfers are kept.
+func (k Keeper) profitableTransfers(ctx sdk.Context, params types.Params, denom string, txs []types.OutgoingTx) []types.OutgoingTx {
+	gasPrice := k.GetGasPrice(ctx)
+	weiPerUnit, ok := params.GetEthValue(denom)
+	if gasPrice == nil || !ok {
+		return txs
+	}
+	for len(txs) > 0 {
+		last := txs[len(txs)-1]
+		fee := weiPerUnit.MulInt(bridgeFeeValue(params, last))
+		if fee.GTE(params.BatchGasCost(gasPrice.Price, len(txs))) {
+			break
+		}
+		txs = txs[:len(txs)-1]
+	}
+	return txs
+}
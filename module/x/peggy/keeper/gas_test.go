@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPriceMedian(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var validators []sdk.AccAddress
+	var valAddrs []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		valAddrs = append(valAddrs, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+		validators = append(validators, sdk.AccAddress(valAddrs[i]))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(valAddrs...)
+	ctx = ctx.WithBlockHeight(10)
+	attest := func(validator sdk.AccAddress, price int64) {
+		k.SetGasPriceAttestation(ctx, types.NewMsgGasPriceObserved(sdk.NewInt(price), validator))
+	}
+
+	// two of four validators are no quorum, with the third one the price is the median
+	attest(validators[0], 100)
+	attest(validators[1], 300)
+	assert.Nil(t, k.GetGasPrice(ctx))
+	attest(validators[2], 200)
+	assert.Equal(t, &types.GasPrice{Price: sdk.NewInt(200), Height: 10}, k.GetGasPrice(ctx))
+
+	// a single validator far off does not move the price
+	ctx = ctx.WithBlockHeight(11)
+	attest(validators[3], 100000)
+	assert.Equal(t, &types.GasPrice{Price: sdk.NewInt(200), Height: 10}, k.GetGasPrice(ctx))
+
+	// the price falls as well
+	ctx = ctx.WithBlockHeight(12)
+	attest(validators[2], 50)
+	assert.Equal(t, &types.GasPrice{Price: sdk.NewInt(100), Height: 12}, k.GetGasPrice(ctx))
+	assert.Len(t, k.GetGasPriceAttestations(ctx), 4)
+
+	// the attestations of height 10 expired, the two others are no quorum
+	params := k.GetParams(ctx)
+	params.AttestationExpiryBlocks = 5
+	k.SetParams(ctx, params)
+	ctx = ctx.WithBlockHeight(16)
+	attest(validators[2], 60)
+	assert.Equal(t, &types.GasPrice{Price: sdk.NewInt(100), Height: 12}, k.GetGasPrice(ctx))
+	// without the expired 300 the median of 60, 400 and 100000 is 400
+	attest(validators[0], 400)
+	assert.Equal(t, &types.GasPrice{Price: sdk.NewInt(400), Height: 16}, k.GetGasPrice(ctx))
+}
+
+func TestBatchProfitability(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var valAddrs []sdk.ValAddress
+	for i := 0; i < 3; i++ {
+		valAddrs = append(valAddrs, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(valAddrs...)
+	params := k.GetParams(ctx)
+	params.BatchBaseGas = 100
+	params.BatchGasPerTransfer = 10
+	params.EthValues = []types.EthValue{{Denom: "mytoken", WeiPerUnit: sdk.NewDec(2)}}
+	k.SetParams(ctx, params)
+
+	sender := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	for _, fee := range []int64{35, 5, 100, 21} {
+		AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 1000), sdk.NewInt64Coin("mytoken", fee))
+	}
+	// without a gas price nothing is left out
+	assert.Equal(t, sdk.ZeroInt(), k.GetFeeEstimate(ctx, "mytoken").GasFee)
+
+	for _, valAddr := range valAddrs {
+		k.SetGasPriceAttestation(ctx, types.NewMsgGasPriceObserved(sdk.OneInt(), sdk.AccAddress(valAddr)))
+	}
+	// a fifth transfer shares the base gas with four others, (100/5 + 10) / 2 rounded up
+	estimate := k.GetFeeEstimate(ctx, "mytoken")
+	assert.Equal(t, sdk.NewInt(15), estimate.GasFee)
+	assert.Equal(t, sdk.NewInt(15), estimate.NextBatchFee)
+
+	// a batch of four costs 35 wei per transfer and leaves out the fee of 5, a batch of three costs
+	// 43.33 wei per transfer which the fee of 21 does not cover
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	var fees []int64
+	for _, tx := range batch.Elements {
+		fees = append(fees, tx.BridgeFee.Amount.Int64())
+	}
+	assert.Equal(t, []int64{35, 100}, fees)
+	assert.Equal(t, 2, poolSize(ctx, k))
+
+	// the transfers left out wait for a lower gas price
+	for _, valAddr := range valAddrs {
+		k.SetGasPriceAttestation(ctx, types.NewMsgGasPriceObserved(sdk.NewInt(10), sdk.AccAddress(valAddr)))
+	}
+	_, err = k.BuildOutgoingTXBatch(ctx, "mytoken")
+	assert.True(t, types.ErrEmpty.Is(err), err)
+	assert.Equal(t, 2, poolSize(ctx, k))
+}
+
+func poolSize(ctx sdk.Context, k Keeper) int {
+	var n int
+	k.IterateOutgoingPool(ctx, func(types.OutgoingTx) bool {
+		n++
+		return false
+	})
+	return n
+}
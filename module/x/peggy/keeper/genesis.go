@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InitGenesis sets the params and imports the bridge state. The imported pool transfers count as
+// entering the pool in the genesis block.
+func (k Keeper) InitGenesis(ctx sdk.Context, data types.GenesisState) {
+	k.SetParams(ctx, data.Params)
+	store := ctx.KVStore(k.storeKey)
+	for _, r := range data.EthAddresses {
+		k.SetEthAddress(ctx, r.Validator, r.EthAddress)
+	}
+	for _, erc20 := range data.CosmosERC20s {
+		k.setCosmosERC20(ctx, erc20)
+		store.Set(types.GetCosmosERC20ByTokenKey(erc20.TokenContract), []byte(erc20.Denom))
+	}
+	for _, tx := range data.OutgoingPool {
+		k.setPoolTx(ctx, tx)
+	}
+	for _, batch := range data.OutgoingBatches {
+		store.Set(types.GetOutgoingTxBatchKey(batch.Nonce), k.cdc.MustMarshalBinaryBare(batch))
+	}
+	store.Set(types.KeyLastObservedTxNonce, sdk.Uint64ToBigEndian(data.LastObservedTxNonce))
+	for _, locked := range data.TimeLockedTransfers {
+		k.setTimeLockedTransfer(ctx, locked)
+	}
+	for _, addr := range data.Blocklist.EthAddresses {
+		k.SetEthAddressBlocked(ctx, addr, true)
+	}
+	for _, addr := range data.Blocklist.CosmosAddresses {
+		k.SetCosmosAddressBlocked(ctx, addr, true)
+	}
+	for _, held := range data.HeldDeposits {
+		k.setHeldDeposit(ctx, held)
+	}
+
+	seq := data.Sequences
+	store.Set(types.KeyLastValsetNonce, sdk.Uint64ToBigEndian(uint64(seq.LastValsetNonce)))
+	store.Set(types.KeyLastTXPoolID, sdk.Uint64ToBigEndian(seq.LastTxPoolID))
+	store.Set(types.KeyLastOutgoingBatchID, sdk.Uint64ToBigEndian(seq.LastBatchNonce))
+	store.Set(types.KeyLastTimeLockID, sdk.Uint64ToBigEndian(seq.LastTimeLockID))
+	store.Set(types.KeyLastHeldDepositID, sdk.Uint64ToBigEndian(seq.LastHeldDepositID))
+	store.Set(types.KeyLastTransferID, sdk.Uint64ToBigEndian(seq.LastTransferID))
+	store.Set(types.KeyLastDepositID, sdk.Uint64ToBigEndian(seq.LastDepositID))
+}
+
+// ExportGenesis returns the params and the bridge state that InitGenesis imports
+func (k Keeper) ExportGenesis(ctx sdk.Context) types.GenesisState {
+	data := types.NewGenesisState(k.GetParams(ctx))
+	data.EthAddresses = k.getEthAddressRegistrations(ctx)
+	data.CosmosERC20s = k.GetCosmosERC20s(ctx)
+	k.IterateOutgoingPool(ctx, func(tx types.OutgoingTx) bool {
+		data.OutgoingPool = append(data.OutgoingPool, tx)
+		return false
+	})
+	k.IterateOutgoingTXBatches(ctx, func(_ []byte, batch types.OutgoingTxBatch) bool {
+		// the iteration is in descending order of the nonce
+		data.OutgoingBatches = append([]types.OutgoingTxBatch{batch}, data.OutgoingBatches...)
+		return false
+	})
+	data.LastObservedTxNonce = k.GetLastObservedTxNonce(ctx)
+	data.TimeLockedTransfers = k.GetTimeLockedTransfers(ctx)
+	data.Blocklist = k.GetBlocklist(ctx)
+	data.HeldDeposits = k.GetHeldDeposits(ctx, nil)
+
+	store := ctx.KVStore(k.storeKey)
+	data.Sequences = types.GenesisSequences{
+		LastValsetNonce:   k.GetLatestValsetNonce(ctx),
+		LastTxPoolID:      getSequence(store, types.KeyLastTXPoolID),
+		LastBatchNonce:    getSequence(store, types.KeyLastOutgoingBatchID),
+		LastTimeLockID:    getSequence(store, types.KeyLastTimeLockID),
+		LastHeldDepositID: getSequence(store, types.KeyLastHeldDepositID),
+		LastTransferID:    getSequence(store, types.KeyLastTransferID),
+		LastDepositID:     getSequence(store, types.KeyLastDepositID),
+	}
+	return data
+}
+
+// getEthAddressRegistrations returns the eth addresses of all validators that registered one, in
+// the order of the validator addresses
+func (k Keeper) getEthAddressRegistrations(ctx sdk.Context) []types.EthAddressRegistration {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.EthAddressKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	res := []types.EthAddressRegistration{}
+	for ; iter.Valid(); iter.Next() {
+		res = append(res, types.EthAddressRegistration{
+			Validator:  sdk.AccAddress(iter.Key()),
+			EthAddress: types.EthAddress(iter.Value()),
+		})
+	}
+	return res
+}
+
+// getSequence returns the last ID stored under the key, zero when none was handed out
+func getSequence(store sdk.KVStore, key []byte) uint64 {
+	bz := store.Get(key)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportGenesis(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	metadata := types.ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 6}
+	params := k.GetParams(ctx)
+	params.NativeTokens = []types.NativeToken{{Denom: "stake", Metadata: metadata}}
+	params.TimeLockThresholds = []types.TimeLockThreshold{{Denom: "mytoken", Threshold: sdk.NewInt(1000)}}
+	params.TimeLockBlocks = 50
+	k.SetParams(ctx, params)
+	ctx = ctx.WithBlockHeight(100)
+
+	validator := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	sender := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	blockedDest := ethAddr("0x7c2C195CD6D34B8F845992d380aADB2730bB9C6F")
+	k.SetEthAddress(ctx, validator, ethAddr("0xb462864E395d88d6bc7C5dd5F3F5eb4cc2599255"))
+	k.setCosmosERC20(ctx, types.CosmosERC20{Denom: "stake", TokenContract: blockedDest, Metadata: metadata, Locked: sdk.NewInt(500), Height: 10})
+	AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 1))
+	_, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 200), sdk.NewInt64Coin("mytoken", 2))
+	k.AddTimeLockedTransfer(ctx, sender, dest, sdk.NewInt64Coin("mytoken", 2000), sdk.NewInt64Coin("mytoken", 3))
+	k.SetEthAddressBlocked(ctx, blockedDest, true)
+	k.SetCosmosAddressBlocked(ctx, sender, true)
+	k.HoldDeposit(ctx, 0, sender, sdk.NewInt64Coin("mytoken", 7))
+	k.StakingKeeper = NewStakingKeeperMock(sdk.ValAddress(validator))
+	_, err = k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+
+	exported := k.ExportGenesis(ctx)
+	require.NoError(t, types.ValidateGenesis(exported))
+	assert.Len(t, exported.EthAddresses, 1)
+	assert.Len(t, exported.CosmosERC20s, 1)
+	assert.Len(t, exported.OutgoingPool, 1)
+	assert.Len(t, exported.OutgoingBatches, 1)
+	assert.Len(t, exported.TimeLockedTransfers, 1)
+	assert.Len(t, exported.HeldDeposits, 1)
+	assert.Equal(t, types.GenesisSequences{
+		LastValsetNonce:   1,
+		LastTxPoolID:      2,
+		LastBatchNonce:    1,
+		LastTimeLockID:    1,
+		LastHeldDepositID: 1,
+		LastTransferID:    3,
+	}, exported.Sequences)
+
+	imported, importedCtx := CreateTestEnv(t)
+	importedCtx = importedCtx.WithBlockHeight(100)
+	imported.InitGenesis(importedCtx, exported)
+	assert.Equal(t, exported, imported.ExportGenesis(importedCtx))
+	assert.Equal(t, &exported.CosmosERC20s[0], imported.GetCosmosERC20ByToken(importedCtx, blockedDest))
+	assert.Equal(t, validator, imported.GetEthAddressOwner(importedCtx, exported.EthAddresses[0].EthAddress))
+
+	// the imported chain continues the IDs and releases the delay queue
+	tx := imported.AddToOutgoingPool(importedCtx, sender, dest, sdk.NewInt64Coin("mytoken", 300), sdk.NewInt64Coin("mytoken", 3))
+	assert.Equal(t, uint64(3), tx.ID)
+	assert.Equal(t, uint64(4), tx.TransferID)
+	imported.ReleaseTimeLockedTransfers(importedCtx.WithBlockHeight(150))
+	assert.Empty(t, imported.GetTimeLockedTransfers(importedCtx))
+}
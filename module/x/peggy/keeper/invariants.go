@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the invariants of the peggy module
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "native-locked", NativeLockedInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "token-decimals", TokenDecimalsInvariant(k))
+}
+
+// NativeLockedInvariant checks that the module account holds the locked coins and the dust of
+// every native denom with an ERC20
+func NativeLockedInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var coins sdk.Coins
+		if acc, _ := k.supplyKeeper.GetModuleAccountAndPermissions(ctx, types.ModuleName); acc != nil {
+			coins = acc.GetCoins()
+		}
+		var msg string
+		broken := false
+		for _, erc20 := range k.GetCosmosERC20s(ctx) {
+			held := erc20.Locked.Add(k.GetTokenDust(ctx, erc20.Denom).Cosmos)
+			if balance := coins.AmountOf(erc20.Denom); balance.LT(held) {
+				broken = true
+				msg += fmt.Sprintf("\t%s: module account holds %s, locked and dust are %s\n", erc20.Denom, balance, held)
+			}
+		}
+		return sdk.FormatInvariant(types.ModuleName, "native-locked", msg), broken
+	}
+}
+
+// TokenDecimalsInvariant checks that the decimals of native denoms match their ERC20 and that the
+// amounts of every stored batch convert back to the transfers without loss beyond the dust
+func TokenDecimalsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		params := k.GetParams(ctx)
+		var msg string
+		broken := false
+		for _, erc20 := range k.GetCosmosERC20s(ctx) {
+			if decimals := params.GetTokenDecimals(erc20.Denom); decimals != (types.TokenDecimals{Denom: erc20.Denom}) &&
+				decimals.ERC20Decimals != erc20.Metadata.Decimals {
+				broken = true
+				msg += fmt.Sprintf("\t%s: %d erc20 decimals, its token has %d\n", erc20.Denom, decimals.ERC20Decimals, erc20.Metadata.Decimals)
+			}
+		}
+		k.IterateOutgoingTXBatches(ctx, func(_ []byte, batch types.OutgoingTxBatch) bool {
+			if batch.Decimals == (types.TokenDecimals{}) {
+				return false
+			}
+			if err := batch.Decimals.ValidateBasic(); err != nil {
+				broken = true
+				msg += fmt.Sprintf("\tbatch %d: %s\n", batch.Nonce, err)
+				return false
+			}
+			for _, tx := range batch.Elements {
+				amount, fee := batch.ERC20Amounts(tx)
+				if !convertsBack(batch.Decimals, amount, tx.Amount.Amount) || !convertsBack(batch.Decimals, fee, tx.EthFee()) {
+					broken = true
+					msg += fmt.Sprintf("\tbatch %d: transfer %d does not convert back from %s and %s\n", batch.Nonce, tx.ID, amount, fee)
+				}
+			}
+			return false
+		})
+		return sdk.FormatInvariant(types.ModuleName, "token-decimals", msg), broken
+	}
+}
+
+// convertsBack reports whether the ERC20 amount converts back to the original amount of the denom
+// less the dust below one unit of the ERC20
+func convertsBack(decimals types.TokenDecimals, erc20 sdk.Int, original sdk.Int) bool {
+	cosmos, rem := decimals.ToCosmos(erc20)
+	_, dust := decimals.ToERC20(original)
+	return rem.IsZero() && cosmos.Add(dust).Equal(original)
+}
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"encoding/binary"
 	"sort"
 
@@ -8,32 +9,158 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/tendermint/tendermint/libs/log"
 )
 
 // Keeper maintains the link to storage and exposes getter/setter methods for the various parts of the state machine
 type Keeper struct {
 	StakingKeeper types.StakingKeeper
+	supplyKeeper  types.SupplyKeeper
 
-	storeKey sdk.StoreKey // Unexposed key to access store from sdk.Context
+	storeKey   sdk.StoreKey // Unexposed key to access store from sdk.Context
+	paramSpace params.Subspace
 
 	cdc *codec.Codec // The wire codec for binary encoding/decoding.
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
-func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, stakingKeeper types.StakingKeeper) Keeper {
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace params.Subspace, stakingKeeper types.StakingKeeper, supplyKeeper types.SupplyKeeper) Keeper {
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
 	return Keeper{
 		cdc:           cdc,
 		storeKey:      storeKey,
+		paramSpace:    paramSpace,
 		StakingKeeper: stakingKeeper,
+		supplyKeeper:  supplyKeeper,
 	}
 }
 
-func (k Keeper) SetValsetRequest(ctx sdk.Context) {
+// Logger returns the logger of the module
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+types.ModuleName)
+}
+
+// GetParams returns the params of the module
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams replaces the params of the module
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// SetValsetRequest stores the current valset under the next valset nonce, the nonces count up by one
+// from 1 on. There is at most one request per block, further ones are refused. It is also refused
+// when the validators with a registered eth address do not hold a quorum of the bonded power, the
+// valset could not stand for the consensus of the chain otherwise. A valset that is the same as the
+// latest one is refused as well. Pending eth address rotations take effect with it.
+func (k Keeper) SetValsetRequest(ctx sdk.Context) (types.Valset, error) {
 	store := ctx.KVStore(k.storeKey)
+	if store.Has(types.GetValsetNonceByHeightKey(ctx.BlockHeight())) {
+		return types.Valset{}, sdkerrors.Wrapf(types.ErrInvalid, "valset requested at height %d already", ctx.BlockHeight())
+	}
+
+	var registeredPower int64
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if r.Registered {
+			registeredPower += r.NormalizedPower
+		}
+	}
+	if !types.HasQuorum(registeredPower) {
+		return types.Valset{}, sdkerrors.Wrapf(types.ErrInvalid, "validators with eth address hold %d of %d normalized power", registeredPower, types.PowerTotal)
+	}
+
+	latestNonce := k.GetLatestValsetNonce(ctx)
+	nonce := latestNonce + 1
+	// a pending rotation changes an address, the valset can only be the same without one
+	k.activatePendingEthAddresses(ctx, nonce)
 	valset := k.GetCurrentValset(ctx)
-	nonce := ctx.BlockHeight()
+	if latest := k.GetValsetRequest(ctx, latestNonce); latest != nil && sameValidators(*latest, valset) {
+		return types.Valset{}, sdkerrors.Wrapf(types.ErrInvalid, "valset unchanged since nonce %d", latestNonce)
+	}
+	k.autoIncrementID(ctx, types.KeyLastValsetNonce)
 	valset.Nonce = nonce
 	store.Set(types.GetValsetRequestKey(nonce), k.cdc.MustMarshalBinaryBare(valset))
+	store.Set(types.GetValsetNonceByHeightKey(ctx.BlockHeight()), sdk.Uint64ToBigEndian(uint64(nonce)))
+	k.setValsetRequestRecord(ctx, types.ValsetRequestRecord{Nonce: nonce, Height: ctx.BlockHeight(), Time: ctx.BlockTime()})
+	return valset, nil
+}
+
+// sameValidators returns true when both valsets have the same eth addresses with the same powers
+func sameValidators(a, b types.Valset) bool {
+	if len(a.EthAddresses) != len(b.EthAddresses) || len(a.Powers) != len(b.Powers) {
+		return false
+	}
+	for i := range a.EthAddresses {
+		if !a.EthAddresses[i].Equals(b.EthAddresses[i]) {
+			return false
+		}
+	}
+	for i := range a.Powers {
+		if a.Powers[i] != b.Powers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyValsetRequestPolicy checks a valset request against the policy governance chose, the fee of
+// the fee policy is taken from the requester and burned
+func (k Keeper) ApplyValsetRequestPolicy(ctx sdk.Context, requester sdk.AccAddress) error {
+	params := k.GetParams(ctx)
+	switch params.ValsetRequestPolicy {
+	case types.ValsetRequestPolicyValidators:
+		if k.GetValidatorRegistration(ctx, sdk.ValAddress(requester)) == nil {
+			return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "valset requests are limited to bonded validators")
+		}
+	case types.ValsetRequestPolicyMinBlocks:
+		next := k.GetLastValsetRequestHeight(ctx) + int64(params.ValsetRequestMinBlocks)
+		if k.GetLatestValsetNonce(ctx) != 0 && ctx.BlockHeight() < next {
+			return sdkerrors.Wrapf(types.ErrInvalid, "next valset request from height %d on", next)
+		}
+	case types.ValsetRequestPolicyFee:
+		if params.ValsetRequestFee.Empty() {
+			return nil
+		}
+		if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, requester, types.ModuleName, params.ValsetRequestFee); err != nil {
+			return sdkerrors.Wrap(err, "valset request fee")
+		}
+		if err := k.supplyKeeper.BurnCoins(ctx, types.ModuleName, params.ValsetRequestFee); err != nil {
+			return err
+		}
+	default:
+		return sdkerrors.Wrapf(types.ErrInvalid, "valset request policy %q", params.ValsetRequestPolicy)
+	}
+	return nil
+}
+
+// GetLastValsetRequestHeight returns the height of the latest valset request or zero if there is
+// none
+func (k Keeper) GetLastValsetRequestHeight(ctx sdk.Context) int64 {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetNonceByHeightKey)
+	iter := prefixStore.ReverseIterator(nil, nil)
+	defer iter.Close()
+	if !iter.Valid() {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(iter.Key()))
+}
+
+// GetValsetNonceByHeight returns the nonce of the valset requested at the height or zero if there
+// was none
+func (k Keeper) GetValsetNonceByHeight(ctx sdk.Context, height int64) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetValsetNonceByHeightKey(height))
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
 }
 
 func (k Keeper) GetValsetRequest(ctx sdk.Context, nonce int64) *types.Valset {
@@ -101,15 +228,64 @@ func (k Keeper) IterateValsetConfirmByNonce(ctx sdk.Context, nonce int64, cb fun
 	}
 }
 
-func (k Keeper) SetEthAddress(ctx sdk.Context, validator sdk.AccAddress, ethAddr string) {
+// SetEthAddress registers the eth address for the validator. The address is recorded as claimed
+// by the validator and added to its history under the next registration nonce.
+func (k Keeper) SetEthAddress(ctx sdk.Context, validator sdk.AccAddress, ethAddr types.EthAddress) {
 	store := ctx.KVStore(k.storeKey)
-	store.Set(types.GetEthAddressKey(validator), []byte(ethAddr))
+	store.Set(types.GetEthAddressKey(validator), ethAddr.Bytes())
+	k.recordEthAddress(ctx, validator, ethAddr)
 }
 
-func (k Keeper) GetEthAddress(ctx sdk.Context, validator sdk.AccAddress) string {
+// recordEthAddress claims the eth address for the validator and adds it to its history
+func (k Keeper) recordEthAddress(ctx sdk.Context, validator sdk.AccAddress, ethAddr types.EthAddress) {
 	store := ctx.KVStore(k.storeKey)
-	val := store.Get(types.GetEthAddressKey(validator))
-	return string(val)
+	nonce := k.GetEthAddressNonce(ctx, validator)
+	store.Set(types.GetEthAddressOwnerKey(ethAddr), validator.Bytes())
+	record := types.EthAddressRecord{EthAddress: ethAddr, Nonce: nonce, Height: ctx.BlockHeight()}
+	store.Set(types.GetEthAddressHistoryKey(validator, nonce), k.cdc.MustMarshalBinaryBare(record))
+}
+
+// GetEthAddressOwner returns the validator that registered the eth address at any time, or nil
+func (k Keeper) GetEthAddressOwner(ctx sdk.Context, ethAddr types.EthAddress) sdk.AccAddress {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetEthAddressOwnerKey(ethAddr))
+	if bz == nil {
+		return nil
+	}
+	return sdk.AccAddress(bz)
+}
+
+// GetEthAddressNonce returns the nonce the next registration of the validator has to be signed
+// with, it is the number of its earlier registrations
+func (k Keeper) GetEthAddressNonce(ctx sdk.Context, validator sdk.AccAddress) uint64 {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetEthAddressHistoryPrefix(validator))
+	iter := prefixStore.ReverseIterator(nil, nil)
+	defer iter.Close()
+	if !iter.Valid() {
+		return 0
+	}
+	return binary.BigEndian.Uint64(iter.Key()) + 1
+}
+
+// GetEthAddressHistory returns all eth addresses the validator registered in the order of their
+// registration
+func (k Keeper) GetEthAddressHistory(ctx sdk.Context, validator sdk.AccAddress) []types.EthAddressRecord {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetEthAddressHistoryPrefix(validator))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	var res []types.EthAddressRecord
+	for ; iter.Valid(); iter.Next() {
+		var record types.EthAddressRecord
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &record)
+		res = append(res, record)
+	}
+	return res
+}
+
+// GetEthAddress returns the eth address of the validator, it is empty when none was registered
+func (k Keeper) GetEthAddress(ctx sdk.Context, validator sdk.AccAddress) types.EthAddress {
+	store := ctx.KVStore(k.storeKey)
+	return types.EthAddress(store.Get(types.GetEthAddressKey(validator)))
 }
 
 type valsetSort types.Valset
@@ -122,22 +298,66 @@ func (a valsetSort) Swap(i, j int) {
 func (a valsetSort) Less(i, j int) bool {
 	// Secondary sort on eth address in case powers are equal
 	if a.Powers[i] == a.Powers[j] {
-		return a.EthAddresses[i] < a.EthAddresses[j]
+		return bytes.Compare(a.EthAddresses[i], a.EthAddresses[j]) < 0
 	}
 	return a.Powers[i] < a.Powers[j]
 }
 
-func (k Keeper) GetCurrentValset(ctx sdk.Context) types.Valset {
+// GetValidatorRegistrations returns the registration status of all bonded validators in the order
+// of the staking keeper
+func (k Keeper) GetValidatorRegistrations(ctx sdk.Context) []types.ValidatorRegistration {
 	validators := k.StakingKeeper.GetBondedValidatorsByPower(ctx)
-	ethAddrs := make([]string, len(validators))
+	res := make([]types.ValidatorRegistration, len(validators))
 	powers := make([]int64, len(validators))
 	for i, validator := range validators {
 		validatorAddress := validator.GetOperator()
-		p := k.StakingKeeper.GetLastValidatorPower(ctx, validatorAddress)
-		powers[i] = p
-		ethAddrs[i] = k.GetEthAddress(ctx, sdk.AccAddress(validatorAddress))
+		ethAddress := k.GetEthAddress(ctx, sdk.AccAddress(validatorAddress))
+		powers[i] = k.StakingKeeper.GetLastValidatorPower(ctx, validatorAddress)
+		res[i] = types.ValidatorRegistration{
+			Validator:  validatorAddress,
+			EthAddress: ethAddress,
+			Registered: !ethAddress.Empty(),
+			Power:      powers[i],
+
+			PendingEthAddress: k.GetPendingEthAddress(ctx, sdk.AccAddress(validatorAddress)),
+		}
+	}
+	for i, p := range types.NormalizePowers(powers) {
+		res[i].NormalizedPower = p
+	}
+	return res
+}
+
+// GetValidatorRegistration returns the registration status of a bonded validator or nil when it is
+// not bonded
+func (k Keeper) GetValidatorRegistration(ctx sdk.Context, validator sdk.ValAddress) *types.ValidatorRegistration {
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if r.Validator.Equals(validator) {
+			return &r
+		}
+	}
+	return nil
+}
+
+// GetCurrentValset returns the bonded validators that registered an eth address with their powers
+// normalized to types.PowerTotal, sorted by power and address. Validators without an eth address
+// are left out and their power is redistributed by the normalization.
+func (k Keeper) GetCurrentValset(ctx sdk.Context) types.Valset {
+	var (
+		ethAddrs []types.EthAddress
+		powers   []int64
+	)
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if !r.Registered {
+			continue
+		}
+		ethAddrs = append(ethAddrs, r.EthAddress)
+		powers = append(powers, r.Power)
 	}
 	valset := types.Valset{EthAddresses: ethAddrs, Powers: powers}
+	// normalize in sorted order, rounding keeps the order of validators with equal stake
+	sort.Sort(valsetSort(valset))
+	valset.Powers = types.NormalizePowers(valset.Powers)
 	sort.Sort(valsetSort(valset))
 	return valset
 }
@@ -1,8 +1,14 @@
 package keeper
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -37,3 +43,152 @@ func TestPrefixRange(t *testing.T) {
 		})
 	}
 }
+
+func ethAddr(s string) types.EthAddress {
+	addr, err := types.NewEthAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+func TestValsetEligibility(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var validators []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		validators = append(validators, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+	}
+	stakingKeeper := NewStakingKeeperMock(validators...)
+	stakingKeeper.ValidatorPower[validators[3].String()] = 50
+	k.StakingKeeper = stakingKeeper
+
+	// two of 350 power registered are not sufficient
+	k.SetEthAddress(ctx, sdk.AccAddress(validators[0]), ethAddr("0xc783df8a850f42e7f7e57013759c285caa701eb6"))
+	k.SetEthAddress(ctx, sdk.AccAddress(validators[1]), ethAddr("0xd041c41ea1bf0f006adbb6d2c9ef9d425de5ead7"))
+	_, err := k.SetValsetRequest(ctx)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	assert.Equal(t, int64(0), k.GetLatestValsetNonce(ctx))
+
+	// with 300 of 350 the unregistered power is left out and redistributed
+	k.SetEthAddress(ctx, sdk.AccAddress(validators[2]), ethAddr("0xe5904695748fe4a84b40b3fc79de2277660bd1d3"))
+	valset, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+	// registered in lower case, the valset has the checksummed form
+	assert.Equal(t, []string{
+		"0xc783df8a850f42e7F7e57013759C285caa701eB6",
+		"0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+		"0xE5904695748fe4A84b40b3fc79De2277660BD1D3",
+	}, []string{valset.EthAddresses[0].String(), valset.EthAddresses[1].String(), valset.EthAddresses[2].String()})
+	assert.Equal(t, types.NormalizePowers([]int64{100, 100, 100}), valset.Powers)
+	_, err = valset.GetCheckpoint(k.GetParams(ctx).PeggyID)
+	require.NoError(t, err)
+
+	registration := k.GetValidatorRegistration(ctx, validators[3])
+	require.NotNil(t, registration)
+	assert.False(t, registration.Registered)
+	assert.Equal(t, int64(50), registration.Power)
+	assert.Equal(t, types.NormalizePowers([]int64{100, 100, 100, 50})[3], registration.NormalizedPower)
+	registration = k.GetValidatorRegistration(ctx, validators[2])
+	require.NotNil(t, registration)
+	assert.True(t, registration.Registered)
+	assert.Nil(t, k.GetValidatorRegistration(ctx, bytes.Repeat([]byte{9}, sdk.AddrLen)))
+}
+
+func TestEthAddressHistory(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var (
+		validator = sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+		first     = ethAddr("0xc783df8a850f42e7F7e57013759C285caa701eB6")
+		second    = ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	)
+	assert.Equal(t, uint64(0), k.GetEthAddressNonce(ctx, validator))
+	assert.Empty(t, k.GetEthAddressHistory(ctx, validator))
+
+	k.SetEthAddress(ctx, validator, first)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 10)
+	k.SetEthAddress(ctx, validator, second)
+
+	assert.Equal(t, second, k.GetEthAddress(ctx, validator))
+	assert.Equal(t, uint64(2), k.GetEthAddressNonce(ctx, validator))
+	assert.Equal(t, []types.EthAddressRecord{
+		{EthAddress: first, Nonce: 0, Height: ctx.BlockHeight() - 10},
+		{EthAddress: second, Nonce: 1, Height: ctx.BlockHeight()},
+	}, k.GetEthAddressHistory(ctx, validator))
+	// the earlier address stays claimed by the validator
+	assert.Equal(t, validator, k.GetEthAddressOwner(ctx, first))
+	assert.Equal(t, validator, k.GetEthAddressOwner(ctx, second))
+	assert.Nil(t, k.GetEthAddressOwner(ctx, ethAddr("0xE5904695748fe4A84b40b3fc79De2277660BD1D3")))
+}
+
+func TestValsetNonceCounter(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	k.StakingKeeper = NewStakingKeeperMock(valAddr)
+
+	for i, height := range []int64{100, 101, 250} {
+		// a new address each time, a valset that is the same as the latest one is refused
+		k.SetEthAddress(ctx, sdk.AccAddress(valAddr), ethAddr(fmt.Sprintf("0x%040x", i+1)))
+		ctx = ctx.WithBlockHeight(height)
+		valset, err := k.SetValsetRequest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(i+1), valset.Nonce)
+		assert.Equal(t, valset.Nonce, k.GetLatestValsetNonce(ctx))
+		assert.Equal(t, valset.Nonce, k.GetValsetNonceByHeight(ctx, height))
+	}
+	assert.Equal(t, int64(0), k.GetValsetNonceByHeight(ctx, 102))
+
+	// a second request in the same block is refused and does not use up a nonce
+	k.SetEthAddress(ctx, sdk.AccAddress(valAddr), ethAddr(fmt.Sprintf("0x%040x", 4)))
+	_, err := k.SetValsetRequest(ctx)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	assert.Equal(t, int64(3), k.GetLatestValsetNonce(ctx))
+
+	// as is a request without a change to the latest valset
+	ctx = ctx.WithBlockHeight(251)
+	_, err = k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(252)
+	_, err = k.SetValsetRequest(ctx)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	assert.Equal(t, int64(4), k.GetLatestValsetNonce(ctx))
+	assert.Equal(t, int64(4), k.GetValsetRequest(ctx, 4).Nonce)
+}
+
+func TestValsetRequestPolicy(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	k.StakingKeeper = NewStakingKeeperMock(valAddr)
+	k.SetEthAddress(ctx, sdk.AccAddress(valAddr), ethAddr("0xc783df8a850f42e7F7e57013759C285caa701eB6"))
+	anyone := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+
+	// by default only validators can request
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(k.ApplyValsetRequestPolicy(ctx, anyone)))
+	assert.NoError(t, k.ApplyValsetRequestPolicy(ctx, sdk.AccAddress(valAddr)))
+
+	params := k.GetParams(ctx)
+	params.ValsetRequestPolicy = types.ValsetRequestPolicyMinBlocks
+	params.ValsetRequestMinBlocks = 10
+	k.SetParams(ctx, params)
+	ctx = ctx.WithBlockHeight(100)
+	assert.NoError(t, k.ApplyValsetRequestPolicy(ctx, anyone), "first request")
+	_, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), k.GetLastValsetRequestHeight(ctx))
+	ctx = ctx.WithBlockHeight(109)
+	assert.True(t, types.ErrInvalid.Is(k.ApplyValsetRequestPolicy(ctx, anyone)))
+	ctx = ctx.WithBlockHeight(110)
+	assert.NoError(t, k.ApplyValsetRequestPolicy(ctx, anyone))
+
+	fee := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	params.ValsetRequestPolicy = types.ValsetRequestPolicyFee
+	params.ValsetRequestFee = fee
+	k.SetParams(ctx, params)
+	assert.Error(t, k.ApplyValsetRequestPolicy(ctx, anyone), "no funds")
+	_, err = keepers.BankKeeper.AddCoins(ctx, anyone, sdk.NewCoins(sdk.NewInt64Coin("stake", 15)))
+	require.NoError(t, err)
+	keepers.SupplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.NewCoins(sdk.NewInt64Coin("stake", 15))))
+	require.NoError(t, k.ApplyValsetRequestPolicy(ctx, anyone))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 5)), keepers.BankKeeper.GetCoins(ctx, anyone))
+	// the fee is burned
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 5)), keepers.SupplyKeeper.GetSupply(ctx).GetTotal())
+}
@@ -0,0 +1,141 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// transferStatusRank orders the statuses of a transfer in a batch, a batch status only moves
+// forward
+var transferStatusRank = map[string]int{
+	types.TransferBatched:   1,
+	types.TransferSigned:    2,
+	types.TransferSubmitted: 3,
+	types.TransferObserved:  4,
+}
+
+// GetTransferStatus returns the status of the transfer to Ethereum with the id or nil
+func (k Keeper) GetTransferStatus(ctx sdk.Context, id uint64) *types.TransferStatus {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetTransferStatusKey(id))
+	if bz == nil {
+		return nil
+	}
+	var status types.TransferStatus
+	k.cdc.MustUnmarshalBinaryBare(bz, &status)
+	return &status
+}
+
+// GetTransferStatusesBySender returns the statuses of the transfers of the sender in the order of
+// their IDs
+func (k Keeper) GetTransferStatusesBySender(ctx sdk.Context, sender sdk.AccAddress) []types.TransferStatus {
+	res := []types.TransferStatus{}
+	for _, id := range k.indexedIDs(ctx, types.GetTransferBySenderPrefix(sender)) {
+		res = append(res, *k.GetTransferStatus(ctx, id))
+	}
+	return res
+}
+
+// GetTransferStatusesByRecipient returns the statuses of the transfers to the eth address in the
+// order of their IDs
+func (k Keeper) GetTransferStatusesByRecipient(ctx sdk.Context, recipient types.EthAddress) []types.TransferStatus {
+	res := []types.TransferStatus{}
+	for _, id := range k.indexedIDs(ctx, types.GetTransferByRecipientPrefix(recipient)) {
+		res = append(res, *k.GetTransferStatus(ctx, id))
+	}
+	return res
+}
+
+// UpdateBatchSigned moves the transfers of the batch to signed once the confirms over the batch,
+// or over the batch combined with the valset with the nonce, hold a quorum of the power of the
+// last valset observed on Ethereum. Only confirms signed with a key of that valset count, as in
+// the relay payload.
+func (k Keeper) UpdateBatchSigned(ctx sdk.Context, batchNonce int64, valsetNonce int64) {
+	batch := k.GetOutgoingTXBatch(ctx, batchNonce)
+	currentValset := k.GetValsetRequest(ctx, k.GetLastObservedValsetNonce(ctx))
+	if batch == nil || currentValset == nil {
+		return
+	}
+	powers := make(map[string]int64, len(currentValset.EthAddresses))
+	for i, ethAddress := range currentValset.EthAddresses {
+		powers[ethAddress.String()] = currentValset.Powers[i]
+	}
+	var power int64
+	k.IterateBatchConfirmByNonce(ctx, batchNonce, valsetNonce, func(_ []byte, c types.BatchConfirm) bool {
+		power += powers[k.batchConfirmSigner(ctx, c, currentValset.Nonce).String()]
+		return false
+	})
+	if !types.HasQuorum(power) {
+		return
+	}
+	k.updateBatchStatus(ctx, *batch, types.TransferSigned)
+}
+
+// GetDepositStatusesByRecipient returns the statuses of the deposits to the recipient in the order
+// of their IDs
+func (k Keeper) GetDepositStatusesByRecipient(ctx sdk.Context, recipient sdk.AccAddress) []types.DepositStatus {
+	res := []types.DepositStatus{}
+	for _, id := range k.indexedIDs(ctx, types.GetDepositByRecipientPrefix(recipient)) {
+		res = append(res, *k.GetDepositStatus(ctx, id))
+	}
+	return res
+}
+
+// newTransfer starts tracking the status of a new transfer to Ethereum and returns it with its
+// transfer ID, the caller sets the status
+func (k Keeper) newTransfer(ctx sdk.Context, sender sdk.AccAddress, destAddress types.EthAddress, amount sdk.Coin, fee sdk.Coin) types.OutgoingTx {
+	tx := types.OutgoingTx{
+		Sender:      sender,
+		DestAddress: destAddress,
+		Amount:      amount,
+		BridgeFee:   fee,
+		TransferID:  k.autoIncrementID(ctx, types.KeyLastTransferID),
+	}
+	status := types.TransferStatus{
+		ID:          tx.TransferID,
+		Sender:      sender,
+		DestAddress: destAddress,
+		Amount:      amount,
+		BridgeFee:   fee,
+		Height:      ctx.BlockHeight(),
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetTransferStatusKey(status.ID), k.cdc.MustMarshalBinaryBare(status))
+	store.Set(types.GetTransferBySenderKey(sender, status.ID), []byte{1})
+	store.Set(types.GetTransferByRecipientKey(destAddress, status.ID), []byte{1})
+	return tx
+}
+
+// updateTransferStatus applies the change to the status of the transfer with the id. Transfers
+// that were queued before their status was tracked have none and are skipped.
+func (k Keeper) updateTransferStatus(ctx sdk.Context, id uint64, change func(*types.TransferStatus)) {
+	status := k.GetTransferStatus(ctx, id)
+	if status == nil {
+		return
+	}
+	change(status)
+	status.Height = ctx.BlockHeight()
+	ctx.KVStore(k.storeKey).Set(types.GetTransferStatusKey(id), k.cdc.MustMarshalBinaryBare(*status))
+}
+
+// updateBatchStatus moves the transfers of the batch forward to the status
+func (k Keeper) updateBatchStatus(ctx sdk.Context, batch types.OutgoingTxBatch, status string) {
+	for _, tx := range batch.Elements {
+		k.updateTransferStatus(ctx, tx.TransferID, func(s *types.TransferStatus) {
+			if transferStatusRank[status] > transferStatusRank[s.Status] {
+				s.Status = status
+				s.BatchNonce = batch.Nonce
+			}
+		})
+	}
+}
+
+// indexedIDs returns the IDs at the end of the keys under the prefix of an index
+func (k Keeper) indexedIDs(ctx sdk.Context, indexPrefix []byte) []uint64 {
+	var ids []uint64
+	for _, key := range prefixKeys(ctx.KVStore(k.storeKey), indexPrefix) {
+		ids = append(ids, binary.BigEndian.Uint64(key))
+	}
+	return ids
+}
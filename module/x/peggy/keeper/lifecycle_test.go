@@ -0,0 +1,146 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferLifecycle(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	var validators []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		validators = append(validators, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(validators...)
+	params := k.GetParams(ctx)
+	params.TimeLockThresholds = []types.TimeLockThreshold{{Denom: "mytoken", Threshold: sdk.NewInt(1000)}}
+	params.TimeLockBlocks = 50
+	k.SetParams(ctx, params)
+
+	sender := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	// minted through the supply so that the batch can burn the vouchers
+	coins := sdk.NewCoins(sdk.NewInt64Coin("mytoken", 5000))
+	require.NoError(t, keepers.SupplyKeeper.MintCoins(ctx, types.ModuleName, coins))
+	require.NoError(t, keepers.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sender, coins))
+	ctx = ctx.WithBlockHeight(10)
+
+	fee := sdk.NewInt64Coin("mytoken", 10)
+	for _, amount := range []int64{100, 2000} {
+		require.NoError(t, k.EscrowTransfer(ctx, sender, sdk.NewInt64Coin("mytoken", amount), fee))
+	}
+	pooled := k.AddToOutgoingPool(ctx, sender, dest, sdk.NewInt64Coin("mytoken", 100), fee)
+	locked := k.AddTimeLockedTransfer(ctx, sender, dest, sdk.NewInt64Coin("mytoken", 2000), fee)
+	assert.Equal(t, uint64(1), pooled.TransferID)
+	assert.Equal(t, uint64(2), locked.Transfer.TransferID)
+	assert.Equal(t, &types.TransferStatus{
+		ID:          1,
+		Sender:      sender,
+		DestAddress: dest,
+		Amount:      sdk.NewInt64Coin("mytoken", 100),
+		BridgeFee:   fee,
+		Status:      types.TransferPooled,
+		PoolID:      1,
+		Height:      10,
+	}, k.GetTransferStatus(ctx, 1))
+	status := k.GetTransferStatus(ctx, 2)
+	assert.Equal(t, types.TransferTimeLocked, status.Status)
+	assert.Equal(t, uint64(1), status.TimeLockID)
+
+	// the released transfer keeps its transfer ID in the pool
+	ctx = ctx.WithBlockHeight(60)
+	k.ReleaseTimeLockedTransfers(ctx)
+	status = k.GetTransferStatus(ctx, 2)
+	assert.Equal(t, types.TransferPooled, status.Status)
+	assert.Equal(t, uint64(2), status.PoolID)
+	assert.Equal(t, int64(60), status.Height)
+
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	for _, s := range k.GetTransferStatusesBySender(ctx, sender) {
+		assert.Equal(t, types.TransferBatched, s.Status)
+		assert.Equal(t, batch.Nonce, s.BatchNonce)
+	}
+
+	_, err = k.SetBatchInChain(ctx, batch.Nonce+1, sdk.AccAddress(validators[0]))
+	assert.True(t, types.ErrUnknown.Is(err), err)
+	for i, validator := range validators[:3] {
+		_, err := k.SetBatchInChain(ctx, batch.Nonce, sdk.AccAddress(validator))
+		require.NoError(t, err)
+		expected := types.TransferSubmitted
+		if i == 2 {
+			expected = types.TransferObserved
+		}
+		assert.Equal(t, expected, k.GetTransferStatus(ctx, 1).Status)
+	}
+	// a late attestation does not move the transfers back
+	_, err = k.SetBatchInChain(ctx, batch.Nonce, sdk.AccAddress(validators[3]))
+	require.NoError(t, err)
+	statuses := k.GetTransferStatusesByRecipient(ctx, dest)
+	require.Len(t, statuses, 2)
+	for _, s := range statuses {
+		assert.Equal(t, types.TransferObserved, s.Status)
+	}
+	assert.Empty(t, k.GetTransferStatusesByRecipient(ctx, ethAddr("0x7c2C195CD6D34B8F845992d380aADB2730bB9C6F")))
+}
+
+func TestUpdateBatchSigned(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var validators []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		validator := bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen)
+		k.SetEthAddress(ctx, sdk.AccAddress(validator), types.EthAddress(bytes.Repeat([]byte{byte(i + 1)}, types.EthAddressLength)))
+		validators = append(validators, validator)
+	}
+	k.StakingKeeper = NewStakingKeeperMock(validators...)
+	valset, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+	for _, validator := range validators {
+		k.SetValsetObservation(ctx, types.NewMsgValsetObserved(valset.Nonce, sdk.AccAddress(validator)))
+	}
+	sender := sdk.AccAddress(validators[0])
+	AddEscrowedTransfer(t, k, ctx, sender, ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"), sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 1))
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+
+	confirm := func(validator sdk.ValAddress, ethSigner types.EthAddress) {
+		k.SetBatchConfirm(ctx, types.NewMsgConfirmBatch(batch.Nonce, 0, sdk.AccAddress(validator), "sig"), ethSigner)
+		k.UpdateBatchSigned(ctx, batch.Nonce, 0)
+	}
+	for _, validator := range validators[:2] {
+		confirm(validator, k.GetEthAddress(ctx, sdk.AccAddress(validator)))
+	}
+	// a confirm made with a key outside of the observed valset holds no power
+	confirm(validators[2], types.EthAddress(bytes.Repeat([]byte{9}, types.EthAddressLength)))
+	assert.Equal(t, types.TransferBatched, k.GetTransferStatus(ctx, 1).Status)
+	confirm(validators[3], k.GetEthAddress(ctx, sdk.AccAddress(validators[3])))
+	assert.Equal(t, types.TransferSigned, k.GetTransferStatus(ctx, 1).Status)
+}
+
+func TestGetDepositStatusesByRecipient(t *testing.T) {
+	k, ctx, _ := CreateTestEnvWithKeepers(t)
+	validator := bytes.Repeat([]byte{1}, sdk.AddrLen)
+	k.StakingKeeper = NewStakingKeeperMock(validator)
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	other := sdk.AccAddress(bytes.Repeat([]byte{8}, sdk.AddrLen))
+	txHash := "0x35d2fd082787280e325543086c269c912becb598fd43bb58fae254bb8efd9a16"
+
+	k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, sdk.NewInt64Coin("mytoken", 100), txHash, 0))
+	k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, other, sdk.NewInt64Coin("mytoken", 100), txHash, 1))
+	k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, sdk.NewInt64Coin("mytoken", 200), txHash, 2))
+	// a second claim of the same deposit is not indexed again
+	k.ClaimDeposit(ctx, types.NewMsgEthDeposit(validator, recipient, sdk.NewInt64Coin("mytoken", 200), txHash, 2))
+
+	statuses := k.GetDepositStatusesByRecipient(ctx, recipient)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, uint64(1), statuses[0].ID)
+	assert.Equal(t, uint64(3), statuses[1].ID)
+	assert.Equal(t, types.DepositMinted, statuses[0].Status)
+	assert.Len(t, k.GetDepositStatusesByRecipient(ctx, other), 1)
+	assert.Empty(t, k.GetDepositStatusesByRecipient(ctx, sdk.AccAddress(bytes.Repeat([]byte{7}, sdk.AddrLen))))
+}
@@ -0,0 +1,268 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+)
+
+// UpgradeName is the name of the upgrade plan that runs Migrate
+const UpgradeName = "peggy-v2"
+
+// Migrate brings the store of a chain that runs the first release of the module up to date. The
+// migrations run in the order they were added, later ones rely on the state the earlier ones left.
+func (k Keeper) Migrate(ctx sdk.Context) {
+	k.MigrateEthAddressesToBytes(ctx)
+	k.MigrateEthAddressRegistry(ctx)
+	k.MigrateValsetNonceCounter(ctx)
+	k.MigrateParams(ctx)
+	k.MigrateValsetPruning(ctx)
+	k.MigrateDepositMinting(ctx)
+	k.MigrateRateLimits(ctx)
+	k.MigrateTimeLocks(ctx)
+	k.MigrateBridgeFees(ctx)
+	k.MigrateGasModel(ctx)
+	k.MigrateNativeTokens(ctx)
+	k.MigrateTokenDecimals(ctx)
+	k.MigrateObservedTxNonce(ctx)
+}
+
+// MigrateEthAddressesToBytes converts the eth addresses that were stored as hex strings to their
+// 20 bytes. The binary encoding of a string and of bytes is the same, the registrations, valset
+// requests, pooled transfers and batches are therefore decoded as they are and only the address
+// values are rewritten. Registrations that are no valid address are removed, the validators have
+// to register again.
+func (k Keeper) MigrateEthAddressesToBytes(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+
+	ethAddressStore := prefix.NewStore(store, types.EthAddressKey)
+	// updates are collected in key order and applied after the iteration, the order of the writes
+	// shapes the tree and has to be the same on every node
+	var (
+		registrationKeys [][]byte
+		registrations    []types.EthAddress
+		invalid          [][]byte
+	)
+	iter := ethAddressStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		if len(iter.Value()) == types.EthAddressLength {
+			continue
+		}
+		if addr, ok := types.LegacyEthAddress(iter.Value()); ok {
+			registrationKeys = append(registrationKeys, iter.Key())
+			registrations = append(registrations, addr)
+		} else {
+			invalid = append(invalid, iter.Key())
+		}
+	}
+	iter.Close()
+	for i, key := range registrationKeys {
+		ethAddressStore.Set(key, registrations[i])
+	}
+	for _, key := range invalid {
+		ethAddressStore.Delete(key)
+	}
+
+	migrateEntries(store, types.ValsetRequestKey, func(bz []byte) []byte {
+		var valset types.Valset
+		k.cdc.MustUnmarshalBinaryBare(bz, &valset)
+		changed := false
+		for i := range valset.EthAddresses {
+			changed = migrateLegacyEthAddress(&valset.EthAddresses[i]) || changed
+		}
+		if !changed {
+			return nil
+		}
+		return k.cdc.MustMarshalBinaryBare(valset)
+	})
+	migrateEntries(store, types.OutgoingTXPoolKey, func(bz []byte) []byte {
+		var tx types.OutgoingTx
+		k.cdc.MustUnmarshalBinaryBare(bz, &tx)
+		if !migrateLegacyEthAddress(&tx.DestAddress) {
+			return nil
+		}
+		return k.cdc.MustMarshalBinaryBare(tx)
+	})
+	migrateEntries(store, types.OutgoingTXBatchKey, func(bz []byte) []byte {
+		var batch types.OutgoingTxBatch
+		k.cdc.MustUnmarshalBinaryBare(bz, &batch)
+		changed := false
+		for i := range batch.Elements {
+			changed = migrateLegacyEthAddress(&batch.Elements[i].DestAddress) || changed
+		}
+		if !changed {
+			return nil
+		}
+		return k.cdc.MustMarshalBinaryBare(batch)
+	})
+}
+
+// migrateEntries replaces every value under the prefix with the result of convert in key order, nil
+// keeps the value as it is
+func migrateEntries(store sdk.KVStore, keyPrefix []byte, convert func([]byte) []byte) {
+	prefixStore := prefix.NewStore(store, keyPrefix)
+	var keys, values [][]byte
+	iter := prefixStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		if bz := convert(iter.Value()); bz != nil {
+			keys = append(keys, iter.Key())
+			values = append(values, bz)
+		}
+	}
+	iter.Close()
+	for i, key := range keys {
+		prefixStore.Set(key, values[i])
+	}
+}
+
+// migrateLegacyEthAddress converts a hex string address in place and returns true when it was one
+func migrateLegacyEthAddress(a *types.EthAddress) bool {
+	addr, ok := types.LegacyEthAddress(*a)
+	if ok {
+		*a = addr
+	}
+	return ok
+}
+
+// MigrateEthAddressRegistry records the registrations made before eth addresses were bound to a
+// nonce as first entry of the history of their validator and as claimed by it. When validators
+// registered the same address the one with the highest key keeps the claim.
+func (k Keeper) MigrateEthAddressRegistry(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	ethAddressStore := prefix.NewStore(store, types.EthAddressKey)
+	var (
+		validators []sdk.AccAddress
+		addresses  []types.EthAddress
+	)
+	iter := ethAddressStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		validators = append(validators, sdk.AccAddress(iter.Key()))
+		addresses = append(addresses, types.EthAddress(iter.Value()))
+	}
+	iter.Close()
+	for i, validator := range validators {
+		if k.GetEthAddressNonce(ctx, validator) != 0 {
+			continue
+		}
+		k.SetEthAddress(ctx, validator, addresses[i])
+	}
+}
+
+// MigrateValsetNonceCounter starts the valset nonce counter after the valset requests that were
+// stored under their block height as nonce, so that the nonces keep increasing for the contract,
+// and indexes those requests by their height.
+func (k Keeper) MigrateValsetNonceCounter(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	var nonces []int64
+	k.IterateValsetRequest(ctx, func(_ []byte, val types.Valset) bool {
+		nonces = append(nonces, val.Nonce)
+		return false
+	})
+	// the requests are iterated in descending order, the index is written in ascending order
+	for i := len(nonces) - 1; i >= 0; i-- {
+		store.Set(types.GetValsetNonceByHeightKey(nonces[i]), sdk.Uint64ToBigEndian(uint64(nonces[i])))
+	}
+	if len(nonces) != 0 {
+		store.Set(types.KeyLastValsetNonce, sdk.Uint64ToBigEndian(uint64(nonces[0])))
+	}
+}
+
+// MigrateParams stores the default params for a chain that started without them. The PeggyID is
+// the legacy id, the contract of the chain was deployed with it.
+func (k Keeper) MigrateParams(ctx sdk.Context) {
+	params := types.DefaultParams()
+	params.PeggyID = types.LegacyPeggyID
+	k.SetParams(ctx, params)
+}
+
+// MigrateValsetPruning records the valset requests made before pruning with the upgrade time as
+// request time, their confirms are therefore kept as evidence for the full unbonding time from now
+// on. It relies on the height index of MigrateValsetNonceCounter. The retention window param is set
+// to its default.
+func (k Keeper) MigrateValsetPruning(ctx sdk.Context) {
+	var records []types.ValsetRequestRecord
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetNonceByHeightKey)
+	iter := prefixStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		nonce := int64(binary.BigEndian.Uint64(iter.Value()))
+		if k.GetValsetRequestRecord(ctx, nonce) != nil {
+			continue
+		}
+		height := int64(binary.BigEndian.Uint64(iter.Key()))
+		records = append(records, types.ValsetRequestRecord{Nonce: nonce, Height: height, Time: ctx.BlockTime()})
+	}
+	iter.Close()
+	for _, record := range records {
+		k.setValsetRequestRecord(ctx, record)
+	}
+	k.paramSpace.Set(ctx, types.KeyValsetRetentionBlocks, types.DefaultValsetRetentionBlocks)
+}
+
+// MigrateDepositMinting gives the module account the minter permission that deposits are minted
+// with. The permissions of a module account are stored when it is created, so they are reset to
+// the ones the app configures now.
+func (k Keeper) MigrateDepositMinting(ctx sdk.Context) {
+	acc, perms := k.supplyKeeper.GetModuleAccountAndPermissions(ctx, types.ModuleName)
+	macc, ok := acc.(*supply.ModuleAccount)
+	if !ok {
+		return
+	}
+	macc.Permissions = perms
+	k.supplyKeeper.SetModuleAccount(ctx, macc)
+}
+
+// MigrateRateLimits adds the rate limits param without any limit
+func (k Keeper) MigrateRateLimits(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyRateLimits, []types.RateLimit{})
+}
+
+// MigrateTimeLocks adds the time lock params without any threshold
+func (k Keeper) MigrateTimeLocks(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyTimeLockThresholds, []types.TimeLockThreshold{})
+	k.paramSpace.Set(ctx, types.KeyTimeLockBlocks, types.DefaultTimeLockBlocks)
+}
+
+// MigrateBridgeFees adds the bridge fee params without minimum fees or other fee denoms
+func (k Keeper) MigrateBridgeFees(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyMinBridgeFees, []types.MinBridgeFee{})
+	k.paramSpace.Set(ctx, types.KeyFeeDenoms, []types.FeeDenom{})
+}
+
+// MigrateGasModel adds the gas cost model of submitBatch without eth values, batches are built
+// regardless of the gas price until governance sets them. The attestation expiry is set to its
+// default.
+func (k Keeper) MigrateGasModel(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyBatchBaseGas, types.DefaultBatchBaseGas)
+	k.paramSpace.Set(ctx, types.KeyBatchGasPerTransfer, types.DefaultBatchGasPerTransfer)
+	k.paramSpace.Set(ctx, types.KeyEthValues, []types.EthValue{})
+	k.paramSpace.Set(ctx, types.KeyAttestationExpiryBlocks, types.DefaultAttestationExpiryBlocks)
+}
+
+// MigrateNativeTokens adds the native token param without any token, all denoms keep originating
+// on Ethereum until governance adds them
+func (k Keeper) MigrateNativeTokens(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyNativeTokens, []types.NativeToken{})
+}
+
+// MigrateTokenDecimals adds the token decimals param without any decimals, amounts keep passing
+// unchanged between Cosmos and Ethereum until governance sets them
+func (k Keeper) MigrateTokenDecimals(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyTokenDecimals, []types.TokenDecimals{})
+}
+
+// MigrateObservedTxNonce records the last tx nonce of the batches a quorum attested executed, so
+// that they are not pending anymore and later observations know which batches they supersede
+func (k Keeper) MigrateObservedTxNonce(ctx sdk.Context) {
+	var last uint64
+	k.IterateOutgoingTXBatches(ctx, func(_ []byte, batch types.OutgoingTxBatch) bool {
+		lastID := batch.Elements[len(batch.Elements)-1].ID
+		if lastID > last && types.HasQuorum(k.batchInChainPower(ctx, batch.Nonce)) {
+			last = lastID
+		}
+		return false
+	})
+	ctx.KVStore(k.storeKey).Set(types.KeyLastObservedTxNonce, sdk.Uint64ToBigEndian(last))
+}
@@ -0,0 +1,149 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateEthAddressesToBytes(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	store := ctx.KVStore(k.storeKey)
+	var (
+		validator      = sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+		otherValidator = sdk.AccAddress(bytes.Repeat([]byte{2}, sdk.AddrLen))
+		migrated       = sdk.AccAddress(bytes.Repeat([]byte{3}, sdk.AddrLen))
+		addr           = ethAddr("0xc783df8a850f42e7F7e57013759C285caa701eB6")
+		dest           = ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	)
+
+	// the hex strings addresses were stored as before, in the case they were submitted in
+	store.Set(types.GetEthAddressKey(validator), []byte("0xc783df8a850f42e7f7e57013759c285caa701eb6"))
+	store.Set(types.GetEthAddressKey(otherValidator), []byte("not an address"))
+	k.SetEthAddress(ctx, migrated, dest)
+	legacy := func(a types.EthAddress) types.EthAddress { return types.EthAddress(a.String()) }
+	valset := types.Valset{Nonce: 5, Powers: []int64{1, 2}, EthAddresses: []types.EthAddress{legacy(addr), legacy(dest)}}
+	store.Set(types.GetValsetRequestKey(5), k.cdc.MustMarshalBinaryBare(valset))
+	AddEscrowedTransfer(t, k, ctx, validator, legacy(dest), sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 1))
+	AddEscrowedTransfer(t, k, ctx, validator, legacy(dest), sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 2))
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	AddEscrowedTransfer(t, k, ctx, validator, legacy(dest), sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 3))
+
+	k.MigrateEthAddressesToBytes(ctx)
+
+	assert.Equal(t, addr, k.GetEthAddress(ctx, validator))
+	assert.True(t, k.GetEthAddress(ctx, otherValidator).Empty())
+	assert.Equal(t, dest, k.GetEthAddress(ctx, migrated))
+	assert.Equal(t, []types.EthAddress{addr, dest}, k.GetValsetRequest(ctx, 5).EthAddresses)
+	gotBatch := k.GetOutgoingTXBatch(ctx, batch.Nonce)
+	require.NotNil(t, gotBatch)
+	require.Len(t, gotBatch.Elements, 2)
+	for _, tx := range gotBatch.Elements {
+		assert.Equal(t, dest, tx.DestAddress)
+	}
+	var pooled int
+	k.IterateOutgoingPool(ctx, func(tx types.OutgoingTx) bool {
+		assert.Equal(t, dest, tx.DestAddress)
+		pooled++
+		return false
+	})
+	// the other two left the pool with the batch
+	assert.Equal(t, 1, pooled)
+}
+
+func TestMigrateEthAddressRegistry(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	store := ctx.KVStore(k.storeKey)
+	var (
+		legacyValidator = sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+		validator       = sdk.AccAddress(bytes.Repeat([]byte{2}, sdk.AddrLen))
+		legacyAddr      = ethAddr("0xc783df8a850f42e7F7e57013759C285caa701eB6")
+		addr            = ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	)
+	// registrations were only stored under the validator before
+	store.Set(types.GetEthAddressKey(legacyValidator), legacyAddr.Bytes())
+	k.SetEthAddress(ctx, validator, addr)
+	require.Nil(t, k.GetEthAddressOwner(ctx, legacyAddr))
+	require.Equal(t, uint64(0), k.GetEthAddressNonce(ctx, legacyValidator))
+
+	k.MigrateEthAddressRegistry(ctx)
+
+	assert.Equal(t, legacyValidator, k.GetEthAddressOwner(ctx, legacyAddr))
+	assert.Equal(t, uint64(1), k.GetEthAddressNonce(ctx, legacyValidator))
+	assert.Equal(t, []types.EthAddressRecord{{EthAddress: legacyAddr, Nonce: 0, Height: ctx.BlockHeight()}}, k.GetEthAddressHistory(ctx, legacyValidator))
+	// registrations that have a history are kept as they are
+	assert.Equal(t, uint64(1), k.GetEthAddressNonce(ctx, validator))
+	assert.Len(t, k.GetEthAddressHistory(ctx, validator), 1)
+}
+
+func TestMigrateValsetNonceCounter(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	k.StakingKeeper = NewStakingKeeperMock(valAddr)
+	k.SetEthAddress(ctx, sdk.AccAddress(valAddr), ethAddr("0xc783df8a850f42e7F7e57013759C285caa701eB6"))
+	store := ctx.KVStore(k.storeKey)
+	// requests were stored under their block height as nonce
+	for _, height := range []int64{100, 150} {
+		valset := k.GetCurrentValset(ctx)
+		valset.Nonce = height
+		store.Set(types.GetValsetRequestKey(height), k.cdc.MustMarshalBinaryBare(valset))
+	}
+
+	k.MigrateValsetNonceCounter(ctx)
+
+	assert.Equal(t, int64(150), k.GetLatestValsetNonce(ctx))
+	assert.Equal(t, int64(100), k.GetValsetNonceByHeight(ctx, 100))
+	assert.Equal(t, int64(150), k.GetValsetNonceByHeight(ctx, 150))
+	// the next nonce is greater than the legacy ones, a request in a block that had one is refused
+	ctx = ctx.WithBlockHeight(150)
+	_, err := k.SetValsetRequest(ctx)
+	assert.Error(t, err)
+	ctx = ctx.WithBlockHeight(151)
+	k.SetEthAddress(ctx, sdk.AccAddress(valAddr), ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"))
+	valset, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(151), valset.Nonce)
+}
+
+func TestMigrateValsetPruning(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	k.StakingKeeper = NewStakingKeeperMock(valAddr)
+	k.SetEthAddress(ctx, sdk.AccAddress(valAddr), ethAddr("0xc783df8a850f42e7F7e57013759C285caa701eB6"))
+	ctx = ctx.WithBlockHeight(100).WithBlockTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	_, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+	// a request made before they were recorded
+	ctx.KVStore(k.storeKey).Delete(types.GetValsetRequestRecordKey(1))
+
+	upgradeTime := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	ctx = ctx.WithBlockHeight(200).WithBlockTime(upgradeTime)
+	k.MigrateValsetPruning(ctx)
+
+	assert.Equal(t, &types.ValsetRequestRecord{Nonce: 1, Height: 100, Time: upgradeTime}, k.GetValsetRequestRecord(ctx, 1))
+	assert.Equal(t, types.DefaultValsetRetentionBlocks, k.GetParams(ctx).ValsetRetentionBlocks)
+}
+
+func TestMigrate(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	k.StakingKeeper = NewStakingKeeperMock(valAddr)
+	k.SetEthAddress(ctx, sdk.AccAddress(valAddr), ethAddr("0xc783df8a850f42e7F7e57013759C285caa701eB6"))
+	ctx = ctx.WithBlockHeight(100)
+	_, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+
+	k.Migrate(ctx)
+
+	params := types.DefaultParams()
+	params.PeggyID = types.LegacyPeggyID
+	assert.True(t, params.Equal(k.GetParams(ctx)))
+	assert.NotNil(t, k.GetValsetRequestRecord(ctx, 1))
+	assert.Equal(t, int64(1), k.GetLatestValsetNonce(ctx))
+}
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// GetPauseState returns the circuit breaker state, the bridge is active when it was never set
+func (k Keeper) GetPauseState(ctx sdk.Context) types.PauseState {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.KeyPauseState)
+	if bz == nil {
+		return types.PauseState{}
+	}
+	var state types.PauseState
+	k.cdc.MustUnmarshalBinaryBare(bz, &state)
+	return state
+}
+
+// SetPaused pauses or resumes the bridge. The pause votes are cleared with every change, a new
+// pause needs new votes.
+func (k Keeper) SetPaused(ctx sdk.Context, paused bool, source string) {
+	store := ctx.KVStore(k.storeKey)
+	state := types.PauseState{Paused: paused, Height: ctx.BlockHeight(), Source: source}
+	store.Set(types.KeyPauseState, k.cdc.MustMarshalBinaryBare(state))
+	deletePrefix(store, types.PauseVoteKey)
+}
+
+// AssertNotPaused returns types.ErrPaused while the bridge is paused
+func (k Keeper) AssertNotPaused(ctx sdk.Context) error {
+	if state := k.GetPauseState(ctx); state.Paused {
+		return sdkerrors.Wrapf(types.ErrPaused, "by %s at height %d", state.Source, state.Height)
+	}
+	return nil
+}
+
+// SetPauseVote records the vote of the validator to pause the bridge. When the voting validators
+// hold a quorum of the bonded power the bridge is paused and true is returned.
+func (k Keeper) SetPauseVote(ctx sdk.Context, validator sdk.AccAddress) bool {
+	if k.GetPauseState(ctx).Paused {
+		return false
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPauseVoteKey(validator), []byte{1})
+
+	var power int64
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if store.Has(types.GetPauseVoteKey(sdk.AccAddress(r.Validator))) {
+			power += r.NormalizedPower
+		}
+	}
+	if !types.HasQuorum(power) {
+		return false
+	}
+	k.SetPaused(ctx, true, types.PauseSourceValidators)
+	return true
+}
+
+// GetPauseVotes returns the validators that voted to pause the bridge since its last change
+func (k Keeper) GetPauseVotes(ctx sdk.Context) []sdk.AccAddress {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.PauseVoteKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	var res []sdk.AccAddress
+	for ; iter.Valid(); iter.Next() {
+		res = append(res, sdk.AccAddress(iter.Key()))
+	}
+	return res
+}
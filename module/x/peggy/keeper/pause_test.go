@@ -0,0 +1,45 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseVotes(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var validators []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		validators = append(validators, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(validators...)
+	AddEscrowedTransfer(t, k, ctx, sdk.AccAddress(validators[0]), ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"), sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 1))
+	require.NoError(t, k.AssertNotPaused(ctx))
+
+	// two of four validators are no supermajority
+	ctx = ctx.WithBlockHeight(10)
+	assert.False(t, k.SetPauseVote(ctx, sdk.AccAddress(validators[0])))
+	assert.False(t, k.SetPauseVote(ctx, sdk.AccAddress(validators[1])))
+	assert.False(t, k.GetPauseState(ctx).Paused)
+	assert.Len(t, k.GetPauseVotes(ctx), 2)
+
+	// the third one pauses the bridge
+	assert.True(t, k.SetPauseVote(ctx, sdk.AccAddress(validators[2])))
+	assert.Equal(t, types.PauseState{Paused: true, Height: 10, Source: types.PauseSourceValidators}, k.GetPauseState(ctx))
+	assert.Empty(t, k.GetPauseVotes(ctx))
+	assert.True(t, types.ErrPaused.Is(k.AssertNotPaused(ctx)))
+	_, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	assert.True(t, types.ErrPaused.Is(err), err)
+
+	// governance resumes it, earlier votes do not count towards the next pause
+	ctx = ctx.WithBlockHeight(20)
+	k.SetPaused(ctx, false, types.PauseSourceGovernance)
+	assert.Equal(t, types.PauseState{Paused: false, Height: 20, Source: types.PauseSourceGovernance}, k.GetPauseState(ctx))
+	assert.False(t, k.SetPauseVote(ctx, sdk.AccAddress(validators[3])))
+	_, err = k.BuildOutgoingTXBatch(ctx, "mytoken")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,388 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// OutgoingTxBatchSize is the maximum number of transfers that are put into a single batch
+const OutgoingTxBatchSize = 100
+
+// AddToOutgoingPool adds a transfer to the Peggy Bridge Tx pool and returns it with the pool ID it was
+// stored under and its transfer ID
+func (k Keeper) AddToOutgoingPool(ctx sdk.Context, sender sdk.AccAddress, destAddress types.EthAddress, amount sdk.Coin, fee sdk.Coin) types.OutgoingTx {
+	return k.addToPool(ctx, k.newTransfer(ctx, sender, destAddress, amount, fee))
+}
+
+// addToPool stores the transfer in the pool under the next pool ID
+func (k Keeper) addToPool(ctx sdk.Context, tx types.OutgoingTx) types.OutgoingTx {
+	tx.ID = k.autoIncrementID(ctx, types.KeyLastTXPoolID)
+	k.setPoolTx(ctx, tx)
+	k.updateTransferStatus(ctx, tx.TransferID, func(s *types.TransferStatus) {
+		s.Status = types.TransferPooled
+		s.PoolID = tx.ID
+	})
+	return tx
+}
+
+// setPoolTx stores the transfer in the pool under its pool ID, it entered the pool in this block
+func (k Keeper) setPoolTx(ctx sdk.Context, tx types.OutgoingTx) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetOutgoingTxPoolKey(tx.ID), k.cdc.MustMarshalBinaryBare(tx))
+	store.Set(types.GetOutgoingTxTimeKey(tx.ID), k.cdc.MustMarshalBinaryBare(ctx.BlockTime()))
+}
+
+// EscrowTransfer moves the amount and fee of a transfer to Ethereum from the sender into the module
+// account, they stay there until a batch carries the transfer or a veto refunds them
+func (k Keeper) EscrowTransfer(ctx sdk.Context, sender sdk.AccAddress, amount sdk.Coin, fee sdk.Coin) error {
+	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, types.TransferCoins(amount, fee))
+}
+
+// IterateOutgoingPool iterates through all transfers in the pool in ASC order of their ID
+func (k Keeper) IterateOutgoingPool(ctx sdk.Context, cb func(tx types.OutgoingTx) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.OutgoingTXPoolKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var tx types.OutgoingTx
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &tx)
+		// cb returns true to stop early
+		if cb(tx) {
+			break
+		}
+	}
+}
+
+// BuildOutgoingTXBatch takes up to OutgoingTxBatchSize transfers of the given denom with the highest
+// fees out of the pool and stores them as a new batch. The transfers inside the batch are ordered
+// by ID so that the tx nonces are strictly increasing as the contract requires. No batches are
+// built while the bridge is paused. Transfers from or to a blocked address are left in the pool
+// until governance unblocks the address.
+func (k Keeper) BuildOutgoingTXBatch(ctx sdk.Context, denom string) (*types.OutgoingTxBatch, error) {
+	if err := k.AssertNotPaused(ctx); err != nil {
+		return nil, err
+	}
+	params := k.GetParams(ctx)
+	selected := k.batchCandidates(ctx, params, denom)
+	if len(selected) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrEmpty, "no outgoing transfers for denom")
+	}
+	if len(selected) > OutgoingTxBatchSize {
+		selected = selected[:OutgoingTxBatchSize]
+	}
+	// the transfers left out stay in the pool until the gas price falls or a batch with more
+	// transfers shares the base gas
+	selected = k.profitableTransfers(ctx, params, denom, selected)
+	if len(selected) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrEmpty, "no outgoing transfers cover their gas")
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].ID < selected[j].ID
+	})
+
+	store := ctx.KVStore(k.storeKey)
+	totalFee := sdk.NewCoin(denom, sdk.ZeroInt())
+	var relayerFees sdk.Coins
+	var waits []time.Duration
+	for _, tx := range selected {
+		if tx.BridgeFee.Denom == denom {
+			totalFee = totalFee.Add(tx.BridgeFee)
+		} else {
+			relayerFees = relayerFees.Add(tx.BridgeFee)
+		}
+		if bz := store.Get(types.GetOutgoingTxTimeKey(tx.ID)); bz != nil {
+			var poolTime time.Time
+			k.cdc.MustUnmarshalBinaryBare(bz, &poolTime)
+			waits = append(waits, ctx.BlockTime().Sub(poolTime))
+		}
+		store.Delete(types.GetOutgoingTxPoolKey(tx.ID))
+		store.Delete(types.GetOutgoingTxTimeKey(tx.ID))
+	}
+
+	batch := types.OutgoingTxBatch{
+		Nonce:       int64(k.autoIncrementID(ctx, types.KeyLastOutgoingBatchID)),
+		ValsetNonce: k.GetLastObservedValsetNonce(ctx),
+		Elements:    selected,
+		TotalFee:    totalFee,
+		RelayerFees: relayerFees,
+		Decimals:    params.GetTokenDecimals(denom),
+	}
+	store.Set(types.GetOutgoingTxBatchKey(batch.Nonce), k.cdc.MustMarshalBinaryBare(batch))
+	k.updateBatchStatus(ctx, batch, types.TransferBatched)
+	// the amounts and the fees in the denom leave for Ethereum, native denoms stay locked here and
+	// vouchers are burned
+	paidOut, dust := batchPayout(batch)
+	k.addDust(ctx, denom, dust, sdk.ZeroInt())
+	if err := k.lockNative(ctx, paidOut); err != nil {
+		return nil, err
+	}
+	k.setBatchWait(ctx, denom, batch.Nonce, waits)
+	return &batch, nil
+}
+
+// batchPayout returns the amounts and fees in the denom of the batch that the contract pays out
+// and the dust that it does not. Transfers sent before the decimals of the denom were set can
+// have dust.
+func batchPayout(batch types.OutgoingTxBatch) (sdk.Coin, sdk.Int) {
+	total := sdk.ZeroInt()
+	dust := sdk.ZeroInt()
+	for _, tx := range batch.Elements {
+		total = total.Add(tx.Amount.Amount).Add(tx.EthFee())
+		_, amountDust := batch.Decimals.ToERC20(tx.Amount.Amount)
+		_, feeDust := batch.Decimals.ToERC20(tx.EthFee())
+		dust = dust.Add(amountDust).Add(feeDust)
+	}
+	return sdk.NewCoin(batch.TotalFee.Denom, total.Sub(dust)), dust
+}
+
+// GetLastObservedTxNonce returns the highest tx nonce of the batches observed executed on Ethereum,
+// the contract rejects every tx nonce up to it
+func (k Keeper) GetLastObservedTxNonce(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyLastObservedTxNonce)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// GetPendingOutgoingTXBatches returns the batches that were not observed executed on Ethereum yet
+// in ASC order of their nonce
+func (k Keeper) GetPendingOutgoingTXBatches(ctx sdk.Context) []types.OutgoingTxBatch {
+	lastTxNonce := k.GetLastObservedTxNonce(ctx)
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.OutgoingTXBatchKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	var res []types.OutgoingTxBatch
+	for ; iter.Valid(); iter.Next() {
+		var batch types.OutgoingTxBatch
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &batch)
+		if batch.Elements[0].ID > lastTxNonce {
+			res = append(res, batch)
+		}
+	}
+	return res
+}
+
+// observeBatch records the last tx nonce of the batch that was observed executed and pays its fees
+// in other denoms to the fee collector. The contract rejects the tx nonces up to it from now on, so
+// the other batches that start at or below it are invalidated and the transfers in the pool that
+// have such a pool ID get a new one.
+func (k Keeper) observeBatch(ctx sdk.Context, batch types.OutgoingTxBatch) error {
+	prev := k.GetLastObservedTxNonce(ctx)
+	last := batch.Elements[len(batch.Elements)-1].ID
+	if last <= prev {
+		return nil
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.KeyLastObservedTxNonce, sdk.Uint64ToBigEndian(last))
+	if !batch.RelayerFees.Empty() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, auth.FeeCollectorName, batch.RelayerFees); err != nil {
+			return err
+		}
+	}
+
+	var superseded []types.OutgoingTxBatch
+	k.IterateOutgoingTXBatches(ctx, func(_ []byte, b types.OutgoingTxBatch) bool {
+		if b.Nonce != batch.Nonce && b.Elements[0].ID > prev && b.Elements[0].ID <= last {
+			superseded = append(superseded, b)
+		}
+		return false
+	})
+	for _, b := range superseded {
+		if err := k.invalidateBatch(ctx, b); err != nil {
+			return err
+		}
+	}
+
+	var stale []types.OutgoingTx
+	k.IterateOutgoingPool(ctx, func(tx types.OutgoingTx) bool {
+		if tx.ID > last {
+			return true
+		}
+		stale = append(stale, tx)
+		return false
+	})
+	for _, tx := range stale {
+		store.Delete(types.GetOutgoingTxPoolKey(tx.ID))
+		store.Delete(types.GetOutgoingTxTimeKey(tx.ID))
+		k.addToPool(ctx, tx)
+	}
+	return nil
+}
+
+// invalidateBatch deletes a batch the contract can no longer execute together with its confirms and
+// attestations and returns its transfers to the pool under new pool IDs. The dust and the native
+// coins that were counted when it was built are taken back and the burned vouchers are minted.
+func (k Keeper) invalidateBatch(ctx sdk.Context, batch types.OutgoingTxBatch) error {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetOutgoingTxBatchKey(batch.Nonce))
+	deletePrefix(store, types.GetBatchConfirmsPrefix(batch.Nonce))
+	deletePrefix(store, types.GetBatchInChainPrefix(batch.Nonce))
+
+	paidOut, dust := batchPayout(batch)
+	k.addDust(ctx, paidOut.Denom, dust.Neg(), sdk.ZeroInt())
+	if err := k.unlockBatchPayout(ctx, paidOut); err != nil {
+		return err
+	}
+	for _, tx := range batch.Elements {
+		k.updateTransferStatus(ctx, tx.TransferID, func(s *types.TransferStatus) {
+			s.BatchNonce = 0
+		})
+		k.addToPool(ctx, tx)
+	}
+	return nil
+}
+
+// batchCandidates returns the transfers of the denom in the pool that can go into a batch, highest
+// fee value first. The stable sort keeps ties in ID order.
+func (k Keeper) batchCandidates(ctx sdk.Context, params types.Params, denom string) []types.OutgoingTx {
+	var candidates []types.OutgoingTx
+	k.IterateOutgoingPool(ctx, func(tx types.OutgoingTx) bool {
+		if tx.Amount.Denom == denom && k.AssertNotBlocked(ctx, tx.Sender, tx.DestAddress) == nil {
+			candidates = append(candidates, tx)
+		}
+		return false
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return bridgeFeeValue(params, candidates[i]).GT(bridgeFeeValue(params, candidates[j]))
+	})
+	return candidates
+}
+
+func (k Keeper) GetOutgoingTXBatch(ctx sdk.Context, nonce int64) *types.OutgoingTxBatch {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetOutgoingTxBatchKey(nonce))
+	if bz == nil {
+		return nil
+	}
+	var batch types.OutgoingTxBatch
+	k.cdc.MustUnmarshalBinaryBare(bz, &batch)
+	return &batch
+}
+
+// Iterate through all outgoing batches in DESC order.
+func (k Keeper) IterateOutgoingTXBatches(ctx sdk.Context, cb func(key []byte, batch types.OutgoingTxBatch) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.OutgoingTXBatchKey)
+	iter := prefixStore.ReverseIterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var batch types.OutgoingTxBatch
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &batch)
+		// cb returns true to stop early
+		if cb(iter.Key(), batch) {
+			break
+		}
+	}
+}
+
+func (k Keeper) HasBatchConfirm(ctx sdk.Context, batchNonce int64, valsetNonce int64, validator sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetBatchConfirmKey(batchNonce, valsetNonce, validator))
+}
+
+// SetBatchConfirm stores the confirm together with the eth address that made its signature
+func (k Keeper) SetBatchConfirm(ctx sdk.Context, batchConf types.MsgConfirmBatch, ethSigner types.EthAddress) {
+	store := ctx.KVStore(k.storeKey)
+	confirm := types.NewBatchConfirm(batchConf, ethSigner)
+	store.Set(types.GetBatchConfirmKey(batchConf.Nonce, batchConf.ValsetNonce, batchConf.Validator), k.cdc.MustMarshalBinaryBare(confirm))
+}
+
+func (k Keeper) GetBatchConfirm(ctx sdk.Context, batchNonce int64, valsetNonce int64, validator sdk.AccAddress) *types.BatchConfirm {
+	store := ctx.KVStore(k.storeKey)
+	entity := store.Get(types.GetBatchConfirmKey(batchNonce, valsetNonce, validator))
+	if entity == nil {
+		return nil
+	}
+	confirm := types.BatchConfirm{}
+	k.cdc.MustUnmarshalBinaryBare(entity, &confirm)
+	return &confirm
+}
+
+// Iterate through all batch confirms for a batch and valset nonce combination in ASC order
+func (k Keeper) IterateBatchConfirmByNonce(ctx sdk.Context, batchNonce int64, valsetNonce int64, cb func([]byte, types.BatchConfirm) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetBatchConfirmPrefix(batchNonce, valsetNonce))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		confirm := types.BatchConfirm{}
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &confirm)
+		// cb returns true to stop early
+		if cb(iter.Key(), confirm) {
+			break
+		}
+	}
+}
+
+// GetRelayBatchPayload assembles the `submitBatch` arguments for the batch with the given nonce. When
+// newValsetNonce is not zero the payload is for `updateValsetAndSubmitBatch` with that valset instead.
+// The current validators are those of the last valset observed on Ethereum, the contract checks
+// them against its last checkpoint.
+func (k Keeper) GetRelayBatchPayload(ctx sdk.Context, batchNonce int64, newValsetNonce int64) (*types.RelayBatchPayload, error) {
+	batch := k.GetOutgoingTXBatch(ctx, batchNonce)
+	if batch == nil {
+		return nil, sdkerrors.Wrap(types.ErrUnknown, "batch")
+	}
+	currentValset := k.GetValsetRequest(ctx, k.GetLastObservedValsetNonce(ctx))
+	if currentValset == nil {
+		return nil, sdkerrors.Wrap(types.ErrUnknown, "no valset observed on ethereum")
+	}
+	var newValset *types.Valset
+	if newValsetNonce != 0 {
+		if newValsetNonce <= currentValset.Nonce {
+			return nil, sdkerrors.Wrap(types.ErrInvalid, "new valset nonce must be greater than the last observed valset nonce")
+		}
+		if newValset = k.GetValsetRequest(ctx, newValsetNonce); newValset == nil {
+			return nil, sdkerrors.Wrap(types.ErrUnknown, "new valset")
+		}
+	}
+
+	// a signature goes into the slot of the key that made it, one made with a key that is not in
+	// the current valset is left out
+	sigs := make(map[string]string)
+	k.IterateBatchConfirmByNonce(ctx, batchNonce, newValsetNonce, func(_ []byte, c types.BatchConfirm) bool {
+		sigs[k.batchConfirmSigner(ctx, c, currentValset.Nonce).String()] = c.Signature
+		return false
+	})
+	payload, err := types.NewRelayBatchPayload(*batch, *currentValset, newValset, sigs)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	return payload, nil
+}
+
+// batchConfirmSigner returns the eth address that made the signature of the confirm. Confirms stored
+// before the address was recorded are taken to be signed with the address of the validator in the
+// valset with the nonce.
+func (k Keeper) batchConfirmSigner(ctx sdk.Context, confirm types.BatchConfirm, valsetNonce int64) types.EthAddress {
+	if !confirm.EthSigner.Empty() {
+		return confirm.EthSigner
+	}
+	return k.GetEthAddressAtValset(ctx, confirm.Validator, valsetNonce)
+}
+
+// GetLatestValsetNonce returns the nonce of the latest valset request or zero if there is none
+func (k Keeper) GetLatestValsetNonce(ctx sdk.Context) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.KeyLastValsetNonce)
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+func (k Keeper) autoIncrementID(ctx sdk.Context, idKey []byte) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	var id uint64 = 1
+	if bz := store.Get(idKey); bz != nil {
+		id = binary.BigEndian.Uint64(bz) + 1
+	}
+	store.Set(idKey, sdk.Uint64ToBigEndian(id))
+	return id
+}
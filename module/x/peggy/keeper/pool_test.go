@@ -0,0 +1,241 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildOutgoingTXBatch(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var (
+		mySender = bytes.Repeat([]byte{1}, sdk.AddrLen)
+		myDest   = ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	)
+	// pool: fees 2, 3, 1, 4 plus one transfer of another denom
+	for i, fee := range []int64{2, 3, 1, 4} {
+		AddEscrowedTransfer(t, k, ctx, mySender, myDest, sdk.NewInt64Coin("mytoken", int64(100+i)), sdk.NewInt64Coin("mytoken", fee))
+	}
+	AddEscrowedTransfer(t, k, ctx, mySender, myDest, sdk.NewInt64Coin("othertoken", 100), sdk.NewInt64Coin("othertoken", 99))
+
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), batch.Nonce)
+	require.Len(t, batch.Elements, 4)
+	// ordered by id so the tx nonces are increasing on the contract side
+	for i, tx := range batch.Elements {
+		assert.Equal(t, uint64(i+1), tx.ID)
+	}
+	assert.Equal(t, sdk.NewInt64Coin("mytoken", 10), batch.TotalFee)
+	assert.Equal(t, batch, k.GetOutgoingTXBatch(ctx, 1))
+
+	// only the other denom is left in the pool
+	var remaining []types.OutgoingTx
+	k.IterateOutgoingPool(ctx, func(tx types.OutgoingTx) bool {
+		remaining = append(remaining, tx)
+		return false
+	})
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "othertoken", remaining[0].Amount.Denom)
+
+	_, err = k.BuildOutgoingTXBatch(ctx, "mytoken")
+	assert.Error(t, err)
+}
+
+func TestGetRelayBatchPayload(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+
+	// setup three validators with eth keys
+	var (
+		validators []sdk.ValAddress
+		ethKeys    = make(map[common.Address]string)
+	)
+	for i := 0; i < 3; i++ {
+		valAddr := bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen)
+		key, err := ethCrypto.GenerateKey()
+		require.NoError(t, err)
+		addr := ethCrypto.PubkeyToAddress(key.PublicKey)
+		k.SetEthAddress(ctx, sdk.AccAddress(valAddr), types.EthAddressFromCommon(addr))
+		ethKeys[addr] = hex.EncodeToString(ethCrypto.FromECDSA(key))
+		validators = append(validators, valAddr)
+	}
+	k.StakingKeeper = NewStakingKeeperMock(validators...)
+	ctx = ctx.WithBlockHeight(100)
+	_, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+
+	AddEscrowedTransfer(t, k, ctx, validators[0].Bytes(), ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"), sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 2))
+	batch, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	assert.Zero(t, batch.ValsetNonce)
+	// the contract only knows the valset it was deployed with until one is observed
+	_, err = k.GetRelayBatchPayload(ctx, batch.Nonce, 0)
+	assert.True(t, types.ErrUnknown.Is(err), err)
+	for _, v := range validators {
+		k.SetValsetObservation(ctx, types.NewMsgValsetObserved(1, sdk.AccAddress(v)))
+	}
+	assert.Equal(t, int64(1), k.GetLastObservedValsetNonce(ctx))
+
+	ctx = ctx.WithBlockHeight(101)
+	k.StakingKeeper.(*StakingKeeperMock).ValidatorPower[validators[2].String()] = 200
+	_, err = k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+	newValset := k.GetValsetRequest(ctx, 2)
+
+	// the first two validators sign both variants
+	sign := func(valAddr sdk.ValAddress, valsetNonce int64, checkpoint []byte) {
+		key, err := ethCrypto.HexToECDSA(ethKeys[k.GetEthAddress(ctx, sdk.AccAddress(valAddr)).Address()])
+		require.NoError(t, err)
+		sig, err := ethCrypto.Sign(checkpoint, key)
+		require.NoError(t, err)
+		k.SetBatchConfirm(ctx, types.NewMsgConfirmBatch(batch.Nonce, valsetNonce, sdk.AccAddress(valAddr), hex.EncodeToString(sig)), types.EthAddressFromCommon(ethCrypto.PubkeyToAddress(key.PublicKey)))
+	}
+	batchCheckpoint, err := batch.GetCheckpoint(k.GetParams(ctx).PeggyID)
+	require.NoError(t, err)
+	valsetAndBatchCheckpoint, err := batch.GetValsetAndBatchCheckpoint(k.GetParams(ctx).PeggyID, *newValset)
+	require.NoError(t, err)
+	for _, v := range validators[:2] {
+		sign(v, 0, batchCheckpoint)
+		sign(v, 2, valsetAndBatchCheckpoint)
+	}
+	// the third validator signs with a key it rotates to, that key is not in the current valset
+	rotatedKey, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+	for valsetNonce, checkpoint := range map[int64][]byte{0: batchCheckpoint, 2: valsetAndBatchCheckpoint} {
+		sig, err := ethCrypto.Sign(checkpoint, rotatedKey)
+		require.NoError(t, err)
+		k.SetBatchConfirm(ctx, types.NewMsgConfirmBatch(batch.Nonce, valsetNonce, sdk.AccAddress(validators[2]), hex.EncodeToString(sig)), types.EthAddressFromCommon(ethCrypto.PubkeyToAddress(rotatedKey.PublicKey)))
+	}
+
+	specs := map[string]struct {
+		srcValsetNonce int64
+		expMethod      string
+		expCheckpoint  []byte
+		expErr         bool
+	}{
+		"submit batch": {
+			expMethod:     "submitBatch",
+			expCheckpoint: batchCheckpoint,
+		},
+		"update valset and submit batch": {
+			srcValsetNonce: 2,
+			expMethod:      "updateValsetAndSubmitBatch",
+			expCheckpoint:  valsetAndBatchCheckpoint,
+		},
+		"unknown valset": {
+			srcValsetNonce: 3,
+			expErr:         true,
+		},
+		"valset not newer than last observed valset": {
+			srcValsetNonce: 1,
+			expErr:         true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			payload, err := k.GetRelayBatchPayload(ctx, batch.Nonce, spec.srcValsetNonce)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expMethod, payload.Method)
+			assert.Equal(t, []uint64{1}, payload.Nonces)
+
+			var (
+				signed      int
+				signedPower int64
+			)
+			for i, addr := range payload.CurrentValidators {
+				if payload.V[i] == 0 {
+					continue
+				}
+				signed++
+				signedPower += payload.CurrentPowers[i]
+				sig := append(hexutil.MustDecode(payload.R[i]), hexutil.MustDecode(payload.S[i])...)
+				sig = append(sig, payload.V[i]-27)
+				pubKey, err := ethCrypto.SigToPub(spec.expCheckpoint, sig)
+				require.NoError(t, err)
+				assert.Equal(t, addr.Address(), ethCrypto.PubkeyToAddress(*pubKey))
+			}
+			assert.Equal(t, 2, signed)
+			assert.Equal(t, signedPower, payload.SignedPower)
+			assert.Equal(t, types.HasQuorum(signedPower), payload.Quorum)
+
+			// the calldata is a complete contract call for the method
+			calldata := hexutil.MustDecode(payload.Calldata)
+			method, err := types.PeggyRelayABI.MethodById(calldata[:4])
+			require.NoError(t, err)
+			assert.Equal(t, spec.expMethod, method.Name)
+			args, err := method.Inputs.UnpackValues(calldata[4:])
+			require.NoError(t, err)
+			assert.Len(t, args[0].([]common.Address), 3)
+			assert.Equal(t, payload.V, args[3].([]uint8))
+		})
+	}
+}
+
+func TestObservedBatchSupersedes(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	var validators []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		validators = append(validators, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(validators...)
+	sender := sdk.AccAddress(validators[0])
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	// pool IDs: 1 and 4 mytoken, 2 othertoken, 3 thirdtoken
+	AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 100), sdk.NewInt64Coin("mytoken", 1))
+	AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("othertoken", 100), sdk.NewInt64Coin("othertoken", 1))
+	third := AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("thirdtoken", 100), sdk.NewInt64Coin("thirdtoken", 1))
+	AddEscrowedTransfer(t, k, ctx, sender, dest, sdk.NewInt64Coin("mytoken", 200), sdk.NewInt64Coin("mytoken", 1))
+	executed, err := k.BuildOutgoingTXBatch(ctx, "mytoken")
+	require.NoError(t, err)
+	superseded, err := k.BuildOutgoingTXBatch(ctx, "thirdtoken")
+	require.NoError(t, err)
+	// the vouchers of the denoms from Ethereum leave the supply with their batch
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("othertoken", 101)), keepers.SupplyKeeper.GetSupply(ctx).GetTotal())
+	k.SetBatchConfirm(ctx, types.NewMsgConfirmBatch(superseded.Nonce, 0, sender, "sig"), dest)
+	assert.Equal(t, []types.OutgoingTxBatch{*executed, *superseded}, k.GetPendingOutgoingTXBatches(ctx))
+
+	// the execution of tx nonces 1 and 4 makes the contract reject 2 and 3
+	for _, validator := range validators[:3] {
+		_, err := k.SetBatchInChain(ctx, executed.Nonce, sdk.AccAddress(validator))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, uint64(4), k.GetLastObservedTxNonce(ctx))
+	assert.Empty(t, k.GetPendingOutgoingTXBatches(ctx))
+	assert.Nil(t, k.GetOutgoingTXBatch(ctx, superseded.Nonce))
+	assert.False(t, k.HasBatchConfirm(ctx, superseded.Nonce, 0, sender))
+	assert.NotNil(t, k.GetOutgoingTXBatch(ctx, executed.Nonce))
+
+	// the superseded and the stale pooled transfers are back in the pool with new pool IDs
+	var pool []types.OutgoingTx
+	k.IterateOutgoingPool(ctx, func(tx types.OutgoingTx) bool {
+		pool = append(pool, tx)
+		return false
+	})
+	require.Len(t, pool, 2)
+	assert.Equal(t, uint64(5), pool[0].ID)
+	assert.Equal(t, "thirdtoken", pool[0].Amount.Denom)
+	assert.Equal(t, uint64(6), pool[1].ID)
+	assert.Equal(t, "othertoken", pool[1].Amount.Denom)
+	status := k.GetTransferStatus(ctx, third.TransferID)
+	assert.Equal(t, types.TransferPooled, status.Status)
+	assert.Equal(t, uint64(5), status.PoolID)
+	assert.Zero(t, status.BatchNonce)
+	// the vouchers of the superseded batch are minted again
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("othertoken", 101), sdk.NewInt64Coin("thirdtoken", 101)), keepers.SupplyKeeper.GetSupply(ctx).GetTotal())
+
+	rebuilt, err := k.BuildOutgoingTXBatch(ctx, "thirdtoken")
+	require.NoError(t, err)
+	assert.Equal(t, []types.OutgoingTxBatch{*rebuilt}, k.GetPendingOutgoingTXBatches(ctx))
+}
@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// maxValsetsPrunedPerBlock bounds the work of a single end block, a larger backlog is pruned over
+// the following blocks
+const maxValsetsPrunedPerBlock = 20
+
+func (k Keeper) setValsetRequestRecord(ctx sdk.Context, record types.ValsetRequestRecord) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetValsetRequestRecordKey(record.Nonce), k.cdc.MustMarshalBinaryBare(record))
+}
+
+// GetValsetRequestRecord returns the height and time the valset with the nonce was requested at, or
+// nil when it is not known
+func (k Keeper) GetValsetRequestRecord(ctx sdk.Context, nonce int64) *types.ValsetRequestRecord {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetValsetRequestRecordKey(nonce))
+	if bz == nil {
+		return nil
+	}
+	var record types.ValsetRequestRecord
+	k.cdc.MustUnmarshalBinaryBare(bz, &record)
+	return &record
+}
+
+// PruneValsets deletes the valset requests that are superseded by the last observed valset or that
+// are older than the retention window, together with their confirms, observations and indexes. A
+// request is only deleted once the unbonding time passed since it was made, until then its confirms
+// are evidence against a validator that signed it. The latest and the last observed valset are
+// kept. The requests are visited in nonce order and the pass stops at the first one that has to be
+// kept, all later ones were requested after it.
+func (k Keeper) PruneValsets(ctx sdk.Context) {
+	latest := k.GetLatestValsetNonce(ctx)
+	observed := k.GetLastObservedValsetNonce(ctx)
+	retention := int64(k.GetParams(ctx).ValsetRetentionBlocks)
+	evidencePeriod := k.StakingKeeper.UnbondingTime(ctx)
+
+	var records []types.ValsetRequestRecord
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetRequestRecordKey)
+	iter := prefixStore.Iterator(nil, nil)
+	for ; iter.Valid() && len(records) < maxValsetsPrunedPerBlock; iter.Next() {
+		var record types.ValsetRequestRecord
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &record)
+		if record.Nonce == observed {
+			continue
+		}
+		if record.Nonce >= latest {
+			break
+		}
+		superseded := record.Nonce < observed
+		expired := retention != 0 && record.Height+retention <= ctx.BlockHeight()
+		if !superseded && !expired {
+			break
+		}
+		if record.Time.Add(evidencePeriod).After(ctx.BlockTime()) {
+			break
+		}
+		records = append(records, record)
+	}
+	iter.Close()
+	for _, record := range records {
+		k.deleteValsetRequest(ctx, record)
+	}
+}
+
+// deleteValsetRequest removes the valset request and everything stored for it
+func (k Keeper) deleteValsetRequest(ctx sdk.Context, record types.ValsetRequestRecord) {
+	store := ctx.KVStore(k.storeKey)
+	deletePrefix(store, append(types.ValsetConfirmKey, sdk.Uint64ToBigEndian(uint64(record.Nonce))...))
+	deletePrefix(store, types.GetValsetObservationPrefix(record.Nonce))
+	store.Delete(types.GetValsetNonceByHeightKey(record.Height))
+	store.Delete(types.GetValsetRequestKey(record.Nonce))
+	store.Delete(types.GetValsetRequestRecordKey(record.Nonce))
+}
+
+// deletePrefix deletes all entries under the prefix in key order
+func deletePrefix(store sdk.KVStore, keyPrefix []byte) {
+	prefixStore := prefix.NewStore(store, keyPrefix)
+	var keys [][]byte
+	iter := prefixStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		prefixStore.Delete(key)
+	}
+}
+
+// GetValsetRetention returns the bounds of the valset requests that are kept
+func (k Keeper) GetValsetRetention(ctx sdk.Context) types.ValsetRetention {
+	res := types.ValsetRetention{
+		LatestNonce:       k.GetLatestValsetNonce(ctx),
+		LastObservedNonce: k.GetLastObservedValsetNonce(ctx),
+		RetentionBlocks:   k.GetParams(ctx).ValsetRetentionBlocks,
+		EvidencePeriod:    k.StakingKeeper.UnbondingTime(ctx),
+	}
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetRequestKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	if iter.Valid() {
+		var valset types.Valset
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &valset)
+		res.OldestNonce = valset.Nonce
+	}
+	return res
+}
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneValsets(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	valAddr := sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	stakingKeeper := NewStakingKeeperMock(valAddr)
+	stakingKeeper.UnbondingPeriod = 24 * time.Hour
+	k.StakingKeeper = stakingKeeper
+	validator := sdk.AccAddress(valAddr)
+	params := k.GetParams(ctx)
+	params.ValsetRetentionBlocks = 0
+	k.SetParams(ctx, params)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, height := range []int64{10, 20, 30, 40} {
+		k.SetEthAddress(ctx, validator, ethAddr(fmt.Sprintf("0x%040x", i+1)))
+		ctx = ctx.WithBlockHeight(height).WithBlockTime(start.Add(time.Duration(i) * time.Hour))
+		valset, err := k.SetValsetRequest(ctx)
+		require.NoError(t, err)
+		k.SetValsetConfirm(ctx, types.MsgValsetConfirm{Nonce: valset.Nonce, Validator: validator})
+	}
+	require.True(t, k.SetValsetObservation(ctx, types.MsgValsetObserved{Nonce: 2, Validator: validator}))
+
+	// the confirms of the superseded valset are kept as evidence for the unbonding time
+	ctx = ctx.WithBlockHeight(41).WithBlockTime(start.Add(23 * time.Hour))
+	k.PruneValsets(ctx)
+	assert.NotNil(t, k.GetValsetRequest(ctx, 1))
+	assert.Equal(t, int64(1), k.GetValsetRetention(ctx).OldestNonce)
+
+	// then it is pruned, the observed valset stays and without retention window the later ones too
+	ctx = ctx.WithBlockHeight(42).WithBlockTime(start.Add(25 * time.Hour))
+	k.PruneValsets(ctx)
+	assert.Nil(t, k.GetValsetRequest(ctx, 1))
+	assert.Nil(t, k.GetValsetConfirm(ctx, 1, validator))
+	assert.Nil(t, k.GetValsetRequestRecord(ctx, 1))
+	assert.Equal(t, int64(0), k.GetValsetNonceByHeight(ctx, 10))
+	for _, nonce := range []int64{2, 3, 4} {
+		assert.NotNil(t, k.GetValsetRequest(ctx, nonce), "nonce %d", nonce)
+		assert.NotNil(t, k.GetValsetConfirm(ctx, nonce, validator), "nonce %d", nonce)
+	}
+
+	// requests outside of the retention window are pruned as well, except for the latest one
+	params.ValsetRetentionBlocks = 15
+	k.SetParams(ctx, params)
+	ctx = ctx.WithBlockHeight(100).WithBlockTime(start.Add(30 * time.Hour))
+	k.PruneValsets(ctx)
+	assert.NotNil(t, k.GetValsetRequest(ctx, 2))
+	assert.Nil(t, k.GetValsetRequest(ctx, 3))
+	assert.Nil(t, k.GetValsetConfirm(ctx, 3, validator))
+	assert.NotNil(t, k.GetValsetRequest(ctx, 4))
+
+	assert.Equal(t, types.ValsetRetention{
+		OldestNonce:       2,
+		LatestNonce:       4,
+		LastObservedNonce: 2,
+		RetentionBlocks:   15,
+		EvidencePeriod:    24 * time.Hour,
+	}, k.GetValsetRetention(ctx))
+}
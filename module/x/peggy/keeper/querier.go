@@ -17,6 +17,36 @@ const (
 	QueryValsetConfirmsByNonce          = "valsetConfirms"
 	QueryLastValsetRequests             = "lastValsetRequests"
 	QueryLastPendingValsetRequestByAddr = "lastPendingValsetRequest"
+	QueryOutgoingTxBatch                = "outgoingTxBatch"
+	QueryLastPendingBatchRequestByAddr  = "lastPendingBatchRequest"
+	QueryPendingOutgoingTxBatches       = "pendingOutgoingTxBatches"
+	QueryParams                         = "params"
+	QuerySubmitBatchPayload             = "submitBatchPayload"
+	QueryUpdateValsetAndSubmitBatch     = "updateValsetAndSubmitBatchPayload"
+	QueryValidatorRegistrations         = "ethAddressRegistrations"
+	QueryValidatorRegistration          = "ethAddressRegistration"
+	QueryEthAddressHistory              = "ethAddressHistory"
+	QueryLastObservedValsetNonce        = "lastObservedValsetNonce"
+	QueryLatestValsetNonce              = "latestValsetNonce"
+	QueryValsetNonceByHeight            = "valsetNonceByHeight"
+	QueryValsetRetention                = "valsetRetention"
+	QueryPauseState                     = "pauseState"
+	QueryRateLimits                     = "rateLimits"
+	QueryTimeLockedTransfers            = "timeLockedTransfers"
+	QueryBlocklist                      = "blocklist"
+	QueryHeldDeposits                   = "heldDeposits"
+	QueryFeeEstimate                    = "feeEstimate"
+	QueryTransferStatus                 = "transferStatus"
+	QueryTransfersBySender              = "transfersBySender"
+	QueryTransfersByRecipient           = "transfersByRecipient"
+	QueryDepositStatus                  = "depositStatus"
+	QueryDepositsByRecipient            = "depositsByRecipient"
+	QueryEthBlock                       = "ethBlock"
+	QueryEthBlockAttestations           = "ethBlockAttestations"
+	QueryGasPrice                       = "gasPrice"
+	QueryGasPriceAttestations           = "gasPriceAttestations"
+	QueryCosmosERC20                    = "cosmosERC20"
+	QueryCosmosERC20s                   = "cosmosERC20s"
 )
 
 // NewQuerier is the module level router for state queries
@@ -35,6 +65,66 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return lastValsetRequests(ctx, keeper)
 		case QueryLastPendingValsetRequestByAddr:
 			return lastPendingValsetRequest(ctx, path[1], keeper)
+		case QueryOutgoingTxBatch:
+			return queryOutgoingTxBatch(ctx, path[1], keeper)
+		case QueryLastPendingBatchRequestByAddr:
+			return lastPendingBatchRequest(ctx, path[1], keeper)
+		case QueryPendingOutgoingTxBatches:
+			return queryPendingOutgoingTxBatches(ctx, keeper)
+		case QueryParams:
+			return queryParams(ctx, keeper)
+		case QuerySubmitBatchPayload:
+			return querySubmitBatchPayload(ctx, path[1], "0", keeper)
+		case QueryUpdateValsetAndSubmitBatch:
+			return querySubmitBatchPayload(ctx, path[1], path[2], keeper)
+		case QueryValidatorRegistrations:
+			return queryValidatorRegistrations(ctx, keeper)
+		case QueryValidatorRegistration:
+			return queryValidatorRegistration(ctx, path[1], keeper)
+		case QueryEthAddressHistory:
+			return queryEthAddressHistory(ctx, path[1], keeper)
+		case QueryLastObservedValsetNonce:
+			return queryLastObservedValsetNonce(ctx, keeper)
+		case QueryLatestValsetNonce:
+			return queryValsetNonce(ctx, keeper.GetLatestValsetNonce(ctx), keeper)
+		case QueryValsetNonceByHeight:
+			return queryValsetNonceByHeight(ctx, path[1], keeper)
+		case QueryValsetRetention:
+			return queryValsetRetention(ctx, keeper)
+		case QueryPauseState:
+			return queryPauseState(ctx, keeper)
+		case QueryRateLimits:
+			return queryRateLimits(ctx, path[1:], keeper)
+		case QueryTimeLockedTransfers:
+			return queryTimeLockedTransfers(ctx, keeper)
+		case QueryBlocklist:
+			return queryBlocklist(ctx, keeper)
+		case QueryHeldDeposits:
+			return queryHeldDeposits(ctx, path[1:], keeper)
+		case QueryFeeEstimate:
+			return queryFeeEstimate(ctx, path[1], keeper)
+		case QueryTransferStatus:
+			return queryTransferStatus(ctx, path[1], keeper)
+		case QueryTransfersBySender:
+			return queryTransfersBySender(ctx, path[1], keeper)
+		case QueryTransfersByRecipient:
+			return queryTransfersByRecipient(ctx, path[1], keeper)
+		case QueryDepositStatus:
+			return queryDepositStatus(ctx, path[1], keeper)
+		case QueryDepositsByRecipient:
+			return queryDepositsByRecipient(ctx, path[1], keeper)
+		case QueryEthBlock:
+			return queryEthBlock(ctx, keeper)
+		case QueryEthBlockAttestations:
+			return queryEthBlockAttestations(ctx, keeper)
+		case QueryGasPrice:
+			return queryGasPrice(ctx, keeper)
+		case QueryGasPriceAttestations:
+			return queryGasPriceAttestations(ctx, keeper)
+		case QueryCosmosERC20:
+			return queryCosmosERC20(ctx, path[1], keeper)
+		case QueryCosmosERC20s:
+			return queryCosmosERC20s(ctx, keeper)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown nameservice query endpoint")
 		}
@@ -165,3 +255,372 @@ func lastPendingValsetRequest(ctx sdk.Context, operatorAddr string, keeper Keepe
 	}
 	return res, nil
 }
+
+func queryOutgoingTxBatch(ctx sdk.Context, nonceStr string, keeper Keeper) ([]byte, error) {
+	nonce, err := strconv.ParseInt(nonceStr, 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	batch := keeper.GetOutgoingTXBatch(ctx, nonce)
+	if batch == nil {
+		return nil, nil
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *batch)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryPendingOutgoingTxBatches returns the batches that were not observed executed on Ethereum yet
+func queryPendingOutgoingTxBatches(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetPendingOutgoingTXBatches(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// lastPendingBatchRequest returns the latest batch when the given validator has not confirmed it yet
+func lastPendingBatchRequest(ctx sdk.Context, operatorAddr string, keeper Keeper) ([]byte, error) {
+	addr, err := sdk.AccAddressFromBech32(operatorAddr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
+	}
+
+	var pendingBatchReq *types.OutgoingTxBatch
+	keeper.IterateOutgoingTXBatches(ctx, func(_ []byte, batch types.OutgoingTxBatch) bool {
+		if !keeper.HasBatchConfirm(ctx, batch.Nonce, 0, addr) {
+			pendingBatchReq = &batch
+		}
+		return true
+	})
+	if pendingBatchReq == nil {
+		return nil, nil
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *pendingBatchReq)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// querySubmitBatchPayload returns the arguments and ABI encoded calldata for relaying the batch with
+// the given nonce. A valset nonce other than zero returns an `updateValsetAndSubmitBatch` payload
+// that moves the contract to that valset in the same call.
+func querySubmitBatchPayload(ctx sdk.Context, batchNonceStr string, valsetNonceStr string, keeper Keeper) ([]byte, error) {
+	batchNonce, err := strconv.ParseInt(batchNonceStr, 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	valsetNonce, err := strconv.ParseInt(valsetNonceStr, 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	payload, err := keeper.GetRelayBatchPayload(ctx, batchNonce, valsetNonce)
+	if err != nil {
+		return nil, err
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *payload)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryValidatorRegistrations returns the eth address registration status of all bonded validators
+func queryValidatorRegistrations(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetValidatorRegistrations(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryValidatorRegistration returns the eth address registration status of a bonded validator
+func queryValidatorRegistration(ctx sdk.Context, operatorAddr string, keeper Keeper) ([]byte, error) {
+	addr, err := sdk.AccAddressFromBech32(operatorAddr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
+	}
+	registration := keeper.GetValidatorRegistration(ctx, sdk.ValAddress(addr))
+	if registration == nil {
+		return nil, nil
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *registration)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryEthAddressHistory returns all eth addresses the validator registered, the nonce of its next
+// registration is the length of the list
+func queryEthAddressHistory(ctx sdk.Context, operatorAddr string, keeper Keeper) ([]byte, error) {
+	addr, err := sdk.AccAddressFromBech32(operatorAddr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
+	}
+	history := keeper.GetEthAddressHistory(ctx, addr)
+	if history == nil {
+		history = []types.EthAddressRecord{}
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, history)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryLastObservedValsetNonce returns the nonce of the last valset observed on Ethereum, zero when
+// none was
+func queryLastObservedValsetNonce(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetLastObservedValsetNonce(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryValsetNonceByHeight returns the nonce of the valset requested at the height, zero when there
+// was none
+func queryValsetNonceByHeight(ctx sdk.Context, heightStr string, keeper Keeper) ([]byte, error) {
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "height")
+	}
+	return queryValsetNonce(ctx, keeper.GetValsetNonceByHeight(ctx, height), keeper)
+}
+
+func queryValsetNonce(ctx sdk.Context, nonce int64, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, nonce)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryValsetRetention returns the bounds of the valset requests and confirms that are kept
+func queryValsetRetention(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetValsetRetention(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryParams returns the params of the module
+func queryParams(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetParams(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryPauseState returns whether the bridge is paused
+func queryPauseState(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetPauseState(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryRateLimits returns the remaining capacity of the rate limits of the denom given as path
+// argument, or of all denoms without one
+func queryRateLimits(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	var denom string
+	if len(path) != 0 {
+		denom = path[0]
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetRateLimitCapacities(ctx, denom))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryTimeLockedTransfers returns the transfers in the delay queue
+func queryTimeLockedTransfers(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetTimeLockedTransfers(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryBlocklist returns the blocked eth and cosmos addresses
+func queryBlocklist(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetBlocklist(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryHeldDeposits returns the deposits held for the recipient given as path argument, or for all
+// recipients without one
+func queryHeldDeposits(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	var recipient sdk.AccAddress
+	if len(path) != 0 {
+		addr, err := sdk.AccAddressFromBech32(path[0])
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+		recipient = addr
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetHeldDeposits(ctx, recipient))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryFeeEstimate returns the pool depth and fees of the denom and the fee that makes the next batch
+func queryFeeEstimate(ctx sdk.Context, denom string, keeper Keeper) ([]byte, error) {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetFeeEstimate(ctx, denom))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryTransferStatus(ctx sdk.Context, idStr string, keeper Keeper) ([]byte, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	status := keeper.GetTransferStatus(ctx, id)
+	if status == nil {
+		return nil, nil
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *status)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryTransfersBySender(ctx sdk.Context, senderStr string, keeper Keeper) ([]byte, error) {
+	sender, err := sdk.AccAddressFromBech32(senderStr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetTransferStatusesBySender(ctx, sender))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryTransfersByRecipient(ctx sdk.Context, recipientStr string, keeper Keeper) ([]byte, error) {
+	recipient, err := types.NewEthAddress(recipientStr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetTransferStatusesByRecipient(ctx, recipient))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryDepositStatus(ctx sdk.Context, idStr string, keeper Keeper) ([]byte, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	status := keeper.GetDepositStatus(ctx, id)
+	if status == nil {
+		return nil, nil
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *status)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryDepositsByRecipient(ctx sdk.Context, recipientStr string, keeper Keeper) ([]byte, error) {
+	recipient, err := sdk.AccAddressFromBech32(recipientStr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetDepositStatusesByRecipient(ctx, recipient))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryEthBlock returns the chain's view of the latest final Ethereum block, nothing before it has one
+func queryEthBlock(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	block := keeper.GetEthBlock(ctx)
+	if block == nil {
+		return nil, nil
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *block)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryEthBlockAttestations(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetEthBlockAttestations(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryGasPrice returns the chain's view of the Ethereum gas price, nothing before it has one
+func queryGasPrice(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	price := keeper.GetGasPrice(ctx)
+	if price == nil {
+		return nil, nil
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *price)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryGasPriceAttestations(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetGasPriceAttestations(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+// queryCosmosERC20 returns the ERC20 of the native denom, nothing before it was deployed
+func queryCosmosERC20(ctx sdk.Context, denom string, keeper Keeper) ([]byte, error) {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	erc20 := keeper.GetCosmosERC20(ctx, denom)
+	if erc20 == nil {
+		return nil, nil
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, *erc20)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryCosmosERC20s(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetCosmosERC20s(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
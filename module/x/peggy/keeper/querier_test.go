@@ -3,10 +3,12 @@ package keeper
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/althea-net/peggy/module/x/peggy/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -128,13 +130,15 @@ func TestLastValsetRequestNonces(t *testing.T) {
 		for j := 0; j <= i; j++ {
 			// add an validator each block
 			valAddr := bytes.Repeat([]byte{byte(j)}, sdk.AddrLen)
-			ethAddr := fmt.Sprintf("my eth addr %d", j+1)
+			ethAddr, err := types.NewEthAddress(fmt.Sprintf("0x%040x", j+1))
+			require.NoError(t, err)
 			k.SetEthAddress(ctx, valAddr, ethAddr)
 			validators = append(validators, valAddr)
 		}
 		k.StakingKeeper = NewStakingKeeperMock(validators...)
 		ctx = ctx.WithBlockHeight(int64(100 + i))
-		k.SetValsetRequest(ctx)
+		_, err := k.SetValsetRequest(ctx)
+		require.NoError(t, err)
 	}
 
 	specs := map[string]struct {
@@ -143,78 +147,78 @@ func TestLastValsetRequestNonces(t *testing.T) {
 		"limit at 5": {
 			expResp: []byte(`[
   {
-	"Nonce": "105",
+	"Nonce": "6",
 	"Powers": [
-	  "100",
-	  "100",
-	  "100",
-	  "100",
-	  "100",
-	  "100"
+	  "715827882",
+	  "715827882",
+	  "715827883",
+	  "715827883",
+	  "715827883",
+	  "715827883"
 	],
 	"EthAddresses": [
-	  "my eth addr 1",
-	  "my eth addr 2",
-	  "my eth addr 3",
-	  "my eth addr 4",
-	  "my eth addr 5",
-	  "my eth addr 6"
+	  "0x0000000000000000000000000000000000000001",
+	  "0x0000000000000000000000000000000000000002",
+	  "0x0000000000000000000000000000000000000003",
+	  "0x0000000000000000000000000000000000000004",
+	  "0x0000000000000000000000000000000000000005",
+	  "0x0000000000000000000000000000000000000006"
 	]
   },
   {
-	"Nonce": "104",
+	"Nonce": "5",
 	"Powers": [
-	  "100",
-	  "100",
-	  "100",
-	  "100",
-	  "100"
+	  "858993459",
+	  "858993459",
+	  "858993459",
+	  "858993459",
+	  "858993460"
 	],
 	"EthAddresses": [
-	  "my eth addr 1",
-	  "my eth addr 2",
-	  "my eth addr 3",
-	  "my eth addr 4",
-	  "my eth addr 5"
+	  "0x0000000000000000000000000000000000000001",
+	  "0x0000000000000000000000000000000000000002",
+	  "0x0000000000000000000000000000000000000003",
+	  "0x0000000000000000000000000000000000000004",
+	  "0x0000000000000000000000000000000000000005"
 	]
   },
   {
-	"Nonce": "103",
+	"Nonce": "4",
 	"Powers": [
-	  "100",
-	  "100",
-	  "100",
-	  "100"
+	  "1073741824",
+	  "1073741824",
+	  "1073741824",
+	  "1073741824"
 	],
 	"EthAddresses": [
-	  "my eth addr 1",
-	  "my eth addr 2",
-	  "my eth addr 3",
-	  "my eth addr 4"
+	  "0x0000000000000000000000000000000000000001",
+	  "0x0000000000000000000000000000000000000002",
+	  "0x0000000000000000000000000000000000000003",
+	  "0x0000000000000000000000000000000000000004"
 	]
   },
   {
-	"Nonce": "102",
+	"Nonce": "3",
 	"Powers": [
-	  "100",
-	  "100",
-	  "100"
+	  "1431655765",
+	  "1431655765",
+	  "1431655766"
 	],
 	"EthAddresses": [
-	  "my eth addr 1",
-	  "my eth addr 2",
-	  "my eth addr 3"
+	  "0x0000000000000000000000000000000000000001",
+	  "0x0000000000000000000000000000000000000002",
+	  "0x0000000000000000000000000000000000000003"
 	]
   },
   {
-	"Nonce": "101",
+	"Nonce": "2",
 	"Powers": [
-	  "100",
-	  "100"
+	  "2147483648",
+	  "2147483648"
 	],
 	"EthAddresses": [
-	  "my eth addr 1",
-	  "my eth addr 2"
+	  "0x0000000000000000000000000000000000000001",
+	  "0x0000000000000000000000000000000000000002"
 	]
   }
 ]`),
@@ -235,13 +239,18 @@ func TestLastPendingValsetRequest(t *testing.T) {
 		otherValidatorCosmosAddr   = bytes.Repeat([]byte{2}, sdk.AddrLen)
 		unknownValidatorCosmosAddr = bytes.Repeat([]byte{3}, sdk.AddrLen)
 	)
-	k.StakingKeeper = NewStakingKeeperMock(aValidatorCosmosAddr, otherValidatorCosmosAddr)
-	// seed with requests
+	k.SetEthAddress(ctx, aValidatorCosmosAddr, types.EthAddressFromCommon(common.BigToAddress(big.NewInt(1))))
+	k.SetEthAddress(ctx, otherValidatorCosmosAddr, types.EthAddressFromCommon(common.BigToAddress(big.NewInt(2))))
+	// seed with requests, the other validator joins with the second one
+	k.StakingKeeper = NewStakingKeeperMock(aValidatorCosmosAddr)
 	ctx = ctx.WithBlockHeight(200)
-	k.SetValsetRequest(ctx)
+	_, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
 	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
-	k.SetValsetConfirm(ctx, types.MsgValsetConfirm{Nonce: ctx.BlockHeight(), Validator: otherValidatorCosmosAddr})
-	k.SetValsetRequest(ctx)
+	k.SetValsetConfirm(ctx, types.MsgValsetConfirm{Nonce: 2, Validator: otherValidatorCosmosAddr})
+	k.StakingKeeper = NewStakingKeeperMock(aValidatorCosmosAddr, otherValidatorCosmosAddr)
+	_, err = k.SetValsetRequest(ctx)
+	require.NoError(t, err)
 
 	specs := map[string]struct {
 		srcAddr string
@@ -254,14 +263,14 @@ func TestLastPendingValsetRequest(t *testing.T) {
 {
   "type": "peggy/Valset",
   "value": {
-	"Nonce": "201",
+	"Nonce": "2",
 	"Powers": [
-	  "100",
-	  "100"
+	  "2147483648",
+	  "2147483648"
 	],
 	"EthAddresses": [
-	  "",
-	  ""
+	  "0x0000000000000000000000000000000000000001",
+	  "0x0000000000000000000000000000000000000002"
 	]
   }
 }
@@ -277,14 +286,14 @@ func TestLastPendingValsetRequest(t *testing.T) {
 {
   "type": "peggy/Valset",
   "value": {
-	"Nonce": "201",
+	"Nonce": "2",
 	"Powers": [
-	  "100",
-	  "100"
+	  "2147483648",
+	  "2147483648"
 	],
 	"EthAddresses": [
-	  "",
-	  ""
+	  "0x0000000000000000000000000000000000000001",
+	  "0x0000000000000000000000000000000000000002"
 	]
   }
 }
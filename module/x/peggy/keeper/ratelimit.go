@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ConsumeRateLimit records the value of the coin as passed in the direction in the current block.
+// It returns types.ErrRateLimited and records nothing when this would exceed the cap of the denom
+// over the window, that are the current block and the WindowBlocks - 1 before it. The use is only
+// recorded while a cap is set, a new cap starts with an empty window.
+func (k Keeper) ConsumeRateLimit(ctx sdk.Context, direction string, coin sdk.Coin) error {
+	limit, ok := k.GetParams(ctx).GetRateLimit(coin.Denom)
+	if !ok || limit.Max(direction).IsZero() {
+		return nil
+	}
+	k.pruneRateLimitUsage(ctx, direction, limit)
+	capacity := k.rateLimitCapacity(ctx, direction, limit)
+	if coin.Amount.GT(capacity.Remaining) {
+		return sdkerrors.Wrapf(types.ErrRateLimited, "%s %s: %s of %s remaining in the window of %d blocks",
+			direction, coin.Denom, capacity.Remaining, capacity.Max, limit.WindowBlocks)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetRateLimitUsageKey(direction, coin.Denom, ctx.BlockHeight())
+	used := coin.Amount
+	if bz := store.Get(key); bz != nil {
+		var inBlock sdk.Int
+		k.cdc.MustUnmarshalBinaryBare(bz, &inBlock)
+		used = used.Add(inBlock)
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryBare(used))
+	return nil
+}
+
+// refundRateLimit takes the value of the coin off the use recorded in the direction at the height.
+// Use that was pruned with the window is not refunded.
+func (k Keeper) refundRateLimit(ctx sdk.Context, direction string, coin sdk.Coin, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetRateLimitUsageKey(direction, coin.Denom, height)
+	bz := store.Get(key)
+	if bz == nil {
+		return
+	}
+	var inBlock sdk.Int
+	k.cdc.MustUnmarshalBinaryBare(bz, &inBlock)
+	if coin.Amount.GTE(inBlock) {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryBare(inBlock.Sub(coin.Amount)))
+}
+
+// GetRateLimitCapacities returns the use of the caps that are set for the denom, or for all denoms
+// with an empty denom
+func (k Keeper) GetRateLimitCapacities(ctx sdk.Context, denom string) []types.RateLimitCapacity {
+	res := []types.RateLimitCapacity{}
+	for _, limit := range k.GetParams(ctx).RateLimits {
+		if denom != "" && limit.Denom != denom {
+			continue
+		}
+		for _, direction := range []string{types.RateLimitOutflow, types.RateLimitMint} {
+			if limit.Max(direction).IsZero() {
+				continue
+			}
+			res = append(res, k.rateLimitCapacity(ctx, direction, limit))
+		}
+	}
+	return res
+}
+
+// rateLimitCapacity sums up the use within the window
+func (k Keeper) rateLimitCapacity(ctx sdk.Context, direction string, limit types.RateLimit) types.RateLimitCapacity {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetRateLimitUsagePrefix(direction, limit.Denom))
+	iter := prefixStore.Iterator(sdk.Uint64ToBigEndian(uint64(rateLimitWindowStart(ctx, limit))), nil)
+	defer iter.Close()
+	used := sdk.ZeroInt()
+	for ; iter.Valid(); iter.Next() {
+		var inBlock sdk.Int
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &inBlock)
+		used = used.Add(inBlock)
+	}
+	max := limit.Max(direction)
+	remaining := max.Sub(used)
+	if remaining.IsNegative() {
+		// the cap was lowered below the use
+		remaining = sdk.ZeroInt()
+	}
+	return types.RateLimitCapacity{
+		Denom:        limit.Denom,
+		Direction:    direction,
+		WindowBlocks: limit.WindowBlocks,
+		Max:          max,
+		Used:         used,
+		Remaining:    remaining,
+	}
+}
+
+// pruneRateLimitUsage deletes the use recorded before the window
+func (k Keeper) pruneRateLimitUsage(ctx sdk.Context, direction string, limit types.RateLimit) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetRateLimitUsagePrefix(direction, limit.Denom))
+	start := rateLimitWindowStart(ctx, limit)
+	var keys [][]byte
+	iter := prefixStore.Iterator(nil, nil)
+	for ; iter.Valid() && int64(binary.BigEndian.Uint64(iter.Key())) < start; iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		prefixStore.Delete(key)
+	}
+}
+
+// rateLimitWindowStart returns the first height of the window that ends with the current block
+func rateLimitWindowStart(ctx sdk.Context, limit types.RateLimit) int64 {
+	start := ctx.BlockHeight() - int64(limit.WindowBlocks) + 1
+	if start < 0 {
+		return 0
+	}
+	return start
+}
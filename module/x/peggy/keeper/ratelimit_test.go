@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumeRateLimit(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	params := k.GetParams(ctx)
+	params.RateLimits = []types.RateLimit{
+		{Denom: "mytoken", WindowBlocks: 10, MaxOutflow: sdk.NewInt(100), MaxMint: sdk.ZeroInt()},
+	}
+	k.SetParams(ctx, params)
+
+	ctx = ctx.WithBlockHeight(100)
+	require.NoError(t, k.ConsumeRateLimit(ctx, types.RateLimitOutflow, sdk.NewInt64Coin("mytoken", 60)))
+	ctx = ctx.WithBlockHeight(105)
+	require.NoError(t, k.ConsumeRateLimit(ctx, types.RateLimitOutflow, sdk.NewInt64Coin("mytoken", 30)))
+	err := k.ConsumeRateLimit(ctx, types.RateLimitOutflow, sdk.NewInt64Coin("mytoken", 11))
+	assert.True(t, types.ErrRateLimited.Is(err), err)
+	assert.Equal(t, []types.RateLimitCapacity{{
+		Denom:        "mytoken",
+		Direction:    types.RateLimitOutflow,
+		WindowBlocks: 10,
+		Max:          sdk.NewInt(100),
+		Used:         sdk.NewInt(90),
+		Remaining:    sdk.NewInt(10),
+	}}, k.GetRateLimitCapacities(ctx, "mytoken"))
+
+	// without a cap nothing is limited
+	assert.NoError(t, k.ConsumeRateLimit(ctx, types.RateLimitMint, sdk.NewInt64Coin("mytoken", 1000)))
+	assert.NoError(t, k.ConsumeRateLimit(ctx, types.RateLimitOutflow, sdk.NewInt64Coin("othertoken", 1000)))
+	assert.Empty(t, k.GetRateLimitCapacities(ctx, "othertoken"))
+
+	// the window rolls on, the use of height 100 is out of it from height 110 on
+	ctx = ctx.WithBlockHeight(109)
+	assert.Error(t, k.ConsumeRateLimit(ctx, types.RateLimitOutflow, sdk.NewInt64Coin("mytoken", 11)))
+	ctx = ctx.WithBlockHeight(110)
+	require.NoError(t, k.ConsumeRateLimit(ctx, types.RateLimitOutflow, sdk.NewInt64Coin("mytoken", 70)))
+	capacities := k.GetRateLimitCapacities(ctx, "")
+	require.Len(t, capacities, 1)
+	assert.Equal(t, sdk.NewInt(100), capacities[0].Used)
+	assert.True(t, capacities[0].Remaining.IsZero())
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetRateLimitUsageKey(types.RateLimitOutflow, "mytoken", 100)), "pruned")
+}
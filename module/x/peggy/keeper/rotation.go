@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetPendingEthAddress schedules the rotation of the validator to the eth address, it takes effect
+// with the next valset request. The address is claimed by the validator and added to its history
+// right away.
+func (k Keeper) SetPendingEthAddress(ctx sdk.Context, validator sdk.AccAddress, ethAddr types.EthAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingEthAddressKey(validator), ethAddr.Bytes())
+	k.recordEthAddress(ctx, validator, ethAddr)
+}
+
+// GetPendingEthAddress returns the eth address the validator rotates to with the next valset
+// request, it is empty when no rotation is pending
+func (k Keeper) GetPendingEthAddress(ctx sdk.Context, validator sdk.AccAddress) types.EthAddress {
+	store := ctx.KVStore(k.storeKey)
+	return types.EthAddress(store.Get(types.GetPendingEthAddressKey(validator)))
+}
+
+// activatePendingEthAddresses replaces the eth addresses of the validators with a pending rotation,
+// the valset with the nonce is the first one with the new addresses. The address a validator had
+// before its first rotation is kept under nonce zero.
+func (k Keeper) activatePendingEthAddresses(ctx sdk.Context, valsetNonce int64) {
+	store := ctx.KVStore(k.storeKey)
+	pendingStore := prefix.NewStore(store, types.PendingEthAddressKey)
+	var (
+		validators []sdk.AccAddress
+		addresses  []types.EthAddress
+	)
+	iter := pendingStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		validators = append(validators, sdk.AccAddress(iter.Key()))
+		addresses = append(addresses, types.EthAddress(iter.Value()))
+	}
+	iter.Close()
+
+	for i, validator := range validators {
+		if !k.hasEthAddressByValset(ctx, validator) {
+			store.Set(types.GetEthAddressByValsetKey(validator, 0), k.GetEthAddress(ctx, validator).Bytes())
+		}
+		store.Set(types.GetEthAddressByValsetKey(validator, valsetNonce), addresses[i].Bytes())
+		store.Set(types.GetEthAddressKey(validator), addresses[i].Bytes())
+		pendingStore.Delete(validator)
+	}
+}
+
+func (k Keeper) hasEthAddressByValset(ctx sdk.Context, validator sdk.AccAddress) bool {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetEthAddressByValsetPrefix(validator))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	return iter.Valid()
+}
+
+// GetEthAddressAtValset returns the eth address the validator has in the valset with the nonce. It
+// is the current address for validators that never rotated their key.
+func (k Keeper) GetEthAddressAtValset(ctx sdk.Context, validator sdk.AccAddress, valsetNonce int64) types.EthAddress {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetEthAddressByValsetPrefix(validator))
+	iter := prefixStore.ReverseIterator(nil, sdk.Uint64ToBigEndian(uint64(valsetNonce)+1))
+	defer iter.Close()
+	if iter.Valid() {
+		return types.EthAddress(iter.Value())
+	}
+	return k.GetEthAddress(ctx, validator)
+}
+
+// GetSigningEthAddresses returns the eth addresses confirms of the validator are accepted from. That
+// is the address of the validator in the last valset observed on Ethereum and every address it
+// rotated to since, the current address last. It is empty when the validator has no address.
+func (k Keeper) GetSigningEthAddresses(ctx sdk.Context, validator sdk.AccAddress) []types.EthAddress {
+	observed := k.GetLastObservedValsetNonce(ctx)
+	var res []types.EthAddress
+	if addr := k.GetEthAddressAtValset(ctx, validator, observed); !addr.Empty() {
+		res = append(res, addr)
+	}
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetEthAddressByValsetPrefix(validator))
+	iter := prefixStore.Iterator(sdk.Uint64ToBigEndian(uint64(observed)+1), nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		addr := types.EthAddress(iter.Value())
+		if len(res) == 0 || !res[len(res)-1].Equals(addr) {
+			res = append(res, addr)
+		}
+	}
+	return res
+}
+
+// SetValsetObservation records the attestation of the validator that the valset was observed on
+// Ethereum. When the attesting validators hold a quorum of the bonded power the valset becomes the
+// last observed one and true is returned.
+func (k Keeper) SetValsetObservation(ctx sdk.Context, msg types.MsgValsetObserved) bool {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetValsetObservationKey(msg.Nonce, msg.Validator), k.cdc.MustMarshalBinaryBare(msg))
+	if msg.Nonce <= k.GetLastObservedValsetNonce(ctx) {
+		return false
+	}
+
+	var power int64
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if store.Has(types.GetValsetObservationKey(msg.Nonce, sdk.AccAddress(r.Validator))) {
+			power += r.NormalizedPower
+		}
+	}
+	if !types.HasQuorum(power) {
+		return false
+	}
+	store.Set(types.KeyLastObservedValsetNonce, sdk.Uint64ToBigEndian(uint64(msg.Nonce)))
+	return true
+}
+
+// GetLastObservedValsetNonce returns the nonce of the last valset observed on Ethereum or zero
+func (k Keeper) GetLastObservedValsetNonce(ctx sdk.Context) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.KeyLastObservedValsetNonce)
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
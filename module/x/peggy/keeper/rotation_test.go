@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthAddressRotation(t *testing.T) {
+	k, ctx := CreateTestEnv(t)
+	var validators []sdk.ValAddress
+	for i := 0; i < 3; i++ {
+		validators = append(validators, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(validators...)
+	var (
+		rotating = sdk.AccAddress(validators[0])
+		oldAddr  = ethAddr("0xc783df8a850f42e7F7e57013759C285caa701eB6")
+		newAddr  = ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	)
+	k.SetEthAddress(ctx, rotating, oldAddr)
+	k.SetEthAddress(ctx, sdk.AccAddress(validators[1]), ethAddr("0xE5904695748fe4A84b40b3fc79De2277660BD1D3"))
+	k.SetEthAddress(ctx, sdk.AccAddress(validators[2]), ethAddr("0xeAD9C93b79Ae7C1591b1FB5323BD777E86e150d4"))
+	ctx = ctx.WithBlockHeight(10)
+	first, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+
+	// the rotation waits for the next valset request
+	k.SetPendingEthAddress(ctx, rotating, newAddr)
+	assert.Equal(t, oldAddr, k.GetEthAddress(ctx, rotating))
+	assert.Equal(t, newAddr, k.GetPendingEthAddress(ctx, rotating))
+	assert.Equal(t, rotating, k.GetEthAddressOwner(ctx, newAddr))
+	assert.Equal(t, []types.EthAddress{oldAddr}, k.GetSigningEthAddresses(ctx, rotating))
+	assert.Equal(t, newAddr, k.GetValidatorRegistration(ctx, validators[0]).PendingEthAddress)
+
+	// from the next valset on the new key is used, both are accepted until it is observed
+	ctx = ctx.WithBlockHeight(20)
+	second, err := k.SetValsetRequest(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, second.EthAddresses, newAddr)
+	assert.NotContains(t, second.EthAddresses, oldAddr)
+	assert.Equal(t, newAddr, k.GetEthAddress(ctx, rotating))
+	assert.True(t, k.GetPendingEthAddress(ctx, rotating).Empty())
+	assert.Equal(t, oldAddr, k.GetEthAddressAtValset(ctx, rotating, first.Nonce))
+	assert.Equal(t, newAddr, k.GetEthAddressAtValset(ctx, rotating, second.Nonce))
+	assert.Equal(t, []types.EthAddress{oldAddr, newAddr}, k.GetSigningEthAddresses(ctx, rotating))
+
+	// observing the first valset does not end the grace period
+	for _, v := range validators {
+		k.SetValsetObservation(ctx, types.NewMsgValsetObserved(first.Nonce, sdk.AccAddress(v)))
+	}
+	assert.Equal(t, first.Nonce, k.GetLastObservedValsetNonce(ctx))
+	assert.Equal(t, []types.EthAddress{oldAddr, newAddr}, k.GetSigningEthAddresses(ctx, rotating))
+
+	// two of three equal validators are no quorum
+	assert.False(t, k.SetValsetObservation(ctx, types.NewMsgValsetObserved(second.Nonce, sdk.AccAddress(validators[0]))))
+	assert.False(t, k.SetValsetObservation(ctx, types.NewMsgValsetObserved(second.Nonce, sdk.AccAddress(validators[1]))))
+	assert.Equal(t, first.Nonce, k.GetLastObservedValsetNonce(ctx))
+	assert.True(t, k.SetValsetObservation(ctx, types.NewMsgValsetObserved(second.Nonce, sdk.AccAddress(validators[2]))))
+	assert.Equal(t, second.Nonce, k.GetLastObservedValsetNonce(ctx))
+	assert.Equal(t, []types.EthAddress{newAddr}, k.GetSigningEthAddresses(ctx, rotating))
+
+	// validators that never rotated sign with their only key
+	assert.Equal(t, []types.EthAddress{ethAddr("0xE5904695748fe4A84b40b3fc79De2277660BD1D3")}, k.GetSigningEthAddresses(ctx, sdk.AccAddress(validators[1])))
+	assert.Empty(t, k.GetSigningEthAddresses(ctx, sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))))
+}
@@ -21,13 +21,35 @@ import (
 	dbm "github.com/tendermint/tm-db"
 )
 
+// TestKeepers are the keepers of the other modules in the test environment
+type TestKeepers struct {
+	AccountKeeper auth.AccountKeeper
+	BankKeeper    bank.Keeper
+	SupplyKeeper  supply.Keeper
+}
+
 func CreateTestEnv(t *testing.T) (Keeper, sdk.Context) {
+	t.Helper()
+	k, ctx, _ := CreateTestEnvWithKeepers(t)
+	return k, ctx
+}
+
+// CreateTestEnvWithKeepers returns a keeper with the default params, the supply of the accounts can
+// be managed with the returned keepers
+func CreateTestEnvWithKeepers(t *testing.T) (Keeper, sdk.Context, TestKeepers) {
 	t.Helper()
 	peggyKey := sdk.NewKVStoreKey(types.StoreKey)
+	paramsKey := sdk.NewKVStoreKey(params.StoreKey)
+	paramsTKey := sdk.NewTransientStoreKey(params.TStoreKey)
+	authKey := sdk.NewKVStoreKey(auth.StoreKey)
+	supplyKey := sdk.NewKVStoreKey(supply.StoreKey)
 
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
-	ms.MountStoreWithDB(peggyKey, sdk.StoreTypeIAVL, db)
+	for _, key := range []*sdk.KVStoreKey{peggyKey, paramsKey, authKey, supplyKey} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	ms.MountStoreWithDB(paramsTKey, sdk.StoreTypeTransient, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 
@@ -37,7 +59,26 @@ func CreateTestEnv(t *testing.T) (Keeper, sdk.Context) {
 	}, false, log.NewNopLogger())
 
 	cdc := MakeTestCodec()
-	return NewKeeper(cdc, peggyKey, AlwaysPanicStakingMock{}), ctx
+	paramsKeeper := params.NewKeeper(cdc, paramsKey, paramsTKey)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, paramsKeeper.Subspace(bank.DefaultParamspace), nil)
+	supplyKeeper := supply.NewKeeper(cdc, supplyKey, accountKeeper, bankKeeper, map[string][]string{
+		types.ModuleName:      {supply.Minter, supply.Burner},
+		auth.FeeCollectorName: nil,
+	})
+	supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.NewCoins()))
+
+	k := NewKeeper(cdc, peggyKey, paramsKeeper.Subspace(types.DefaultParamspace), AlwaysPanicStakingMock{}, supplyKeeper)
+	k.SetParams(ctx, types.DefaultParams())
+	return k, ctx, TestKeepers{AccountKeeper: accountKeeper, BankKeeper: bankKeeper, SupplyKeeper: supplyKeeper}
+}
+
+// AddEscrowedTransfer adds a transfer to the pool like the send handler, the amount and fee are
+// minted into the module account instead of escrowed from the sender
+func AddEscrowedTransfer(t *testing.T, k Keeper, ctx sdk.Context, sender sdk.AccAddress, destAddress types.EthAddress, amount sdk.Coin, fee sdk.Coin) types.OutgoingTx {
+	t.Helper()
+	require.NoError(t, k.supplyKeeper.MintCoins(ctx, types.ModuleName, types.TransferCoins(amount, fee)))
+	return k.AddToOutgoingPool(ctx, sender, destAddress, amount, fee)
 }
 
 func MakeTestCodec() *codec.Codec {
@@ -60,12 +101,14 @@ var _ types.StakingKeeper = &StakingKeeperMock{}
 type StakingKeeperMock struct {
 	BondedValidators []staking.Validator
 	ValidatorPower   map[string]int64
+	UnbondingPeriod  time.Duration
 }
 
 func NewStakingKeeperMock(operators ...sdk.ValAddress) *StakingKeeperMock {
 	r := &StakingKeeperMock{
 		BondedValidators: make([]staking.Validator, 0),
 		ValidatorPower:   make(map[string]int64, 0),
+		UnbondingPeriod:  staking.DefaultUnbondingTime,
 	}
 	const defaultTestPower = 100
 	for _, a := range operators {
@@ -89,6 +132,10 @@ func (s *StakingKeeperMock) GetLastValidatorPower(ctx sdk.Context, operator sdk.
 	return v
 }
 
+func (s *StakingKeeperMock) UnbondingTime(ctx sdk.Context) time.Duration {
+	return s.UnbondingPeriod
+}
+
 func (s *StakingKeeperMock) GetLastTotalPower(ctx sdk.Context) (power sdk.Int) {
 	var total int64
 	for _, v := range s.ValidatorPower {
@@ -106,3 +153,7 @@ func (s AlwaysPanicStakingMock) GetBondedValidatorsByPower(ctx sdk.Context) []st
 func (s AlwaysPanicStakingMock) GetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) int64 {
 	panic("unexpected call")
 }
+
+func (s AlwaysPanicStakingMock) UnbondingTime(ctx sdk.Context) time.Duration {
+	panic("unexpected call")
+}
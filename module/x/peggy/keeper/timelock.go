@@ -0,0 +1,136 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// IsTimeLocked returns true when the amount is above the time lock threshold of its denom
+func (k Keeper) IsTimeLocked(ctx sdk.Context, amount sdk.Coin) bool {
+	threshold, ok := k.GetParams(ctx).GetTimeLockThreshold(amount.Denom)
+	return ok && amount.Amount.GT(threshold)
+}
+
+// AddTimeLockedTransfer puts the transfer into the delay queue for TimeLockBlocks blocks, the IDs in
+// the queue count up from 1 like the ones of the pool
+func (k Keeper) AddTimeLockedTransfer(ctx sdk.Context, sender sdk.AccAddress, destAddress types.EthAddress, amount sdk.Coin, fee sdk.Coin) types.TimeLockedTransfer {
+	id := k.autoIncrementID(ctx, types.KeyLastTimeLockID)
+	tx := k.newTransfer(ctx, sender, destAddress, amount, fee)
+	tx.ID = id
+	locked := types.TimeLockedTransfer{
+		Transfer:      tx,
+		ReleaseHeight: ctx.BlockHeight() + int64(k.GetParams(ctx).TimeLockBlocks),
+		Height:        ctx.BlockHeight(),
+	}
+	k.setTimeLockedTransfer(ctx, locked)
+	k.updateTransferStatus(ctx, tx.TransferID, func(s *types.TransferStatus) {
+		s.Status = types.TransferTimeLocked
+		s.TimeLockID = id
+	})
+	return locked
+}
+
+// setTimeLockedTransfer stores the transfer in the delay queue and indexes it by its release height
+func (k Keeper) setTimeLockedTransfer(ctx sdk.Context, locked types.TimeLockedTransfer) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetTimeLockedTransferKey(locked.Transfer.ID), k.cdc.MustMarshalBinaryBare(locked))
+	store.Set(types.GetTimeLockReleaseKey(locked.ReleaseHeight, locked.Transfer.ID), []byte{1})
+}
+
+// GetTimeLockedTransfer returns the transfer with the id in the delay queue or nil
+func (k Keeper) GetTimeLockedTransfer(ctx sdk.Context, id uint64) *types.TimeLockedTransfer {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetTimeLockedTransferKey(id))
+	if bz == nil {
+		return nil
+	}
+	var locked types.TimeLockedTransfer
+	k.cdc.MustUnmarshalBinaryBare(bz, &locked)
+	return &locked
+}
+
+// GetTimeLockedTransfers returns the transfers in the delay queue in the order of their IDs
+func (k Keeper) GetTimeLockedTransfers(ctx sdk.Context) []types.TimeLockedTransfer {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.TimeLockedTransferKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	res := []types.TimeLockedTransfer{}
+	for ; iter.Valid(); iter.Next() {
+		var locked types.TimeLockedTransfer
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &locked)
+		res = append(res, locked)
+	}
+	return res
+}
+
+// ReleaseTimeLockedTransfers moves the transfers whose delay passed into the outgoing pool. They get
+// a new pool ID so that the batch nonces keep increasing. Only the release height index up to the
+// current block is read, the transfers that still wait are not loaded.
+func (k Keeper) ReleaseTimeLockedTransfers(ctx sdk.Context) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.TimeLockReleaseKey)
+	var ids []uint64
+	iter := prefixStore.Iterator(nil, sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight()+1)))
+	for ; iter.Valid(); iter.Next() {
+		ids = append(ids, binary.BigEndian.Uint64(iter.Key()[8:]))
+	}
+	iter.Close()
+	for _, id := range ids {
+		locked := k.GetTimeLockedTransfer(ctx, id)
+		k.removeTimeLockedTransfer(ctx, id)
+		k.addToPool(ctx, locked.Transfer)
+	}
+}
+
+// VetoTransfer drops the transfer from the delay queue and refunds the amount and fee to its sender.
+// The outflow the send consumed is given back to the rate limit while it is in the window.
+func (k Keeper) VetoTransfer(ctx sdk.Context, id uint64) error {
+	locked := k.GetTimeLockedTransfer(ctx, id)
+	if locked == nil {
+		return sdkerrors.Wrapf(types.ErrUnknown, "time locked transfer %d", id)
+	}
+	k.removeTimeLockedTransfer(ctx, id)
+	tx := locked.Transfer
+	k.refundRateLimit(ctx, types.RateLimitOutflow, tx.Amount.Add(sdk.NewCoin(tx.Amount.Denom, tx.EthFee())), locked.Height)
+	k.updateTransferStatus(ctx, tx.TransferID, func(s *types.TransferStatus) {
+		s.Status = types.TransferRefunded
+	})
+	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, tx.Sender, types.TransferCoins(tx.Amount, tx.BridgeFee))
+}
+
+// SetTransferVeto records the vote of the validator to veto the time locked transfer. When the
+// voting validators hold a quorum of the bonded power the transfer is vetoed and true is returned.
+func (k Keeper) SetTransferVeto(ctx sdk.Context, id uint64, validator sdk.AccAddress) (bool, error) {
+	if k.GetTimeLockedTransfer(ctx, id) == nil {
+		return false, sdkerrors.Wrapf(types.ErrUnknown, "time locked transfer %d", id)
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetTransferVetoKey(id, validator), []byte{1})
+
+	var power int64
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if store.Has(types.GetTransferVetoKey(id, sdk.AccAddress(r.Validator))) {
+			power += r.NormalizedPower
+		}
+	}
+	if !types.HasQuorum(power) {
+		return false, nil
+	}
+	return true, k.VetoTransfer(ctx, id)
+}
+
+// removeTimeLockedTransfer deletes the transfer from the delay queue together with its release
+// height index entry and veto votes
+func (k Keeper) removeTimeLockedTransfer(ctx sdk.Context, id uint64) {
+	locked := k.GetTimeLockedTransfer(ctx, id)
+	if locked == nil {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetTimeLockedTransferKey(id))
+	store.Delete(types.GetTimeLockReleaseKey(locked.ReleaseHeight, id))
+	deletePrefix(store, types.GetTransferVetoPrefix(id))
+}
@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeLockedTransfers(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	var validators []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		validators = append(validators, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(validators...)
+	params := k.GetParams(ctx)
+	params.TimeLockThresholds = []types.TimeLockThreshold{{Denom: "mytoken", Threshold: sdk.NewInt(1000)}}
+	params.TimeLockBlocks = 50
+	params.RateLimits = []types.RateLimit{
+		{Denom: "mytoken", WindowBlocks: 100, MaxOutflow: sdk.NewInt(4000), MaxMint: sdk.ZeroInt()},
+	}
+	k.SetParams(ctx, params)
+
+	sender := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	dest := ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	_, err := keepers.BankKeeper.AddCoins(ctx, sender, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 5000)))
+	require.NoError(t, err)
+
+	assert.False(t, k.IsTimeLocked(ctx, sdk.NewInt64Coin("mytoken", 1000)))
+	assert.True(t, k.IsTimeLocked(ctx, sdk.NewInt64Coin("mytoken", 1001)))
+	assert.False(t, k.IsTimeLocked(ctx, sdk.NewInt64Coin("othertoken", 1000000)))
+
+	ctx = ctx.WithBlockHeight(100)
+	for _, amount := range []int64{2000, 1500} {
+		send, fee := sdk.NewInt64Coin("mytoken", amount), sdk.NewInt64Coin("mytoken", 10)
+		require.NoError(t, k.ConsumeRateLimit(ctx, types.RateLimitOutflow, send.Add(fee)))
+		require.NoError(t, k.EscrowTransfer(ctx, sender, send, fee))
+		k.AddTimeLockedTransfer(ctx, sender, dest, send, fee)
+	}
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 1480)), keepers.BankKeeper.GetCoins(ctx, sender))
+	locked := k.GetTimeLockedTransfers(ctx)
+	require.Len(t, locked, 2)
+	assert.Equal(t, uint64(1), locked[0].Transfer.ID)
+	assert.Equal(t, int64(150), locked[0].ReleaseHeight)
+	assert.Equal(t, int64(100), locked[0].Height)
+	assert.Equal(t, sdk.NewInt(480), k.GetRateLimitCapacities(ctx, "mytoken")[0].Remaining)
+
+	// two of four validators can not veto, the third one vetoes and the sender is refunded
+	for i, validator := range validators[:3] {
+		vetoed, err := k.SetTransferVeto(ctx, 1, sdk.AccAddress(validator))
+		require.NoError(t, err)
+		assert.Equal(t, i == 2, vetoed)
+	}
+	assert.Nil(t, k.GetTimeLockedTransfer(ctx, 1))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("mytoken", 3490)), keepers.BankKeeper.GetCoins(ctx, sender))
+	// the vetoed outflow is given back to the rate limit
+	assert.Equal(t, sdk.NewInt(2490), k.GetRateLimitCapacities(ctx, "mytoken")[0].Remaining)
+	_, err = k.SetTransferVeto(ctx, 1, sdk.AccAddress(validators[3]))
+	assert.True(t, types.ErrUnknown.Is(err), err)
+
+	// the other one joins the pool once its delay passed
+	ctx = ctx.WithBlockHeight(120)
+	send, fee := sdk.NewInt64Coin("mytoken", 1100), sdk.NewInt64Coin("mytoken", 10)
+	require.NoError(t, k.EscrowTransfer(ctx, sender, send, fee))
+	assert.Equal(t, int64(170), k.AddTimeLockedTransfer(ctx, sender, dest, send, fee).ReleaseHeight)
+	ctx = ctx.WithBlockHeight(149)
+	k.ReleaseTimeLockedTransfers(ctx)
+	assert.NotNil(t, k.GetTimeLockedTransfer(ctx, 2))
+	ctx = ctx.WithBlockHeight(150)
+	k.ReleaseTimeLockedTransfers(ctx)
+	assert.Nil(t, k.GetTimeLockedTransfer(ctx, 2))
+	require.Len(t, k.GetTimeLockedTransfers(ctx), 1)
+	assert.Equal(t, uint64(3), k.GetTimeLockedTransfers(ctx)[0].Transfer.ID)
+	var pool []types.OutgoingTx
+	k.IterateOutgoingPool(ctx, func(tx types.OutgoingTx) bool {
+		pool = append(pool, tx)
+		return false
+	})
+	require.Len(t, pool, 1)
+	assert.Equal(t, sdk.NewInt64Coin("mytoken", 1500), pool[0].Amount)
+	assert.Equal(t, sender, pool[0].Sender)
+}
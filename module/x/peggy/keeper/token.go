@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// GetCosmosERC20 returns the ERC20 that represents the native denom or nil
+func (k Keeper) GetCosmosERC20(ctx sdk.Context, denom string) *types.CosmosERC20 {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCosmosERC20Key(denom))
+	if bz == nil {
+		return nil
+	}
+	var erc20 types.CosmosERC20
+	k.cdc.MustUnmarshalBinaryBare(bz, &erc20)
+	return &erc20
+}
+
+// GetCosmosERC20ByToken returns the native denom the token contract represents or nil
+func (k Keeper) GetCosmosERC20ByToken(ctx sdk.Context, tokenContract types.EthAddress) *types.CosmosERC20 {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCosmosERC20ByTokenKey(tokenContract))
+	if bz == nil {
+		return nil
+	}
+	return k.GetCosmosERC20(ctx, string(bz))
+}
+
+// GetCosmosERC20s returns the ERC20s of all native denoms in the order of the denoms
+func (k Keeper) GetCosmosERC20s(ctx sdk.Context) []types.CosmosERC20 {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.CosmosERC20Key)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	res := []types.CosmosERC20{}
+	for ; iter.Valid(); iter.Next() {
+		var erc20 types.CosmosERC20
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &erc20)
+		res = append(res, erc20)
+	}
+	return res
+}
+
+// ClaimERC20Deployed records the claim of the validator that the ERC20 of a native token was
+// deployed as the token contract of the Peggy contract, orchestrators only attest the token the
+// contract was deployed with. The metadata has to be the one governance set for the denom. The
+// contract carries a single token, once an ERC20 is recorded claims for other denoms are
+// rejected. Once the validators claiming the same deployment hold a quorum of the bonded power
+// the ERC20 is recorded, it is returned then and for late claims.
+func (k Keeper) ClaimERC20Deployed(ctx sdk.Context, msg types.MsgERC20Deployed) (*types.CosmosERC20, error) {
+	native, ok := k.GetParams(ctx).GetNativeToken(msg.Denom)
+	if !ok {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "native token %s", msg.Denom)
+	}
+	if native.Metadata != msg.Metadata {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "metadata %s of %s, expected %s", msg.Metadata, msg.Denom, native.Metadata)
+	}
+	if existing := k.GetCosmosERC20(ctx, msg.Denom); existing != nil {
+		if !existing.TokenContract.Equals(msg.TokenContract) {
+			return nil, sdkerrors.Wrapf(types.ErrInvalid, "%s is represented by %s", msg.Denom, existing.TokenContract)
+		}
+		return existing, nil
+	}
+	if existing := k.GetCosmosERC20ByToken(ctx, msg.TokenContract); existing != nil {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "%s represents %s", msg.TokenContract, existing.Denom)
+	}
+	if erc20s := k.GetCosmosERC20s(ctx); len(erc20s) != 0 {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "the bridge carries the erc20 of %s", erc20s[0].Denom)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	claimHash := types.ERC20DeployedClaimHash(msg)
+	store.Set(types.GetERC20DeployedClaimKey(claimHash, msg.Validator), []byte{1})
+	var power int64
+	for _, r := range k.GetValidatorRegistrations(ctx) {
+		if store.Has(types.GetERC20DeployedClaimKey(claimHash, sdk.AccAddress(r.Validator))) {
+			power += r.NormalizedPower
+		}
+	}
+	if !types.HasQuorum(power) {
+		return nil, nil
+	}
+	erc20 := types.CosmosERC20{
+		Denom:         msg.Denom,
+		TokenContract: msg.TokenContract,
+		Metadata:      msg.Metadata,
+		Locked:        sdk.ZeroInt(),
+		Height:        ctx.BlockHeight(),
+	}
+	k.setCosmosERC20(ctx, erc20)
+	store.Set(types.GetCosmosERC20ByTokenKey(erc20.TokenContract), []byte(erc20.Denom))
+	return &erc20, nil
+}
+
+// AssertBridgeable returns an error for native denoms whose ERC20 was not deployed yet. Once the
+// ERC20 of a native denom is recorded it is the token of the contract, every other denom is
+// rejected then.
+func (k Keeper) AssertBridgeable(ctx sdk.Context, denom string) error {
+	if _, native := k.GetParams(ctx).GetNativeToken(denom); native && k.GetCosmosERC20(ctx, denom) == nil {
+		return sdkerrors.Wrapf(types.ErrUnknown, "no erc20 deployed for %s", denom)
+	}
+	if erc20s := k.GetCosmosERC20s(ctx); len(erc20s) != 0 && erc20s[0].Denom != denom {
+		return sdkerrors.Wrapf(types.ErrInvalid, "the bridge carries the erc20 of %s, not %s", erc20s[0].Denom, denom)
+	}
+	return nil
+}
+
+// lockNative counts the amount of a native denom that left for Ethereum in a batch as locked, the
+// coins stay in the module account. The vouchers of denoms from Ethereum are burned instead, the
+// contract pays out the ERC20 it holds for them.
+func (k Keeper) lockNative(ctx sdk.Context, amount sdk.Coin) error {
+	erc20 := k.GetCosmosERC20(ctx, amount.Denom)
+	if erc20 == nil {
+		if !amount.IsPositive() {
+			return nil
+		}
+		return k.supplyKeeper.BurnCoins(ctx, types.ModuleName, sdk.NewCoins(amount))
+	}
+	erc20.Locked = erc20.Locked.Add(amount.Amount)
+	k.setCosmosERC20(ctx, *erc20)
+	return nil
+}
+
+// unlockBatchPayout takes back what lockNative counted for a batch that can no longer be executed,
+// the vouchers of denoms from Ethereum are minted again
+func (k Keeper) unlockBatchPayout(ctx sdk.Context, amount sdk.Coin) error {
+	erc20 := k.GetCosmosERC20(ctx, amount.Denom)
+	if erc20 == nil {
+		if !amount.IsPositive() {
+			return nil
+		}
+		return k.supplyKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(amount))
+	}
+	return k.unlockNative(ctx, *erc20, amount)
+}
+
+// unlockNative releases the amount of the native denom deposited back from Ethereum, it can not
+// exceed what was locked
+func (k Keeper) unlockNative(ctx sdk.Context, erc20 types.CosmosERC20, amount sdk.Coin) error {
+	if amount.Amount.GT(erc20.Locked) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "unlock of %s above the locked %s", amount, erc20.Locked)
+	}
+	erc20.Locked = erc20.Locked.Sub(amount.Amount)
+	k.setCosmosERC20(ctx, erc20)
+	return nil
+}
+
+func (k Keeper) setCosmosERC20(ctx sdk.Context, erc20 types.CosmosERC20) {
+	ctx.KVStore(k.storeKey).Set(types.GetCosmosERC20Key(erc20.Denom), k.cdc.MustMarshalBinaryBare(erc20))
+}
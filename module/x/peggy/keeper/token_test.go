@@ -0,0 +1,94 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosERC20(t *testing.T) {
+	k, ctx, keepers := CreateTestEnvWithKeepers(t)
+	var validators []sdk.AccAddress
+	var valAddrs []sdk.ValAddress
+	for i := 0; i < 4; i++ {
+		valAddrs = append(valAddrs, bytes.Repeat([]byte{byte(i + 1)}, sdk.AddrLen))
+		validators = append(validators, sdk.AccAddress(valAddrs[i]))
+	}
+	k.StakingKeeper = NewStakingKeeperMock(valAddrs...)
+	metadata := types.ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 6}
+	params := k.GetParams(ctx)
+	params.NativeTokens = []types.NativeToken{
+		{Denom: "stake", Metadata: metadata},
+		{Denom: "other", Metadata: types.ERC20Metadata{Name: "Other", Symbol: "OTH", Decimals: 6}},
+	}
+	k.SetParams(ctx, params)
+	ctx = ctx.WithBlockHeight(10)
+	token := ethAddr("0x7c2C195CD6D34B8F845992d380aADB2730bB9C6F")
+
+	// the metadata has to be the one governance set
+	_, err := k.ClaimERC20Deployed(ctx, types.NewMsgERC20Deployed("mytoken", token, metadata, validators[0]))
+	assert.True(t, types.ErrUnknown.Is(err), err)
+	_, err = k.ClaimERC20Deployed(ctx, types.NewMsgERC20Deployed("stake", token, types.ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 18}, validators[0]))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	// the native denom can not be bridged before validators with a quorum of the power claimed its ERC20
+	err = k.AssertBridgeable(ctx, "stake")
+	assert.True(t, types.ErrUnknown.Is(err), err)
+	assert.NoError(t, k.AssertBridgeable(ctx, "mytoken"))
+	for _, validator := range validators[:2] {
+		erc20, err := k.ClaimERC20Deployed(ctx, types.NewMsgERC20Deployed("stake", token, metadata, validator))
+		require.NoError(t, err)
+		assert.Nil(t, erc20)
+	}
+	erc20, err := k.ClaimERC20Deployed(ctx, types.NewMsgERC20Deployed("stake", token, metadata, validators[2]))
+	require.NoError(t, err)
+	expected := &types.CosmosERC20{Denom: "stake", TokenContract: token, Metadata: metadata, Locked: sdk.ZeroInt(), Height: 10}
+	assert.Equal(t, expected, erc20)
+	assert.Equal(t, expected, k.GetCosmosERC20ByToken(ctx, token))
+	assert.NoError(t, k.AssertBridgeable(ctx, "stake"))
+
+	// neither the denom nor the token contract can be mapped twice
+	_, err = k.ClaimERC20Deployed(ctx, types.NewMsgERC20Deployed("stake", ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"), metadata, validators[3]))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	_, err = k.ClaimERC20Deployed(ctx, types.NewMsgERC20Deployed("other", token, types.ERC20Metadata{Name: "Other", Symbol: "OTH", Decimals: 6}, validators[3]))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	// the contract carries a single token, no other denom is bridged once the ERC20 is recorded
+	_, err = k.ClaimERC20Deployed(ctx, types.NewMsgERC20Deployed("other", ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"), types.ERC20Metadata{Name: "Other", Symbol: "OTH", Decimals: 6}, validators[3]))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	err = k.AssertBridgeable(ctx, "mytoken")
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	// batched transfers of the native denom are locked in the module account, not burned
+	sender := sdk.AccAddress(bytes.Repeat([]byte{9}, sdk.AddrLen))
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{8}, sdk.AddrLen))
+	_, err = keepers.BankKeeper.AddCoins(ctx, sender, sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)))
+	require.NoError(t, err)
+	keepers.SupplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))))
+	require.NoError(t, k.EscrowTransfer(ctx, sender, sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("stake", 5)))
+	k.AddToOutgoingPool(ctx, sender, ethAddr("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"), sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("stake", 5))
+	_, err = k.BuildOutgoingTXBatch(ctx, "stake")
+	require.NoError(t, err)
+	assert.Equal(t, sdk.NewInt(105), k.GetCosmosERC20(ctx, "stake").Locked)
+
+	// deposits of the ERC20 unlock the coins up to the locked amount
+	_, err = k.MintDeposit(ctx, 0, recipient, sdk.NewInt64Coin("stake", 60))
+	require.NoError(t, err)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 60)), keepers.BankKeeper.GetCoins(ctx, recipient))
+	assert.Equal(t, sdk.NewInt(45), k.GetCosmosERC20(ctx, "stake").Locked)
+	_, err = k.MintDeposit(ctx, 0, recipient, sdk.NewInt64Coin("stake", 50))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)), keepers.SupplyKeeper.GetSupply(ctx).GetTotal())
+
+	// a native denom without an ERC20 is never minted
+	_, err = k.MintDeposit(ctx, 0, recipient, sdk.NewInt64Coin("other", 50))
+	assert.True(t, types.ErrUnknown.Is(err), err)
+	_, err = k.MintDeposit(ctx, 0, recipient, sdk.NewInt64Coin("mytoken", 50))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	assert.Len(t, k.GetCosmosERC20s(ctx), 1)
+}
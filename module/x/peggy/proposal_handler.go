@@ -0,0 +1,45 @@
+package peggy
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// NewProposalHandler returns the handler of the governance proposals of the module
+func NewProposalHandler(keeper Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case BridgePauseProposal:
+			keeper.SetPaused(ctx, c.Paused, types.PauseSourceGovernance)
+			return nil
+		case TransferVetoProposal:
+			for _, id := range c.IDs {
+				if keeper.GetTimeLockedTransfer(ctx, id) == nil {
+					continue
+				}
+				if err := keeper.VetoTransfer(ctx, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		case BlocklistProposal:
+			for _, addr := range c.EthAddresses {
+				keeper.SetEthAddressBlocked(ctx, addr, c.Blocked)
+			}
+			for _, addr := range c.CosmosAddresses {
+				keeper.SetCosmosAddressBlocked(ctx, addr, c.Blocked)
+				if c.Blocked {
+					continue
+				}
+				if err := keeper.ReleaseHeldDeposits(ctx, addr); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized peggy proposal content type: %T", c)
+		}
+	}
+}
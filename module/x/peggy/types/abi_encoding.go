@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The checkpoints are the keccak256 of the solidity `abi.encode` of their fields. go-ethereum only
+// exposes that encoding through abi.Arguments, so the argument lists of the three checkpoint kinds
+// are built once here and reused for every hash.
+var (
+	valsetCheckpointArgs         abi.Arguments
+	batchCheckpointArgs          abi.Arguments
+	valsetAndBatchCheckpointArgs abi.Arguments
+	ethAddressRegistrationArgs   abi.Arguments
+)
+
+func init() {
+	mustType := func(t string) abi.Type {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			panic(fmt.Sprintf("abi type %s: %s", t, err))
+		}
+		return typ
+	}
+	args := func(types ...string) abi.Arguments {
+		res := make(abi.Arguments, len(types))
+		for i, t := range types {
+			res[i] = abi.Argument{Type: mustType(t)}
+		}
+		return res
+	}
+	// peggyId, "checkpoint", valsetNonce, validators, powers
+	valsetCheckpointArgs = args("bytes32", "bytes32", "uint256", "address[]", "uint256[]")
+	// peggyId, "transactionBatch", amounts, destinations, fees, nonces
+	batchCheckpointArgs = args("bytes32", "bytes32", "uint256[]", "address[]", "uint256[]", "uint256[]")
+	// peggyId, "valsetAndTransactionBatch", amounts, destinations, fees, nonces, newCheckpoint
+	valsetAndBatchCheckpointArgs = args("bytes32", "bytes32", "uint256[]", "address[]", "uint256[]", "uint256[]", "bytes32")
+	// peggyId, "ethAddressRegistration", chainId, validator, registration nonce
+	ethAddressRegistrationArgs = args("bytes32", "bytes32", "string", "bytes", "uint256")
+}
+
+// encodeCheckpoint packs the arguments and hashes the result
+func encodeCheckpoint(args abi.Arguments, values ...interface{}) ([]byte, error) {
+	bz, err := args.Pack(values...)
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	return crypto.Keccak256(bz), nil
+}
+
+// valsetArgs converts a valset into the representation the go-ethereum ABI encoder expects
+func valsetArgs(v Valset) ([]common.Address, []*big.Int, error) {
+	addresses := make([]common.Address, len(v.EthAddresses))
+	powers := make([]*big.Int, len(v.Powers))
+	for i, ethAddress := range v.EthAddresses {
+		if err := ethAddress.ValidateBasic(); err != nil {
+			return nil, nil, sdkerrors.Wrapf(err, "valset %d", v.Nonce)
+		}
+		addresses[i] = ethAddress.Address()
+	}
+	for i, power := range v.Powers {
+		powers[i] = big.NewInt(power)
+	}
+	return addresses, powers, nil
+}
+
+// batchTxArgs returns the amounts, destinations, fees and tx nonces of the batch in the
+// representation the go-ethereum ABI encoder expects
+func batchTxArgs(b OutgoingTxBatch) ([]*big.Int, []common.Address, []*big.Int, []*big.Int, error) {
+	amounts := make([]*big.Int, len(b.Elements))
+	destinations := make([]common.Address, len(b.Elements))
+	fees := make([]*big.Int, len(b.Elements))
+	nonces := make([]*big.Int, len(b.Elements))
+	for i, tx := range b.Elements {
+		if err := tx.DestAddress.ValidateBasic(); err != nil {
+			return nil, nil, nil, nil, sdkerrors.Wrapf(err, "batch %d", b.Nonce)
+		}
+		amount, fee := b.ERC20Amounts(tx)
+		amounts[i] = amount.BigInt()
+		destinations[i] = tx.DestAddress.Address()
+		fees[i] = fee.BigInt()
+		nonces[i] = new(big.Int).SetUint64(tx.ID)
+	}
+	return amounts, destinations, fees, nonces, nil
+}
@@ -0,0 +1,139 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonABICheckpoint is the previous encoding through a JSON function spec, it is parsed on every call
+// and the 4 byte method id is dropped from the packed call. Kept as the reference for the
+// precompiled arguments and as the baseline of the benchmarks.
+func jsonABICheckpoint(t testing.TB, inputs []string, values ...interface{}) []byte {
+	var specs []string
+	for i, typ := range inputs {
+		specs = append(specs, fmt.Sprintf(`{ "internalType": "%s", "name": "_%d", "type": "%s" }`, typ, i, typ))
+	}
+	spec := fmt.Sprintf(`[{ "inputs": [%s], "name": "checkpoint", "outputs": [], "stateMutability": "pure", "type": "function" }]`, strings.Join(specs, ","))
+	contractAbi, err := abi.JSON(strings.NewReader(spec))
+	require.NoError(t, err)
+	bz, err := contractAbi.Pack("checkpoint", values...)
+	require.NoError(t, err)
+	return crypto.Keccak256(bz[4:])
+}
+
+func testValset(n int) Valset {
+	v := Valset{Nonce: 42}
+	for i := 0; i < n; i++ {
+		v.Powers = append(v.Powers, int64(1000+i))
+		v.EthAddresses = append(v.EthAddresses, EthAddressFromCommon(common.BigToAddress(big.NewInt(int64(i+1)))))
+	}
+	return v
+}
+
+// testPeggyID is the id the contract tests deploy with, the reference hashes are computed with it
+var testPeggyID = []byte("foo")
+
+func testBatch(n int) OutgoingTxBatch {
+	b := OutgoingTxBatch{Nonce: 7, ValsetNonce: 42}
+	for i := 0; i < n; i++ {
+		b.Elements = append(b.Elements, OutgoingTx{
+			ID:          uint64(i + 1),
+			DestAddress: EthAddressFromCommon(common.BigToAddress(big.NewInt(int64(1000 + i)))),
+			Amount:      sdk.NewInt64Coin("mytoken", int64(100+i)),
+			BridgeFee:   sdk.NewInt64Coin("mytoken", int64(i)),
+		})
+	}
+	return b
+}
+
+func TestCheckpointsMatchJSONABI(t *testing.T) {
+	valset := testValset(5)
+	batch := testBatch(3)
+	addresses, powers, err := valsetArgs(valset)
+	require.NoError(t, err)
+	amounts, destinations, fees, nonces, err := batchTxArgs(batch)
+	require.NoError(t, err)
+
+	valsetCheckpoint, err := valset.GetCheckpoint(testPeggyID)
+	require.NoError(t, err)
+	assert.Equal(t, jsonABICheckpoint(t, []string{"bytes32", "bytes32", "uint256", "address[]", "uint256[]"},
+		peggyIDBytes32(testPeggyID), methodNameBytes32("checkpoint"), big.NewInt(valset.Nonce), addresses, powers), valsetCheckpoint)
+
+	batchCheckpoint, err := batch.GetCheckpoint(testPeggyID)
+	require.NoError(t, err)
+	assert.Equal(t, jsonABICheckpoint(t, []string{"bytes32", "bytes32", "uint256[]", "address[]", "uint256[]", "uint256[]"},
+		peggyIDBytes32(testPeggyID), methodNameBytes32("transactionBatch"), amounts, destinations, fees, nonces), batchCheckpoint)
+
+	combinedCheckpoint, err := batch.GetValsetAndBatchCheckpoint(testPeggyID, valset)
+	require.NoError(t, err)
+	var newCheckpoint [32]uint8
+	copy(newCheckpoint[:], valsetCheckpoint)
+	assert.Equal(t, jsonABICheckpoint(t, []string{"bytes32", "bytes32", "uint256[]", "address[]", "uint256[]", "uint256[]", "bytes32"},
+		peggyIDBytes32(testPeggyID), methodNameBytes32("valsetAndTransactionBatch"), amounts, destinations, fees, nonces, newCheckpoint), combinedCheckpoint)
+}
+
+func TestCheckpointInvalidAddress(t *testing.T) {
+	specs := map[string]EthAddress{
+		"empty":     nil,
+		"too short": make(EthAddress, EthAddressLength-1),
+		"too long":  make(EthAddress, EthAddressLength+1),
+	}
+	for msg, addr := range specs {
+		t.Run(msg, func(t *testing.T) {
+			valset := testValset(2)
+			valset.EthAddresses[1] = addr
+			_, err := valset.GetCheckpoint(testPeggyID)
+			assert.True(t, ErrInvalid.Is(err))
+
+			batch := testBatch(2)
+			batch.Elements[1].DestAddress = addr
+			_, err = batch.GetCheckpoint(testPeggyID)
+			assert.True(t, ErrInvalid.Is(err))
+			_, err = testBatch(2).GetValsetAndBatchCheckpoint(testPeggyID, valset)
+			assert.True(t, ErrInvalid.Is(err))
+		})
+	}
+}
+
+func BenchmarkValsetCheckpoint(b *testing.B) {
+	valset := testValset(150)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := valset.GetCheckpoint(testPeggyID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValsetCheckpointJSONABI(b *testing.B) {
+	valset := testValset(150)
+	inputs := []string{"bytes32", "bytes32", "uint256", "address[]", "uint256[]"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		addresses, powers, err := valsetArgs(valset)
+		if err != nil {
+			b.Fatal(err)
+		}
+		jsonABICheckpoint(b, inputs, peggyIDBytes32(testPeggyID), methodNameBytes32("checkpoint"), big.NewInt(valset.Nonce), addresses, powers)
+	}
+}
+
+func BenchmarkValsetAndBatchCheckpoint(b *testing.B) {
+	valset := testValset(150)
+	batch := testBatch(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := batch.GetValsetAndBatchCheckpoint(testPeggyID, valset); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
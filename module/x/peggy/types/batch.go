@@ -0,0 +1,97 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// OutgoingTx is a single transfer from Cosmos to Ethereum. It sits in the Peggy Bridge Tx pool
+// until it is picked up by a batch. The ID doubles as the tx nonce on the Ethereum side, the
+// contract requires these to be strictly increasing inside a batch.
+type OutgoingTx struct {
+	ID          uint64         `json:"id"`
+	Sender      sdk.AccAddress `json:"sender"`
+	DestAddress EthAddress     `json:"dest_address"`
+	Amount      sdk.Coin       `json:"amount"`
+	BridgeFee   sdk.Coin       `json:"bridge_fee"`
+	// TransferID is the ID of the TransferStatus of the transfer
+	TransferID uint64 `json:"transfer_id"`
+}
+
+// OutgoingTxBatch is a set of outgoing transfers that the validators sign over so that a relayer
+// can submit them to the Ethereum contract in a single call to `submitBatch`.
+//
+// ValsetNonce is the nonce of the last valset observed on Ethereum at the time the batch was
+// built, zero when none was observed. The contract holds this validator set unless a later one
+// was observed since, the relay payload hands the last observed one to the contract as
+// `_currentValidators`.
+type OutgoingTxBatch struct {
+	Nonce       int64        `json:"nonce"`
+	ValsetNonce int64        `json:"valset_nonce"`
+	Elements    []OutgoingTx `json:"elements"`
+	// TotalFee is the sum of the fees the contract pays out to the relayer
+	TotalFee sdk.Coin `json:"total_fee"`
+	// RelayerFees are the fees paid in other denoms, they are held in the module account until the
+	// batch is observed and then paid to the fee collector, see FeeDenom
+	RelayerFees sdk.Coins `json:"relayer_fees,omitempty"`
+	// Decimals are the decimals of the denom at the time the batch was built, the contract pays
+	// out the amounts and fees converted with them
+	Decimals TokenDecimals `json:"decimals"`
+}
+
+// BatchConfirm is a stored MsgConfirmBatch together with the eth address whose key made the
+// signature, a validator that rotates its key can sign with the old or the new one. The fields
+// are laid out like those of MsgConfirmBatch so that confirms stored without EthSigner still
+// decode.
+type BatchConfirm struct {
+	Nonce       int64          `json:"nonce"`
+	ValsetNonce int64          `json:"valset_nonce"`
+	Validator   sdk.AccAddress `json:"validator"`
+	Signature   string         `json:"signature"`
+	EthSigner   EthAddress     `json:"eth_signer,omitempty"`
+}
+
+// NewBatchConfirm returns the confirm to store for the message signed by the eth address
+func NewBatchConfirm(msg MsgConfirmBatch, ethSigner EthAddress) BatchConfirm {
+	return BatchConfirm{
+		Nonce:       msg.Nonce,
+		ValsetNonce: msg.ValsetNonce,
+		Validator:   msg.Validator,
+		Signature:   msg.Signature,
+		EthSigner:   ethSigner,
+	}
+}
+
+// ERC20Amounts returns the amount and fee the contract pays out for the transfer of the batch, in
+// units of the ERC20. Dust below one unit of the ERC20 is not paid out.
+func (b OutgoingTxBatch) ERC20Amounts(tx OutgoingTx) (amount sdk.Int, fee sdk.Int) {
+	amount, _ = b.Decimals.ToERC20(tx.Amount.Amount)
+	fee, _ = b.Decimals.ToERC20(tx.EthFee())
+	return amount, fee
+}
+
+// GetCheckpoint returns the hash the validators sign to approve this batch, it is the same as
+// the `transactionsHash` the contract with the PeggyID computes in `submitBatch`
+func (b OutgoingTxBatch) GetCheckpoint(peggyID []byte) ([]byte, error) {
+	amounts, destinations, fees, nonces, err := batchTxArgs(b)
+	if err != nil {
+		return nil, err
+	}
+	return encodeCheckpoint(batchCheckpointArgs, peggyIDBytes32(peggyID), methodNameBytes32("transactionBatch"), amounts, destinations, fees, nonces)
+}
+
+// GetValsetAndBatchCheckpoint returns the hash the validators sign to approve submitting this
+// batch together with the given new validator set in a single `updateValsetAndSubmitBatch` call
+func (b OutgoingTxBatch) GetValsetAndBatchCheckpoint(peggyID []byte, newValset Valset) ([]byte, error) {
+	valsetCheckpoint, err := newValset.GetCheckpoint(peggyID)
+	if err != nil {
+		return nil, err
+	}
+	var newCheckpoint [32]uint8
+	copy(newCheckpoint[:], valsetCheckpoint)
+
+	amounts, destinations, fees, nonces, err := batchTxArgs(b)
+	if err != nil {
+		return nil, err
+	}
+	return encodeCheckpoint(valsetAndBatchCheckpointArgs, peggyIDBytes32(peggyID), methodNameBytes32("valsetAndTransactionBatch"), amounts, destinations, fees, nonces, newCheckpoint)
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// ProposalTypeBlocklist is the gov proposal type that blocks or unblocks addresses
+const ProposalTypeBlocklist = "Blocklist"
+
+// Blocklist are the addresses governance blocked. No transfers to Ethereum are accepted from the
+// blocked Cosmos addresses or to the blocked Ethereum addresses, and deposits to blocked Cosmos
+// addresses are held by the module.
+type Blocklist struct {
+	EthAddresses    []EthAddress     `json:"eth_addresses"`
+	CosmosAddresses []sdk.AccAddress `json:"cosmos_addresses"`
+}
+
+// HeldDeposit is a deposit from Ethereum to a blocked recipient. It was minted into the module
+// account and is paid out to the recipient when governance unblocks it.
+type HeldDeposit struct {
+	// ID counts up from 1 over the deposits of all recipients
+	ID        uint64         `json:"id"`
+	Recipient sdk.AccAddress `json:"recipient"`
+	Amount    sdk.Coin       `json:"amount"`
+	Height    int64          `json:"height"`
+	// DepositID is the ID of the DepositStatus of the deposit
+	DepositID uint64 `json:"deposit_id"`
+}
+
+// BlocklistProposal adds addresses to the blocklist through governance or removes them from it
+type BlocklistProposal struct {
+	Title           string           `json:"title" yaml:"title"`
+	Description     string           `json:"description" yaml:"description"`
+	Blocked         bool             `json:"blocked" yaml:"blocked"`
+	EthAddresses    []EthAddress     `json:"eth_addresses" yaml:"eth_addresses"`
+	CosmosAddresses []sdk.AccAddress `json:"cosmos_addresses" yaml:"cosmos_addresses"`
+}
+
+func NewBlocklistProposal(title, description string, blocked bool, ethAddresses []EthAddress, cosmosAddresses []sdk.AccAddress) govtypes.Content {
+	return BlocklistProposal{
+		Title:           title,
+		Description:     description,
+		Blocked:         blocked,
+		EthAddresses:    ethAddresses,
+		CosmosAddresses: cosmosAddresses,
+	}
+}
+
+var _ govtypes.Content = BlocklistProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeBlocklist)
+	govtypes.RegisterProposalTypeCodec(BlocklistProposal{}, "peggy/BlocklistProposal")
+}
+
+// nolint
+func (p BlocklistProposal) GetTitle() string       { return p.Title }
+func (p BlocklistProposal) GetDescription() string { return p.Description }
+func (p BlocklistProposal) ProposalRoute() string  { return RouterKey }
+func (p BlocklistProposal) ProposalType() string   { return ProposalTypeBlocklist }
+func (p BlocklistProposal) ValidateBasic() error {
+	if len(p.EthAddresses) == 0 && len(p.CosmosAddresses) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "addresses")
+	}
+	for _, addr := range p.EthAddresses {
+		if err := addr.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	for _, addr := range p.CosmosAddresses {
+		if addr.Empty() {
+			return sdkerrors.Wrap(ErrEmpty, "cosmos address")
+		}
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p BlocklistProposal) String() string {
+	return fmt.Sprintf(`Blocklist Proposal:
+  Title:            %s
+  Description:      %s
+  Blocked:          %t
+  Eth Addresses:    %v
+  Cosmos Addresses: %v
+`, p.Title, p.Description, p.Blocked, p.EthAddresses, p.CosmosAddresses)
+}
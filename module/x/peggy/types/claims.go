@@ -0,0 +1,66 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// Statuses of a deposit from Ethereum
+const (
+	// DepositClaimed is claimed by validators without a quorum of the power yet
+	DepositClaimed = "claimed"
+	// DepositObserved is claimed by validators with a quorum of the power but could not be minted
+	// yet, because the bridge is paused or the mint rate limit of the denom is used up. The end
+	// blocker tries again every block.
+	DepositObserved = "observed"
+	// DepositMinted was minted to the recipient
+	DepositMinted = "minted"
+	// DepositHeld was minted into the module account because the recipient is blocked, see
+	// HeldDeposit
+	DepositHeld = "held"
+	// DepositFailed is claimed by validators with a quorum of the power but can not be minted, for
+	// example because its denom is not bridged or it unlocks more than is locked. Error holds the
+	// reason, the end blocker does not try again.
+	DepositFailed = "failed"
+)
+
+// DepositStatus is a deposit from Ethereum claimed by validators. The TransferOutEvent of the
+// contract does not carry the sender, a deposit only has a recipient.
+type DepositStatus struct {
+	ID        uint64         `json:"id"`
+	EthTxHash string         `json:"eth_tx_hash"`
+	LogIndex  uint64         `json:"log_index"`
+	Recipient sdk.AccAddress `json:"recipient"`
+	// Amount is the claimed amount in units of the ERC20
+	Amount sdk.Coin `json:"amount"`
+	// Minted is the amount converted to units of the denom once the deposit is minted
+	Minted sdk.Coin `json:"minted"`
+	Status string   `json:"status"`
+	// HeldDepositID is set while the deposit is held
+	HeldDepositID uint64 `json:"held_deposit_id,omitempty"`
+	// Error is the reason a failed deposit was not minted
+	Error string `json:"error,omitempty"`
+	// Height is the block height of the last change
+	Height int64 `json:"height"`
+}
+
+// depositClaim is the content of a deposit claim, the tx hash is decoded so that the case of its
+// hex encoding does not matter
+type depositClaim struct {
+	Destination sdk.AccAddress
+	Amount      sdk.Coin
+	EthTxHash   []byte
+	LogIndex    uint64
+}
+
+// DepositClaimHash returns the hash that identifies the deposit the message claims. Claims of the
+// same event with different contents are different deposits, only one of them can be observed.
+func DepositClaimHash(msg MsgEthDeposit) []byte {
+	return tmhash.Sum(ModuleCdc.MustMarshalBinaryBare(depositClaim{
+		Destination: msg.Destination,
+		Amount:      msg.Amount,
+		EthTxHash:   common.HexToHash(msg.EthTxHash).Bytes(),
+		LogIndex:    msg.LogIndex,
+	}))
+}
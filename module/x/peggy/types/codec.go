@@ -16,6 +16,21 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSetEthAddress{}, "peggy/MsgSetEthAddress", nil)
 	cdc.RegisterConcrete(MsgValsetRequest{}, "peggy/MsgValsetRequest", nil)
 	cdc.RegisterConcrete(MsgValsetConfirm{}, "peggy/MsgValsetConfirm", nil)
+	cdc.RegisterConcrete(MsgSendToEth{}, "peggy/MsgSendToEth", nil)
+	cdc.RegisterConcrete(MsgRequestBatch{}, "peggy/MsgRequestBatch", nil)
+	cdc.RegisterConcrete(MsgConfirmBatch{}, "peggy/MsgConfirmBatch", nil)
+	cdc.RegisterConcrete(MsgBatchInChain{}, "peggy/MsgBatchInChain", nil)
+	cdc.RegisterConcrete(MsgEthDeposit{}, "peggy/MsgEthDeposit", nil)
+	cdc.RegisterConcrete(MsgRotateEthAddress{}, "peggy/MsgRotateEthAddress", nil)
+	cdc.RegisterConcrete(MsgValsetObserved{}, "peggy/MsgValsetObserved", nil)
+	cdc.RegisterConcrete(MsgEthBlockObserved{}, "peggy/MsgEthBlockObserved", nil)
+	cdc.RegisterConcrete(MsgGasPriceObserved{}, "peggy/MsgGasPriceObserved", nil)
+	cdc.RegisterConcrete(MsgERC20Deployed{}, "peggy/MsgERC20Deployed", nil)
+	cdc.RegisterConcrete(MsgPauseVote{}, "peggy/MsgPauseVote", nil)
+	cdc.RegisterConcrete(MsgVetoTransfer{}, "peggy/MsgVetoTransfer", nil)
+	cdc.RegisterConcrete(BridgePauseProposal{}, "peggy/BridgePauseProposal", nil)
+	cdc.RegisterConcrete(TransferVetoProposal{}, "peggy/TransferVetoProposal", nil)
+	cdc.RegisterConcrete(BlocklistProposal{}, "peggy/BlocklistProposal", nil)
 
 	cdc.RegisterConcrete(Valset{}, "peggy/Valset", nil)
 }
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxDecimalsShift is the highest difference between the decimals of a denom and of its ERC20,
+// it keeps the converted amounts of all sane supplies inside the 255 bits of an sdk.Int
+const MaxDecimalsShift = 36
+
+// TokenDecimals are the decimals of a denom on Cosmos and of its ERC20 on Ethereum. Amounts on
+// Cosmos are in units of the denom, the contract and the deposit events count in units of the
+// ERC20. Denoms without TokenDecimals have the same units on both sides.
+type TokenDecimals struct {
+	Denom          string `json:"denom" yaml:"denom"`
+	CosmosDecimals uint32 `json:"cosmos_decimals" yaml:"cosmos_decimals"`
+	ERC20Decimals  uint32 `json:"erc20_decimals" yaml:"erc20_decimals"`
+}
+
+// ValidateBasic checks the denom and that the decimals are at most MaxDecimalsShift apart
+func (d TokenDecimals) ValidateBasic() error {
+	if err := sdk.ValidateDenom(d.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	if d.ERC20Decimals > MaxERC20Decimals {
+		return sdkerrors.Wrapf(ErrInvalid, "erc20 decimals %d", d.ERC20Decimals)
+	}
+	if d.shift() > MaxDecimalsShift {
+		return sdkerrors.Wrapf(ErrInvalid, "%d cosmos and %d erc20 decimals of %s", d.CosmosDecimals, d.ERC20Decimals, d.Denom)
+	}
+	return nil
+}
+
+func (d TokenDecimals) String() string {
+	return fmt.Sprintf("%s: %d cosmos, %d erc20 decimals", d.Denom, d.CosmosDecimals, d.ERC20Decimals)
+}
+
+// ToERC20 converts an amount of the denom to units of the ERC20. The dust is the part of the
+// amount below one unit of the ERC20, in units of the denom, it can not be sent to Ethereum.
+func (d TokenDecimals) ToERC20(amount sdk.Int) (converted sdk.Int, dust sdk.Int) {
+	if d.ERC20Decimals >= d.CosmosDecimals {
+		return amount.Mul(d.factor()), sdk.ZeroInt()
+	}
+	return quoRem(amount, d.factor())
+}
+
+// ToCosmos converts an amount of the ERC20 to units of the denom. The dust is the part of the
+// amount below one unit of the denom, in units of the ERC20, it can not be minted on Cosmos.
+func (d TokenDecimals) ToCosmos(amount sdk.Int) (converted sdk.Int, dust sdk.Int) {
+	if d.CosmosDecimals >= d.ERC20Decimals {
+		return amount.Mul(d.factor()), sdk.ZeroInt()
+	}
+	return quoRem(amount, d.factor())
+}
+
+// factor is the ratio between the smaller and the larger of the two units
+func (d TokenDecimals) factor() sdk.Int {
+	return sdk.NewIntFromBigInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.shift())), nil))
+}
+
+func (d TokenDecimals) shift() uint32 {
+	if d.ERC20Decimals > d.CosmosDecimals {
+		return d.ERC20Decimals - d.CosmosDecimals
+	}
+	return d.CosmosDecimals - d.ERC20Decimals
+}
+
+func quoRem(amount sdk.Int, divisor sdk.Int) (sdk.Int, sdk.Int) {
+	quo := amount.Quo(divisor)
+	return quo, amount.Sub(quo.Mul(divisor))
+}
+
+// TokenDust is what the conversion between the units of a denom and of its ERC20 could not
+// represent, it is kept as a module fee
+type TokenDust struct {
+	Denom string `json:"denom"`
+	// Cosmos is the dust of transfers to Ethereum that stays in the module account, in units of
+	// the denom
+	Cosmos sdk.Int `json:"cosmos"`
+	// ERC20 is the dust of deposits that stays in the contract, in units of the ERC20
+	ERC20 sdk.Int `json:"erc20"`
+}
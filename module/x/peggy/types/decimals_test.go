@@ -0,0 +1,115 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenDecimalsConversion(t *testing.T) {
+	specs := map[string]struct {
+		decimals   TokenDecimals
+		amount     int64
+		toERC20    int64
+		cosmosDust int64
+		toCosmos   int64
+		erc20Dust  int64
+	}{
+		"same decimals": {
+			decimals: TokenDecimals{Denom: "stake"},
+			amount:   1234567,
+			toERC20:  1234567,
+			toCosmos: 1234567,
+		},
+		"finer erc20": {
+			decimals:  TokenDecimals{Denom: "stake", CosmosDecimals: 6, ERC20Decimals: 9},
+			amount:    1234567,
+			toERC20:   1234567000,
+			toCosmos:  1234,
+			erc20Dust: 567,
+		},
+		"coarser erc20": {
+			decimals:   TokenDecimals{Denom: "stake", CosmosDecimals: 9, ERC20Decimals: 6},
+			amount:     1234567,
+			toERC20:    1234,
+			cosmosDust: 567,
+			toCosmos:   1234567000,
+		},
+		"below one unit": {
+			decimals:   TokenDecimals{Denom: "stake", CosmosDecimals: 9, ERC20Decimals: 6},
+			amount:     999,
+			cosmosDust: 999,
+			toCosmos:   999000,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			converted, dust := spec.decimals.ToERC20(sdk.NewInt(spec.amount))
+			assert.Equal(t, spec.toERC20, converted.Int64())
+			assert.Equal(t, spec.cosmosDust, dust.Int64())
+			converted, dust = spec.decimals.ToCosmos(sdk.NewInt(spec.amount))
+			assert.Equal(t, spec.toCosmos, converted.Int64())
+			assert.Equal(t, spec.erc20Dust, dust.Int64())
+		})
+	}
+}
+
+func TestValidateTokenDecimals(t *testing.T) {
+	native := NativeToken{Denom: "stake", Metadata: ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 18}}
+	specs := map[string]struct {
+		src    []TokenDecimals
+		native []NativeToken
+		expErr bool
+	}{
+		"empty": {
+			src: []TokenDecimals{},
+		},
+		"valid": {
+			src: []TokenDecimals{{Denom: "stake", CosmosDecimals: 6, ERC20Decimals: 18}},
+		},
+		"matching native token": {
+			src:    []TokenDecimals{{Denom: "stake", CosmosDecimals: 6, ERC20Decimals: 18}},
+			native: []NativeToken{native},
+		},
+		"other decimals than the native token": {
+			src:    []TokenDecimals{{Denom: "stake", CosmosDecimals: 6, ERC20Decimals: 8}},
+			native: []NativeToken{native},
+			expErr: true,
+		},
+		"shift too large": {
+			src:    []TokenDecimals{{Denom: "stake", CosmosDecimals: 0, ERC20Decimals: MaxDecimalsShift + 1}},
+			expErr: true,
+		},
+		"erc20 decimals above uint8": {
+			src:    []TokenDecimals{{Denom: "stake", CosmosDecimals: 250, ERC20Decimals: 256}},
+			expErr: true,
+		},
+		"invalid denom": {
+			src:    []TokenDecimals{{Denom: "1x", CosmosDecimals: 6, ERC20Decimals: 18}},
+			expErr: true,
+		},
+		"duplicate denom": {
+			src: []TokenDecimals{
+				{Denom: "stake", CosmosDecimals: 6, ERC20Decimals: 18},
+				{Denom: "stake", CosmosDecimals: 6, ERC20Decimals: 8},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			params := DefaultParams()
+			params.TokenDecimals = spec.src
+			if spec.native != nil {
+				params.NativeTokens = spec.native
+			}
+			err := params.Validate()
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -6,4 +6,11 @@ import (
 
 var (
 	ErrMyCustomError = sdkerrors.Register(ModuleName, 1, "leaving this here as a reference for when we do our errors better")
+	ErrEmpty         = sdkerrors.Register(ModuleName, 2, "empty")
+	ErrUnknown       = sdkerrors.Register(ModuleName, 3, "unknown")
+	ErrInvalid       = sdkerrors.Register(ModuleName, 4, "invalid")
+	ErrPaused        = sdkerrors.Register(ModuleName, 5, "bridge paused")
+	ErrRateLimited   = sdkerrors.Register(ModuleName, 6, "rate limit exceeded")
+	ErrBlocked       = sdkerrors.Register(ModuleName, 7, "address blocked")
+	ErrFeeTooLow     = sdkerrors.Register(ModuleName, 8, "bridge fee too low")
 )
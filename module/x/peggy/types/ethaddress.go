@@ -0,0 +1,116 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EthAddressLength is the number of bytes of an Ethereum address
+const EthAddressLength = common.AddressLength
+
+// EthAddress is an Ethereum address. It is stored as its 20 bytes and rendered in the EIP-55
+// mixed case checksum form, so that addresses given in different cases are the same address.
+type EthAddress []byte
+
+// NewEthAddress parses a 0x prefixed hex address in any case
+func NewEthAddress(s string) (EthAddress, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return nil, sdkerrors.Wrapf(ErrInvalid, "eth address %q: missing 0x prefix", s)
+	}
+	bz, err := hexutil.Decode("0x" + s[2:])
+	if err != nil {
+		return nil, sdkerrors.Wrapf(ErrInvalid, "eth address %q: %s", s, err)
+	}
+	if len(bz) != EthAddressLength {
+		return nil, sdkerrors.Wrapf(ErrInvalid, "eth address %q: length %d", s, len(bz))
+	}
+	return EthAddress(bz), nil
+}
+
+// EthAddressFromCommon converts a go-ethereum address
+func EthAddressFromCommon(addr common.Address) EthAddress {
+	return EthAddress(addr.Bytes())
+}
+
+// Address returns the go-ethereum representation
+func (a EthAddress) Address() common.Address {
+	return common.BytesToAddress(a)
+}
+
+// Bytes returns the raw address bytes
+func (a EthAddress) Bytes() []byte {
+	return a
+}
+
+// Empty returns true when no address is set
+func (a EthAddress) Empty() bool {
+	return len(a) == 0
+}
+
+// Equals returns true when both are the same address
+func (a EthAddress) Equals(o EthAddress) bool {
+	return bytes.Equal(a, o)
+}
+
+// ValidateBasic checks the length of the address
+func (a EthAddress) ValidateBasic() error {
+	if len(a) != EthAddressLength {
+		return sdkerrors.Wrapf(ErrInvalid, "eth address length %d", len(a))
+	}
+	return nil
+}
+
+// String renders the address in EIP-55 form, an empty address renders as empty string
+func (a EthAddress) String() string {
+	if a.Empty() {
+		return ""
+	}
+	return a.Address().Hex()
+}
+
+// MarshalJSON renders the address as EIP-55 string
+func (a EthAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON parses the address from a hex string in any case, an empty string is an empty
+// address
+func (a *EthAddress) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*a = EthAddress{}
+		return nil
+	}
+	addr, err := NewEthAddress(s)
+	if err != nil {
+		return err
+	}
+	*a = addr
+	return nil
+}
+
+// MarshalYAML renders the address as EIP-55 string
+func (a EthAddress) MarshalYAML() (interface{}, error) {
+	return a.String(), nil
+}
+
+// LegacyEthAddress converts an address that was stored as its hex string before addresses were
+// kept as bytes. It returns false when the value is not such a string.
+func LegacyEthAddress(bz []byte) (EthAddress, bool) {
+	if len(bz) != 2+2*EthAddressLength {
+		return nil, false
+	}
+	addr, err := NewEthAddress(string(bz))
+	if err != nil {
+		return nil, false
+	}
+	return addr, true
+}
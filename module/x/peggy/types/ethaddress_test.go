@@ -0,0 +1,70 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEthAddress(t *testing.T) {
+	const checksummed = "0xc783df8a850f42e7F7e57013759C285caa701eB6"
+	specs := map[string]struct {
+		src    string
+		expErr bool
+	}{
+		"checksummed":  {src: checksummed},
+		"lower case":   {src: "0xc783df8a850f42e7f7e57013759c285caa701eb6"},
+		"upper case":   {src: "0xC783DF8A850F42E7F7E57013759C285CAA701EB6"},
+		"upper prefix": {src: "0XC783DF8A850F42E7F7E57013759C285CAA701EB6"},
+		"no 0x prefix": {src: "c783df8a850f42e7F7e57013759C285caa701eB6", expErr: true},
+		"too short":    {src: "0xc783df8a850f42e7F7e57013759C285caa701e", expErr: true},
+		"too long":     {src: checksummed + "00", expErr: true},
+		"not hex":      {src: "0xz783df8a850f42e7F7e57013759C285caa701eB6", expErr: true},
+		"empty":        {src: "", expErr: true},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			addr, err := NewEthAddress(spec.src)
+			if spec.expErr {
+				assert.True(t, ErrInvalid.Is(err), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, addr.Bytes(), EthAddressLength)
+			assert.Equal(t, checksummed, addr.String())
+		})
+	}
+}
+
+func TestEthAddressJSON(t *testing.T) {
+	addr, err := NewEthAddress("0xc783df8a850f42e7f7e57013759c285caa701eb6")
+	require.NoError(t, err)
+	bz, err := ModuleCdc.MarshalJSON(addr)
+	require.NoError(t, err)
+	assert.Equal(t, `"0xc783df8a850f42e7F7e57013759C285caa701eB6"`, string(bz))
+
+	var got EthAddress
+	require.NoError(t, ModuleCdc.UnmarshalJSON([]byte(`"0xC783DF8A850F42E7F7E57013759C285CAA701EB6"`), &got))
+	assert.True(t, addr.Equals(got))
+	assert.Error(t, ModuleCdc.UnmarshalJSON([]byte(`"0x1234"`), &got))
+
+	// the binary encoding is the same as for the strings addresses were kept as before, so
+	// values stored back then decode into the legacy form that can be converted
+	type legacyValset struct {
+		Nonce        int64
+		Powers       []int64
+		EthAddresses []string
+	}
+	cdc := codec.New()
+	bz, err = cdc.MarshalBinaryBare(legacyValset{Nonce: 1, Powers: []int64{1}, EthAddresses: []string{"0xc783df8a850f42e7f7e57013759c285caa701eb6"}})
+	require.NoError(t, err)
+	var valset Valset
+	require.NoError(t, cdc.UnmarshalBinaryBare(bz, &valset))
+	converted, ok := LegacyEthAddress(valset.EthAddresses[0])
+	require.True(t, ok)
+	assert.True(t, addr.Equals(converted))
+	_, ok = LegacyEthAddress(addr)
+	assert.False(t, ok)
+}
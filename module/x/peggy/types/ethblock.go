@@ -0,0 +1,26 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EthBlock is the chain's view of the latest final Ethereum block, the power weighted median of
+// the attestations of the bonded validators. It only moves forward.
+type EthBlock struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+	// Timestamp is the time of the block in unix seconds
+	Timestamp uint64 `json:"timestamp"`
+	// Height is the block height the view moved to the block at
+	Height int64 `json:"height"`
+}
+
+// EthBlockAttestation is the latest Ethereum block a validator attested with MsgEthBlockObserved
+type EthBlockAttestation struct {
+	Validator sdk.AccAddress `json:"validator"`
+	Number    uint64         `json:"number"`
+	Hash      string         `json:"hash"`
+	Timestamp uint64         `json:"timestamp"`
+	// Height is the block height of the attestation
+	Height int64 `json:"height"`
+}
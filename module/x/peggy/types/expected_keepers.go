@@ -1,11 +1,27 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	staking "github.com/cosmos/cosmos-sdk/x/staking"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
 )
 
 type StakingKeeper interface {
 	GetBondedValidatorsByPower(ctx sdk.Context) []staking.Validator
 	GetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) int64
+	UnbondingTime(ctx sdk.Context) time.Duration
+}
+
+// SupplyKeeper moves coins between accounts and the module account, mints and burns them and
+// manages the module account
+type SupplyKeeper interface {
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
+	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	GetModuleAccountAndPermissions(ctx sdk.Context, moduleName string) (supplyexported.ModuleAccountI, []string)
+	SetModuleAccount(ctx sdk.Context, macc supplyexported.ModuleAccountI)
 }
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MinBridgeFee is the lowest bridge fee a transfer of the denom to Ethereum is accepted with, in
+// the denom of the transfer
+type MinBridgeFee struct {
+	Denom  string  `json:"denom" yaml:"denom"`
+	MinFee sdk.Int `json:"min_fee" yaml:"min_fee"`
+}
+
+// ValidateBasic checks the denom and that the minimum is not negative
+func (m MinBridgeFee) ValidateBasic() error {
+	if err := sdk.ValidateDenom(m.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	if m.MinFee == (sdk.Int{}) || m.MinFee.IsNegative() {
+		return sdkerrors.Wrapf(ErrInvalid, "min bridge fee of %s", m.Denom)
+	}
+	return nil
+}
+
+func (m MinBridgeFee) String() string {
+	return fmt.Sprintf("%s%s", m.MinFee, m.Denom)
+}
+
+// FeeDenom whitelists a denom the bridge fee of transfers of TransferDenom can be paid in. These
+// fees can not be paid out by the contract, they stay in the module account until the batch is
+// observed on Ethereum and are then paid to the fee collector, that shares them among the
+// validators whose orchestrators relay the batches. With a positive Rate one unit of the fee
+// counts as Rate units of TransferDenom towards the minimum fee and the batch order, with a zero
+// Rate the fee is paid straight to the relayers and counts as nothing.
+type FeeDenom struct {
+	Denom         string  `json:"denom" yaml:"denom"`
+	TransferDenom string  `json:"transfer_denom" yaml:"transfer_denom"`
+	Rate          sdk.Dec `json:"rate" yaml:"rate"`
+}
+
+// ValidateBasic checks the denoms and that the rate is not negative
+func (f FeeDenom) ValidateBasic() error {
+	for _, denom := range []string{f.Denom, f.TransferDenom} {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return sdkerrors.Wrap(ErrInvalid, err.Error())
+		}
+	}
+	if f.Denom == f.TransferDenom {
+		return sdkerrors.Wrapf(ErrInvalid, "fee denom %s is the transfer denom", f.Denom)
+	}
+	if f.Rate == (sdk.Dec{}) || f.Rate.IsNegative() {
+		return sdkerrors.Wrapf(ErrInvalid, "fee denom %s rate", f.Denom)
+	}
+	return nil
+}
+
+func (f FeeDenom) String() string {
+	return fmt.Sprintf("%s for %s at %s", f.Denom, f.TransferDenom, f.Rate)
+}
+
+// TransferCoins returns the amount and the fee of a transfer together, they can be of different
+// denoms
+func TransferCoins(amount sdk.Coin, fee sdk.Coin) sdk.Coins {
+	if amount.Denom == fee.Denom {
+		return sdk.NewCoins(amount.Add(fee))
+	}
+	return sdk.NewCoins(amount, fee)
+}
+
+// EthFee returns the part of the bridge fee the contract pays out, the fee when it is in the
+// denom of the transfer and zero otherwise
+func (tx OutgoingTx) EthFee() sdk.Int {
+	if tx.BridgeFee.Denom != tx.Amount.Denom {
+		return sdk.ZeroInt()
+	}
+	return tx.BridgeFee.Amount
+}
+
+// BatchWait is how long the transfers of a batch waited in the pool, from the block they entered
+// it in to the block the batch was built in
+type BatchWait struct {
+	BatchNonce int64         `json:"batch_nonce"`
+	Height     int64         `json:"height"`
+	MedianWait time.Duration `json:"median_wait"`
+}
+
+// FeePercentile is the fee value that the percentage of the transfers in the pool pay at most
+type FeePercentile struct {
+	Percentile uint64  `json:"percentile"`
+	Fee        sdk.Int `json:"fee"`
+}
+
+// FeeEstimate describes the pool of a denom for users choosing a bridge fee. All fees are values
+// in the denom, see FeeDenom.
+type FeeEstimate struct {
+	Denom string `json:"denom"`
+	// PoolDepth is the number of transfers waiting for a batch
+	PoolDepth  uint64  `json:"pool_depth"`
+	PoolAmount sdk.Int `json:"pool_amount"`
+	MinFee     sdk.Int `json:"min_fee"`
+	// FeePercentiles are empty while the pool is
+	FeePercentiles []FeePercentile `json:"fee_percentiles"`
+	MaxBatchSize   uint64          `json:"max_batch_size"`
+	// GasFee is the lowest fee that covers the share of a transfer in the gas of the next batch,
+	// zero without a gas price or an eth value of the denom
+	GasFee sdk.Int `json:"gas_fee"`
+	// NextBatchFee is the lowest fee that makes the next batch
+	NextBatchFee sdk.Int `json:"next_batch_fee"`
+	// MedianBatchWait is the median of the median waits of the RecentBatches last batches
+	MedianBatchWait time.Duration `json:"median_batch_wait"`
+	RecentBatches   uint64        `json:"recent_batches"`
+}
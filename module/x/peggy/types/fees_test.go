@@ -0,0 +1,95 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFeeDenoms(t *testing.T) {
+	valid := FeeDenom{Denom: "stake", TransferDenom: "mytoken", Rate: sdk.NewDecWithPrec(5, 1)}
+	specs := map[string]struct {
+		src    []FeeDenom
+		expErr bool
+	}{
+		"empty": {
+			src: []FeeDenom{},
+		},
+		"valid": {
+			src: []FeeDenom{valid},
+		},
+		"zero rate": {
+			src: []FeeDenom{{Denom: "stake", TransferDenom: "mytoken", Rate: sdk.ZeroDec()}},
+		},
+		"same denom for another transfer denom": {
+			src: []FeeDenom{valid, {Denom: "stake", TransferDenom: "othertoken", Rate: sdk.OneDec()}},
+		},
+		"transfer denom": {
+			src:    []FeeDenom{{Denom: "mytoken", TransferDenom: "mytoken", Rate: sdk.OneDec()}},
+			expErr: true,
+		},
+		"negative rate": {
+			src:    []FeeDenom{{Denom: "stake", TransferDenom: "mytoken", Rate: sdk.NewDec(-1)}},
+			expErr: true,
+		},
+		"missing rate": {
+			src:    []FeeDenom{{Denom: "stake", TransferDenom: "mytoken"}},
+			expErr: true,
+		},
+		"duplicate pair": {
+			src:    []FeeDenom{valid, valid},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := validateFeeDenoms(spec.src)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBridgeFeeValue(t *testing.T) {
+	params := DefaultParams()
+	params.FeeDenoms = []FeeDenom{
+		{Denom: "stake", TransferDenom: "mytoken", Rate: sdk.NewDecWithPrec(25, 1)},
+		{Denom: "other", TransferDenom: "mytoken", Rate: sdk.ZeroDec()},
+	}
+	specs := map[string]struct {
+		fee      sdk.Coin
+		expValue int64
+		expOk    bool
+	}{
+		"same denom": {
+			fee:      sdk.NewInt64Coin("mytoken", 7),
+			expValue: 7,
+			expOk:    true,
+		},
+		"converted and truncated": {
+			fee:      sdk.NewInt64Coin("stake", 3),
+			expValue: 7,
+			expOk:    true,
+		},
+		"paid to relayers only": {
+			fee:   sdk.NewInt64Coin("other", 3),
+			expOk: true,
+		},
+		"not whitelisted": {
+			fee: sdk.NewInt64Coin("unknown", 3),
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			value, ok := params.BridgeFeeValue("mytoken", spec.fee)
+			assert.Equal(t, spec.expOk, ok)
+			if ok {
+				assert.Equal(t, spec.expValue, value.Int64())
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// DefaultBatchBaseGas is the gas submitBatch takes apart from the transfers, mostly checking the
+// signatures of the valset
+const DefaultBatchBaseGas uint64 = 500000
+
+// DefaultBatchGasPerTransfer is the gas an ERC20 transfer inside submitBatch takes
+const DefaultBatchGasPerTransfer uint64 = 40000
+
+// EthValue is what one unit of a denom is worth in wei. Batches of denoms with an EthValue leave
+// out the transfers whose fees do not cover their share of the gas of submitBatch.
+type EthValue struct {
+	Denom      string  `json:"denom" yaml:"denom"`
+	WeiPerUnit sdk.Dec `json:"wei_per_unit" yaml:"wei_per_unit"`
+}
+
+// ValidateBasic checks the denom and that the value is positive
+func (v EthValue) ValidateBasic() error {
+	if err := sdk.ValidateDenom(v.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	if v.WeiPerUnit == (sdk.Dec{}) || !v.WeiPerUnit.IsPositive() {
+		return sdkerrors.Wrapf(ErrInvalid, "eth value of %s", v.Denom)
+	}
+	return nil
+}
+
+func (v EthValue) String() string {
+	return fmt.Sprintf("%s wei per %s", v.WeiPerUnit, v.Denom)
+}
+
+// GasPrice is the chain's view of the Ethereum gas price, the power weighted median of the
+// attestations of the bonded validators
+type GasPrice struct {
+	// Price is in wei per gas
+	Price sdk.Int `json:"price"`
+	// Height is the block height the view changed at
+	Height int64 `json:"height"`
+}
+
+// GasPriceAttestation is the latest gas price a validator attested with MsgGasPriceObserved
+type GasPriceAttestation struct {
+	Validator sdk.AccAddress `json:"validator"`
+	Price     sdk.Int        `json:"price"`
+	// Height is the block height of the attestation
+	Height int64 `json:"height"`
+}
+
+// BatchGasCost returns the wei the share of a transfer in the gas of a submitBatch call with n
+// transfers costs at the gas price, the gas per transfer and an equal part of the base gas
+func (p Params) BatchGasCost(gasPrice sdk.Int, n int) sdk.Dec {
+	gas := sdk.NewDecFromInt(sdk.NewIntFromUint64(p.BatchBaseGas)).QuoInt64(int64(n)).
+		Add(sdk.NewDecFromInt(sdk.NewIntFromUint64(p.BatchGasPerTransfer)))
+	return gas.MulInt(gasPrice)
+}
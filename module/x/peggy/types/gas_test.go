@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEthValues(t *testing.T) {
+	valid := EthValue{Denom: "mytoken", WeiPerUnit: sdk.NewDecWithPrec(5, 1)}
+	specs := map[string]struct {
+		src    []EthValue
+		expErr bool
+	}{
+		"empty": {
+			src: []EthValue{},
+		},
+		"valid": {
+			src: []EthValue{valid, {Denom: "othertoken", WeiPerUnit: sdk.NewDec(1000)}},
+		},
+		"zero value": {
+			src:    []EthValue{{Denom: "mytoken", WeiPerUnit: sdk.ZeroDec()}},
+			expErr: true,
+		},
+		"missing value": {
+			src:    []EthValue{{Denom: "mytoken"}},
+			expErr: true,
+		},
+		"invalid denom": {
+			src:    []EthValue{{Denom: "1x", WeiPerUnit: sdk.OneDec()}},
+			expErr: true,
+		},
+		"duplicate denom": {
+			src:    []EthValue{valid, valid},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := validateEthValues(spec.src)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBatchGasCost(t *testing.T) {
+	params := Params{BatchBaseGas: 100, BatchGasPerTransfer: 10}
+	assert.Equal(t, sdk.NewDec(1100), params.BatchGasCost(sdk.NewInt(10), 1))
+	assert.Equal(t, sdk.MustNewDecFromStr("433.333333333333333330"), params.BatchGasCost(sdk.NewInt(10), 3))
+}
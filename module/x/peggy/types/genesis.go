@@ -1,16 +1,207 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// GenesisState holds the params and the bridge state of the module. The transfers, batches and
+// held deposits keep their IDs, the counters in Sequences continue after them.
 type GenesisState struct {
+	Params Params `json:"params" yaml:"params"`
+	// EthAddresses are the eth addresses the validators sign with
+	EthAddresses []EthAddressRegistration `json:"eth_addresses" yaml:"eth_addresses"`
+	// CosmosERC20s are the ERC20s of the native denoms with the amounts locked for them
+	CosmosERC20s []CosmosERC20 `json:"cosmos_erc20s" yaml:"cosmos_erc20s"`
+	// OutgoingPool are the transfers waiting for a batch and OutgoingBatches the batches built
+	OutgoingPool    []OutgoingTx      `json:"outgoing_pool" yaml:"outgoing_pool"`
+	OutgoingBatches []OutgoingTxBatch `json:"outgoing_batches" yaml:"outgoing_batches"`
+	// LastObservedTxNonce is the highest tx nonce of the batches observed executed on Ethereum
+	LastObservedTxNonce uint64               `json:"last_observed_tx_nonce" yaml:"last_observed_tx_nonce"`
+	TimeLockedTransfers []TimeLockedTransfer `json:"time_locked_transfers" yaml:"time_locked_transfers"`
+	Blocklist           Blocklist            `json:"blocklist" yaml:"blocklist"`
+	HeldDeposits        []HeldDeposit        `json:"held_deposits" yaml:"held_deposits"`
+	Sequences           GenesisSequences     `json:"sequences" yaml:"sequences"`
+}
+
+// EthAddressRegistration is the eth address a validator registered
+type EthAddressRegistration struct {
+	Validator  sdk.AccAddress `json:"validator" yaml:"validator"`
+	EthAddress EthAddress     `json:"eth_address" yaml:"eth_address"`
 }
 
-func NewGenesisState() GenesisState {
-	return GenesisState{}
+// GenesisSequences are the last IDs handed out, each is at least the highest ID of its kind in the
+// genesis state. The transfer and deposit IDs count the TransferStatus and DepositStatus entries,
+// which are not exported but are referenced by the transfers and held deposits.
+type GenesisSequences struct {
+	LastValsetNonce   int64  `json:"last_valset_nonce" yaml:"last_valset_nonce"`
+	LastTxPoolID      uint64 `json:"last_tx_pool_id" yaml:"last_tx_pool_id"`
+	LastBatchNonce    uint64 `json:"last_batch_nonce" yaml:"last_batch_nonce"`
+	LastTimeLockID    uint64 `json:"last_time_lock_id" yaml:"last_time_lock_id"`
+	LastHeldDepositID uint64 `json:"last_held_deposit_id" yaml:"last_held_deposit_id"`
+	LastTransferID    uint64 `json:"last_transfer_id" yaml:"last_transfer_id"`
+	LastDepositID     uint64 `json:"last_deposit_id" yaml:"last_deposit_id"`
 }
 
+func NewGenesisState(params Params) GenesisState {
+	return GenesisState{
+		Params:              params,
+		EthAddresses:        []EthAddressRegistration{},
+		CosmosERC20s:        []CosmosERC20{},
+		OutgoingPool:        []OutgoingTx{},
+		OutgoingBatches:     []OutgoingTxBatch{},
+		TimeLockedTransfers: []TimeLockedTransfer{},
+		Blocklist: Blocklist{
+			EthAddresses:    []EthAddress{},
+			CosmosAddresses: []sdk.AccAddress{},
+		},
+		HeldDeposits: []HeldDeposit{},
+	}
+}
+
+// ValidateGenesis checks the params, that the entries are valid and unique and that no ID is above
+// its sequence
 func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+	seq := data.Sequences
+	if seq.LastValsetNonce < 0 {
+		return sdkerrors.Wrapf(ErrInvalid, "last valset nonce %d", seq.LastValsetNonce)
+	}
+
+	validators := make(map[string]bool, len(data.EthAddresses))
+	ethAddresses := make(map[string]bool, len(data.EthAddresses))
+	for _, r := range data.EthAddresses {
+		if r.Validator.Empty() {
+			return sdkerrors.Wrap(ErrEmpty, "validator")
+		}
+		if err := r.EthAddress.ValidateBasic(); err != nil {
+			return err
+		}
+		if validators[r.Validator.String()] || ethAddresses[r.EthAddress.String()] {
+			return sdkerrors.Wrapf(ErrInvalid, "duplicate registration of %s with %s", r.Validator, r.EthAddress)
+		}
+		validators[r.Validator.String()] = true
+		ethAddresses[r.EthAddress.String()] = true
+	}
+
+	denoms := make(map[string]bool, len(data.CosmosERC20s))
+	tokens := make(map[string]bool, len(data.CosmosERC20s))
+	for _, erc20 := range data.CosmosERC20s {
+		if err := sdk.ValidateDenom(erc20.Denom); err != nil {
+			return sdkerrors.Wrap(ErrInvalid, err.Error())
+		}
+		if err := erc20.TokenContract.ValidateBasic(); err != nil {
+			return err
+		}
+		if token, ok := data.Params.GetNativeToken(erc20.Denom); !ok || token.Metadata != erc20.Metadata {
+			return sdkerrors.Wrapf(ErrInvalid, "erc20 of %s does not match a native token", erc20.Denom)
+		}
+		if erc20.Locked == (sdk.Int{}) || erc20.Locked.IsNegative() {
+			return sdkerrors.Wrapf(ErrInvalid, "locked amount of %s", erc20.Denom)
+		}
+		if denoms[erc20.Denom] || tokens[erc20.TokenContract.String()] {
+			return sdkerrors.Wrapf(ErrInvalid, "duplicate erc20 %s of %s", erc20.TokenContract, erc20.Denom)
+		}
+		denoms[erc20.Denom] = true
+		tokens[erc20.TokenContract.String()] = true
+	}
+
+	poolIDs := make(map[uint64]bool, len(data.OutgoingPool))
+	for _, tx := range data.OutgoingPool {
+		if err := validateGenesisTransfer(tx, seq.LastTxPoolID, seq.LastTransferID); err != nil {
+			return err
+		}
+		if poolIDs[tx.ID] {
+			return sdkerrors.Wrapf(ErrInvalid, "duplicate pool id %d", tx.ID)
+		}
+		poolIDs[tx.ID] = true
+	}
+	batchNonces := make(map[int64]bool, len(data.OutgoingBatches))
+	for _, batch := range data.OutgoingBatches {
+		if batch.Nonce <= 0 || uint64(batch.Nonce) > seq.LastBatchNonce || batchNonces[batch.Nonce] {
+			return sdkerrors.Wrapf(ErrInvalid, "batch nonce %d", batch.Nonce)
+		}
+		batchNonces[batch.Nonce] = true
+		if len(batch.Elements) == 0 {
+			return sdkerrors.Wrapf(ErrEmpty, "batch %d", batch.Nonce)
+		}
+		for i, tx := range batch.Elements {
+			if err := validateGenesisTransfer(tx, seq.LastTxPoolID, seq.LastTransferID); err != nil {
+				return err
+			}
+			if tx.Amount.Denom != batch.TotalFee.Denom || (i > 0 && tx.ID <= batch.Elements[i-1].ID) {
+				return sdkerrors.Wrapf(ErrInvalid, "transfer %d in batch %d", tx.ID, batch.Nonce)
+			}
+		}
+	}
+	if data.LastObservedTxNonce > seq.LastTxPoolID {
+		return sdkerrors.Wrapf(ErrInvalid, "last observed tx nonce %d above the last pool id", data.LastObservedTxNonce)
+	}
+
+	timeLockIDs := make(map[uint64]bool, len(data.TimeLockedTransfers))
+	for _, locked := range data.TimeLockedTransfers {
+		if err := validateGenesisTransfer(locked.Transfer, seq.LastTimeLockID, seq.LastTransferID); err != nil {
+			return err
+		}
+		if timeLockIDs[locked.Transfer.ID] {
+			return sdkerrors.Wrapf(ErrInvalid, "duplicate time lock id %d", locked.Transfer.ID)
+		}
+		timeLockIDs[locked.Transfer.ID] = true
+	}
+
+	for _, addr := range data.Blocklist.EthAddresses {
+		if err := addr.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	for _, addr := range data.Blocklist.CosmosAddresses {
+		if addr.Empty() {
+			return sdkerrors.Wrap(ErrEmpty, "cosmos address")
+		}
+	}
+
+	heldIDs := make(map[uint64]bool, len(data.HeldDeposits))
+	for _, held := range data.HeldDeposits {
+		if held.ID == 0 || held.ID > seq.LastHeldDepositID || heldIDs[held.ID] {
+			return sdkerrors.Wrapf(ErrInvalid, "held deposit id %d", held.ID)
+		}
+		heldIDs[held.ID] = true
+		if held.Recipient.Empty() {
+			return sdkerrors.Wrap(ErrEmpty, "held deposit recipient")
+		}
+		if !held.Amount.IsValid() {
+			return sdkerrors.Wrapf(ErrInvalid, "held deposit %d amount %s", held.ID, held.Amount)
+		}
+		if held.DepositID > seq.LastDepositID {
+			return sdkerrors.Wrapf(ErrInvalid, "held deposit %d of deposit %d", held.ID, held.DepositID)
+		}
+	}
+	return nil
+}
+
+// validateGenesisTransfer checks the transfer and that its ID and transfer ID are not above their
+// sequences
+func validateGenesisTransfer(tx OutgoingTx, lastID uint64, lastTransferID uint64) error {
+	if tx.ID == 0 || tx.ID > lastID {
+		return sdkerrors.Wrapf(ErrInvalid, "transfer id %d", tx.ID)
+	}
+	if tx.TransferID > lastTransferID {
+		return sdkerrors.Wrapf(ErrInvalid, "transfer %d: transfer id %d", tx.ID, tx.TransferID)
+	}
+	if tx.Sender.Empty() {
+		return sdkerrors.Wrapf(ErrEmpty, "transfer %d sender", tx.ID)
+	}
+	if err := tx.DestAddress.ValidateBasic(); err != nil {
+		return err
+	}
+	if !tx.Amount.IsValid() || !tx.BridgeFee.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalid, "transfer %d of %s with fee %s", tx.ID, tx.Amount, tx.BridgeFee)
+	}
 	return nil
 }
 
 func DefaultGenesisState() GenesisState {
-	return GenesisState{}
+	return NewGenesisState(DefaultParams())
 }
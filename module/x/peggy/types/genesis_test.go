@@ -0,0 +1,108 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	validator := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	dest, err := NewEthAddress("0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7")
+	if err != nil {
+		panic(err)
+	}
+	metadata := ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 6}
+	tx := OutgoingTx{
+		ID:          1,
+		Sender:      validator,
+		DestAddress: dest,
+		Amount:      sdk.NewInt64Coin("mytoken", 100),
+		BridgeFee:   sdk.NewInt64Coin("mytoken", 1),
+		TransferID:  1,
+	}
+	valid := func() GenesisState {
+		data := DefaultGenesisState()
+		data.Params.NativeTokens = []NativeToken{{Denom: "stake", Metadata: metadata}}
+		data.EthAddresses = []EthAddressRegistration{{Validator: validator, EthAddress: dest}}
+		data.CosmosERC20s = []CosmosERC20{{Denom: "stake", TokenContract: dest, Metadata: metadata, Locked: sdk.NewInt(10)}}
+		data.OutgoingBatches = []OutgoingTxBatch{{Nonce: 1, Elements: []OutgoingTx{tx}, TotalFee: sdk.NewInt64Coin("mytoken", 1)}}
+		data.LastObservedTxNonce = 1
+		data.HeldDeposits = []HeldDeposit{{ID: 1, Recipient: validator, Amount: sdk.NewInt64Coin("mytoken", 5), DepositID: 1}}
+		data.Sequences = GenesisSequences{LastTxPoolID: 1, LastBatchNonce: 1, LastHeldDepositID: 1, LastTransferID: 1, LastDepositID: 1}
+		return data
+	}
+	specs := map[string]struct {
+		change func(*GenesisState)
+		expErr bool
+	}{
+		"default": {
+			change: func(data *GenesisState) { *data = DefaultGenesisState() },
+		},
+		"valid": {
+			change: func(*GenesisState) {},
+		},
+		"duplicate eth address": {
+			change: func(data *GenesisState) {
+				data.EthAddresses = append(data.EthAddresses, EthAddressRegistration{Validator: sdk.AccAddress(bytes.Repeat([]byte{2}, sdk.AddrLen)), EthAddress: dest})
+			},
+			expErr: true,
+		},
+		"erc20 of a denom that is not native": {
+			change: func(data *GenesisState) { data.Params.NativeTokens = []NativeToken{} },
+			expErr: true,
+		},
+		"negative locked amount": {
+			change: func(data *GenesisState) { data.CosmosERC20s[0].Locked = sdk.NewInt(-1) },
+			expErr: true,
+		},
+		"pool id above its sequence": {
+			change: func(data *GenesisState) {
+				next := tx
+				next.ID = 2
+				data.OutgoingPool = []OutgoingTx{next}
+			},
+			expErr: true,
+		},
+		"batch nonce above its sequence": {
+			change: func(data *GenesisState) { data.Sequences.LastBatchNonce = 0 },
+			expErr: true,
+		},
+		"batch of another denom": {
+			change: func(data *GenesisState) { data.OutgoingBatches[0].TotalFee = sdk.NewInt64Coin("other", 1) },
+			expErr: true,
+		},
+		"transfer id above its sequence": {
+			change: func(data *GenesisState) { data.Sequences.LastTransferID = 0 },
+			expErr: true,
+		},
+		"time lock id above its sequence": {
+			change: func(data *GenesisState) {
+				data.TimeLockedTransfers = []TimeLockedTransfer{{Transfer: tx, ReleaseHeight: 10}}
+			},
+			expErr: true,
+		},
+		"empty blocked address": {
+			change: func(data *GenesisState) { data.Blocklist.CosmosAddresses = []sdk.AccAddress{{}} },
+			expErr: true,
+		},
+		"duplicate held deposit": {
+			change: func(data *GenesisState) { data.HeldDeposits = append(data.HeldDeposits, data.HeldDeposits[0]) },
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			data := valid()
+			spec.change(&data)
+			err := ValidateGenesis(data)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -24,12 +24,267 @@ var (
 	EthAddressKey    = []byte{0x1}
 	ValsetRequestKey = []byte{0x2}
 	ValsetConfirmKey = []byte{0x3}
+
+	OutgoingTXPoolKey  = []byte{0x4}
+	OutgoingTXBatchKey = []byte{0x5}
+	BatchConfirmKey    = []byte{0x6}
+	SequenceKeyPrefix  = []byte{0x7}
+
+	EthAddressOwnerKey   = []byte{0x8}
+	EthAddressHistoryKey = []byte{0x9}
+
+	PendingEthAddressKey   = []byte{0xa}
+	EthAddressByValsetKey  = []byte{0xb}
+	ValsetObservationKey   = []byte{0xc}
+	ValsetNonceByHeightKey = []byte{0xd}
+	ValsetRequestRecordKey = []byte{0xe}
+	PauseVoteKey           = []byte{0xf}
+	DepositStatusKey       = []byte{0x10}
+	DepositByClaimHashKey  = []byte{0x11}
+	DepositClaimKey        = []byte{0x12}
+	ObservedDepositKey     = []byte{0x13}
+	BatchInChainKey        = []byte{0x14}
+	RateLimitUsageKey      = []byte{0x15}
+	TimeLockedTransferKey  = []byte{0x16}
+	TransferVetoKey        = []byte{0x17}
+	BlockedEthAddressKey   = []byte{0x18}
+	BlockedCosmosKey       = []byte{0x19}
+	HeldDepositKey         = []byte{0x1a}
+	OutgoingTxTimeKey      = []byte{0x1b}
+	BatchWaitKey           = []byte{0x1c}
+	TransferStatusKey      = []byte{0x1d}
+	TransferBySenderKey    = []byte{0x1e}
+	TransferByRecipientKey = []byte{0x1f}
+	DepositByRecipientKey  = []byte{0x20}
+	EthBlockAttestationKey = []byte{0x21}
+	GasPriceAttestationKey = []byte{0x22}
+	CosmosERC20Key         = []byte{0x23}
+	CosmosERC20ByTokenKey  = []byte{0x24}
+	ERC20DeployedClaimKey  = []byte{0x25}
+	TokenDustKey           = []byte{0x26}
+	TimeLockReleaseKey     = []byte{0x27}
+
+	KeyLastTXPoolID            = append(SequenceKeyPrefix, []byte("lastTxPoolId")...)
+	KeyLastOutgoingBatchID     = append(SequenceKeyPrefix, []byte("lastBatchId")...)
+	KeyLastObservedValsetNonce = append(SequenceKeyPrefix, []byte("lastObservedValsetNonce")...)
+	KeyLastValsetNonce         = append(SequenceKeyPrefix, []byte("lastValsetNonce")...)
+	KeyPauseState              = append(SequenceKeyPrefix, []byte("pauseState")...)
+	KeyLastDepositID           = append(SequenceKeyPrefix, []byte("lastDepositId")...)
+	KeyLastTimeLockID          = append(SequenceKeyPrefix, []byte("lastTimeLockId")...)
+	KeyLastHeldDepositID       = append(SequenceKeyPrefix, []byte("lastHeldDepositId")...)
+	KeyLastTransferID          = append(SequenceKeyPrefix, []byte("lastTransferId")...)
+	KeyEthBlock                = append(SequenceKeyPrefix, []byte("ethBlock")...)
+	KeyGasPrice                = append(SequenceKeyPrefix, []byte("gasPrice")...)
+	KeyLastObservedTxNonce     = append(SequenceKeyPrefix, []byte("lastObservedTxNonce")...)
+	KeyDepositMintCursor       = append(SequenceKeyPrefix, []byte("depositMintCursor")...)
 )
 
 func GetEthAddressKey(validator sdk.AccAddress) []byte {
 	return append(EthAddressKey, []byte(validator)...)
 }
 
+// GetEthAddressOwnerKey returns the key of the validator that registered the eth address
+func GetEthAddressOwnerKey(ethAddr EthAddress) []byte {
+	return append(EthAddressOwnerKey, ethAddr.Bytes()...)
+}
+
+// GetEthAddressHistoryKey returns the key of the eth address a validator registered with the nonce
+func GetEthAddressHistoryKey(validator sdk.AccAddress, nonce uint64) []byte {
+	return append(GetEthAddressHistoryPrefix(validator), sdk.Uint64ToBigEndian(nonce)...)
+}
+
+// GetEthAddressHistoryPrefix returns the prefix of all eth addresses a validator registered
+func GetEthAddressHistoryPrefix(validator sdk.AccAddress) []byte {
+	return append(EthAddressHistoryKey, validator.Bytes()...)
+}
+
+// GetPendingEthAddressKey returns the key of the eth address a validator rotates to with the next
+// valset request
+func GetPendingEthAddressKey(validator sdk.AccAddress) []byte {
+	return append(PendingEthAddressKey, validator.Bytes()...)
+}
+
+// GetEthAddressByValsetKey returns the key of the eth address a validator has from the valset with
+// the nonce on
+func GetEthAddressByValsetKey(validator sdk.AccAddress, valsetNonce int64) []byte {
+	return append(GetEthAddressByValsetPrefix(validator), sdk.Uint64ToBigEndian(uint64(valsetNonce))...)
+}
+
+// GetEthAddressByValsetPrefix returns the prefix of the eth addresses of a validator by valset nonce
+func GetEthAddressByValsetPrefix(validator sdk.AccAddress) []byte {
+	return append(EthAddressByValsetKey, validator.Bytes()...)
+}
+
+// GetValsetObservationKey returns the key of the attestation of a validator that the valset with
+// the nonce was observed on Ethereum
+func GetValsetObservationKey(nonce int64, validator sdk.AccAddress) []byte {
+	return append(GetValsetObservationPrefix(nonce), validator.Bytes()...)
+}
+
+// GetValsetObservationPrefix returns the prefix of all attestations for the valset with the nonce
+func GetValsetObservationPrefix(nonce int64) []byte {
+	return append(ValsetObservationKey, sdk.Uint64ToBigEndian(uint64(nonce))...)
+}
+
+// GetValsetNonceByHeightKey returns the key of the nonce of the valset requested at the height
+func GetValsetNonceByHeightKey(height int64) []byte {
+	return append(ValsetNonceByHeightKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetPauseVoteKey returns the key of the vote of a validator to pause the bridge
+func GetPauseVoteKey(validator sdk.AccAddress) []byte {
+	return append(PauseVoteKey, validator.Bytes()...)
+}
+
+// GetDepositStatusKey returns the key of the status of the deposit from Ethereum with the id
+func GetDepositStatusKey(id uint64) []byte {
+	return append(DepositStatusKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetDepositByClaimHashKey returns the key of the id of the deposit with the claim hash
+func GetDepositByClaimHashKey(claimHash []byte) []byte {
+	return append(DepositByClaimHashKey, claimHash...)
+}
+
+// GetDepositClaimKey returns the key of the claim of a validator for the deposit with the id
+func GetDepositClaimKey(id uint64, validator sdk.AccAddress) []byte {
+	return append(GetDepositClaimPrefix(id), validator.Bytes()...)
+}
+
+// GetDepositClaimPrefix returns the prefix of all claims for the deposit with the id
+func GetDepositClaimPrefix(id uint64) []byte {
+	return append(DepositClaimKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetObservedDepositKey returns the key that queues the observed deposit with the id for minting
+func GetObservedDepositKey(id uint64) []byte {
+	return append(ObservedDepositKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetBatchInChainKey returns the key of the attestation of a validator that the batch with the
+// nonce was executed on Ethereum
+func GetBatchInChainKey(batchNonce int64, validator sdk.AccAddress) []byte {
+	return append(GetBatchInChainPrefix(batchNonce), validator.Bytes()...)
+}
+
+// GetBatchInChainPrefix returns the prefix of all attestations for the batch with the nonce
+func GetBatchInChainPrefix(batchNonce int64) []byte {
+	return append(BatchInChainKey, sdk.Uint64ToBigEndian(uint64(batchNonce))...)
+}
+
+// GetRateLimitUsageKey returns the key of the value of the denom that passed in the direction at
+// the height
+func GetRateLimitUsageKey(direction string, denom string, height int64) []byte {
+	return append(GetRateLimitUsagePrefix(direction, denom), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetRateLimitUsagePrefix returns the prefix of the values of the denom that passed in the
+// direction by height. Neither directions nor denoms contain a slash.
+func GetRateLimitUsagePrefix(direction string, denom string) []byte {
+	return append(RateLimitUsageKey, []byte(direction+"/"+denom+"/")...)
+}
+
+// GetTimeLockedTransferKey returns the key of the transfer with the id in the delay queue
+func GetTimeLockedTransferKey(id uint64) []byte {
+	return append(TimeLockedTransferKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetTimeLockReleaseKey returns the key of the release height index of the transfer with the id in
+// the delay queue, the heights sort before the IDs
+func GetTimeLockReleaseKey(height int64, id uint64) []byte {
+	return append(append(TimeLockReleaseKey, sdk.Uint64ToBigEndian(uint64(height))...), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetTransferVetoKey returns the key of the vote of a validator to veto the time locked transfer
+func GetTransferVetoKey(id uint64, validator sdk.AccAddress) []byte {
+	return append(GetTransferVetoPrefix(id), validator.Bytes()...)
+}
+
+// GetTransferVetoPrefix returns the prefix of all veto votes for the time locked transfer
+func GetTransferVetoPrefix(id uint64) []byte {
+	return append(TransferVetoKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetBlockedEthAddressKey returns the key that marks the eth address as blocked
+func GetBlockedEthAddressKey(ethAddr EthAddress) []byte {
+	return append(BlockedEthAddressKey, ethAddr.Bytes()...)
+}
+
+// GetBlockedCosmosKey returns the key that marks the cosmos address as blocked
+func GetBlockedCosmosKey(addr sdk.AccAddress) []byte {
+	return append(BlockedCosmosKey, addr.Bytes()...)
+}
+
+// GetHeldDepositKey returns the key of the held deposit with the id to the recipient
+func GetHeldDepositKey(recipient sdk.AccAddress, id uint64) []byte {
+	return append(GetHeldDepositPrefix(recipient), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetHeldDepositPrefix returns the prefix of all held deposits to the recipient. Cosmos addresses
+// all have the same length so that the prefix of one recipient does not cover another.
+func GetHeldDepositPrefix(recipient sdk.AccAddress) []byte {
+	return append(HeldDepositKey, recipient.Bytes()...)
+}
+
+// GetOutgoingTxTimeKey returns the key of the block time the transfer with the id entered the pool
+func GetOutgoingTxTimeKey(id uint64) []byte {
+	return append(OutgoingTxTimeKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetBatchWaitKey returns the key of the wait of the transfers of the batch of the denom with the
+// nonce
+func GetBatchWaitKey(denom string, batchNonce int64) []byte {
+	return append(GetBatchWaitPrefix(denom), sdk.Uint64ToBigEndian(uint64(batchNonce))...)
+}
+
+// GetBatchWaitPrefix returns the prefix of the waits of the batches of the denom by nonce, denoms
+// do not contain a slash
+func GetBatchWaitPrefix(denom string) []byte {
+	return append(BatchWaitKey, []byte(denom+"/")...)
+}
+
+// GetTransferStatusKey returns the key of the status of the transfer to Ethereum with the id
+func GetTransferStatusKey(id uint64) []byte {
+	return append(TransferStatusKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetTransferBySenderKey returns the key that indexes the transfer with the id by its sender
+func GetTransferBySenderKey(sender sdk.AccAddress, id uint64) []byte {
+	return append(GetTransferBySenderPrefix(sender), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetTransferBySenderPrefix returns the prefix of the transfers of the sender
+func GetTransferBySenderPrefix(sender sdk.AccAddress) []byte {
+	return append(TransferBySenderKey, sender.Bytes()...)
+}
+
+// GetTransferByRecipientKey returns the key that indexes the transfer with the id by its eth
+// destination
+func GetTransferByRecipientKey(recipient EthAddress, id uint64) []byte {
+	return append(GetTransferByRecipientPrefix(recipient), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetTransferByRecipientPrefix returns the prefix of the transfers to the eth address
+func GetTransferByRecipientPrefix(recipient EthAddress) []byte {
+	return append(TransferByRecipientKey, recipient.Bytes()...)
+}
+
+// GetDepositByRecipientKey returns the key that indexes the deposit with the id by its recipient
+func GetDepositByRecipientKey(recipient sdk.AccAddress, id uint64) []byte {
+	return append(GetDepositByRecipientPrefix(recipient), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetDepositByRecipientPrefix returns the prefix of the deposits to the recipient
+func GetDepositByRecipientPrefix(recipient sdk.AccAddress) []byte {
+	return append(DepositByRecipientKey, recipient.Bytes()...)
+}
+
+// GetValsetRequestRecordKey returns the key of the height and time the valset with the nonce was
+// requested at
+func GetValsetRequestRecordKey(nonce int64) []byte {
+	return append(ValsetRequestRecordKey, sdk.Uint64ToBigEndian(uint64(nonce))...)
+}
+
 func GetValsetRequestKey(nonce int64) []byte {
 	nonceBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(nonceBytes, uint64(nonce))
@@ -43,3 +298,60 @@ func GetValsetConfirmKey(nonce int64, validator sdk.AccAddress) []byte {
 
 	return append(ValsetConfirmKey, append(nonceBytes, []byte(validator)...)...)
 }
+
+func GetOutgoingTxPoolKey(id uint64) []byte {
+	return append(OutgoingTXPoolKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetOutgoingTxBatchKey(nonce int64) []byte {
+	return append(OutgoingTXBatchKey, sdk.Uint64ToBigEndian(uint64(nonce))...)
+}
+
+// GetBatchConfirmKey returns the key of a batch confirm. The valset nonce is zero for a confirm
+// over the batch alone and set for a confirm over the batch combined with a new valset.
+func GetBatchConfirmKey(batchNonce int64, valsetNonce int64, validator sdk.AccAddress) []byte {
+	return append(GetBatchConfirmPrefix(batchNonce, valsetNonce), []byte(validator)...)
+}
+
+// GetBatchConfirmPrefix returns the prefix under which all confirms for the batch and
+// valset nonce combination are stored
+func GetBatchConfirmPrefix(batchNonce int64, valsetNonce int64) []byte {
+	nonceBytes := append(sdk.Uint64ToBigEndian(uint64(batchNonce)), sdk.Uint64ToBigEndian(uint64(valsetNonce))...)
+	return append(BatchConfirmKey, nonceBytes...)
+}
+
+// GetBatchConfirmsPrefix returns the prefix of all confirms for the batch, with or without valset
+func GetBatchConfirmsPrefix(batchNonce int64) []byte {
+	return append(BatchConfirmKey, sdk.Uint64ToBigEndian(uint64(batchNonce))...)
+}
+
+// GetEthBlockAttestationKey returns the key of the latest Ethereum block the validator attested
+func GetEthBlockAttestationKey(validator sdk.AccAddress) []byte {
+	return append(EthBlockAttestationKey, validator.Bytes()...)
+}
+
+// GetCosmosERC20Key returns the key of the ERC20 that represents the native denom
+func GetCosmosERC20Key(denom string) []byte {
+	return append(CosmosERC20Key, []byte(denom)...)
+}
+
+// GetCosmosERC20ByTokenKey returns the key of the native denom the token contract represents
+func GetCosmosERC20ByTokenKey(tokenContract EthAddress) []byte {
+	return append(CosmosERC20ByTokenKey, tokenContract.Bytes()...)
+}
+
+// GetERC20DeployedClaimKey returns the key of the claim of a validator for the deployment with the
+// claim hash
+func GetERC20DeployedClaimKey(claimHash []byte, validator sdk.AccAddress) []byte {
+	return append(append(ERC20DeployedClaimKey, claimHash...), validator.Bytes()...)
+}
+
+// GetTokenDustKey returns the key of the dust kept of the denom
+func GetTokenDustKey(denom string) []byte {
+	return append(TokenDustKey, []byte(denom)...)
+}
+
+// GetGasPriceAttestationKey returns the key of the latest gas price the validator attested
+func GetGasPriceAttestationKey(validator sdk.AccAddress) []byte {
+	return append(GasPriceAttestationKey, validator.Bytes()...)
+}
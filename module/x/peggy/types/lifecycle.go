@@ -0,0 +1,41 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Statuses of a transfer to Ethereum
+const (
+	// TransferPooled waits in the pool for a batch, PoolID is set
+	TransferPooled = "pooled"
+	// TransferTimeLocked waits in the delay queue, TimeLockID is set
+	TransferTimeLocked = "time_locked"
+	// TransferBatched is in the batch with BatchNonce
+	TransferBatched = "batched"
+	// TransferSigned is in a batch that validators with a quorum of the power signed
+	TransferSigned = "signed"
+	// TransferSubmitted is in a batch that a validator saw executed on Ethereum
+	TransferSubmitted = "submitted"
+	// TransferObserved is in a batch that validators with a quorum of the power saw executed on
+	// Ethereum, it is done
+	TransferObserved = "observed"
+	// TransferRefunded was vetoed in the delay queue and refunded
+	TransferRefunded = "refunded"
+)
+
+// TransferStatus is the lifecycle of a transfer to Ethereum. Its ID stays the same while the
+// transfer moves from the delay queue to the pool and into a batch.
+type TransferStatus struct {
+	ID          uint64         `json:"id"`
+	Sender      sdk.AccAddress `json:"sender"`
+	DestAddress EthAddress     `json:"dest_address"`
+	Amount      sdk.Coin       `json:"amount"`
+	BridgeFee   sdk.Coin       `json:"bridge_fee"`
+	Status      string         `json:"status"`
+	// PoolID is the ID in the pool, which is the tx nonce on Ethereum
+	PoolID     uint64 `json:"pool_id,omitempty"`
+	TimeLockID uint64 `json:"time_lock_id,omitempty"`
+	BatchNonce int64  `json:"batch_nonce,omitempty"`
+	// Height is the block height of the last change
+	Height int64 `json:"height"`
+}
@@ -3,12 +3,11 @@ package types
 import (
 	"encoding/hex"
 	"fmt"
-	"regexp"
 
-	"github.com/althea-net/peggy/module/x/peggy/utils"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // ValsetConfirm
@@ -49,8 +48,7 @@ func (msg MsgValsetConfirm) ValidateBasic() error {
 	if msg.Validator.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
 	}
-
-	return nil
+	return validateEthSignature(msg.Signature)
 }
 
 // GetSignBytes encodes the message for signing
@@ -66,10 +64,10 @@ func (msg MsgValsetConfirm) GetSigners() []sdk.AccAddress {
 // ValsetRequest
 // This message starts off the validator set update process by coordinating a block height
 // around which signatures over the validators, powers, and ethereum addresses will be made
-// and submitted using a ValsetConfirm. Anyone can send this message as it is not authenticated
-// in any way. In theory people could spam it and the validators will have to determine which
-// block to actually coordinate around by looking over the valset requests and seeing which one
-// some other validator has already submitted a ValsetResponse for.
+// and submitted using a ValsetConfirm. Who can send it and how often is limited by the valset
+// request policy governance chose, see ValsetRequestPolicyValidators, ValsetRequestPolicyMinBlocks
+// and ValsetRequestPolicyFee. Requests for a valset that is the same as the latest one are refused.
+// The valset is stored under the next valset nonce, a second request in the same block is refused.
 // -------------
 type MsgValsetRequest struct {
 	Requester sdk.AccAddress `json:"requester"`
@@ -101,22 +99,27 @@ func (msg MsgValsetRequest) GetSigners() []sdk.AccAddress {
 
 // SetEthAddress
 // This is used by the validators to set the Ethereum address that represents them on the
-// Ethereum side of the bridge. They must sign their Cosmos address using the Ethereum address
-// they have submitted.
+// Ethereum side of the bridge. They must sign the EthAddressRegistrationHash of the peggy id, the
+// chain id, their Cosmos address and the nonce with the Ethereum key of the address they have
+// submitted.
+// The nonce is the number of earlier registrations of the validator. An address can only ever be
+// registered by one validator.
 // Like ValsetResponse this message can in theory be submitted by anyone, but only the current
 // validator sets submissions carry any weight.
 // -------------
 type MsgSetEthAddress struct {
 	// the ethereum address
-	Address   string         `json:"address"`
+	Address   EthAddress     `json:"address"`
 	Validator sdk.AccAddress `json:"validator"`
+	Nonce     uint64         `json:"nonce"`
 	Signature string         `json:"signature"`
 }
 
-func NewMsgSetEthAddress(address string, validator sdk.AccAddress, signature string) MsgSetEthAddress {
+func NewMsgSetEthAddress(address EthAddress, validator sdk.AccAddress, nonce uint64, signature string) MsgSetEthAddress {
 	return MsgSetEthAddress{
 		Address:   address,
 		Validator: validator,
+		Nonce:     nonce,
 		Signature: signature,
 	}
 }
@@ -128,65 +131,391 @@ func (msg MsgSetEthAddress) Route() string { return RouterKey }
 func (msg MsgSetEthAddress) Type() string { return "set_eth_address" }
 
 // ValidateBasic runs stateless checks on the message
-// Checks if the Eth address is valid, and whether the Eth address has signed the validator address
-// (proving control of the Eth address)
+// Checks if the Eth address and the signature are well formed, the signature is bound to the chain
+// id and the registration nonce and is verified by the handler
 func (msg MsgSetEthAddress) ValidateBasic() error {
+	return validateEthAddressRegistration(msg.Validator, msg.Address, msg.Signature)
+}
+
+func validateEthAddressRegistration(validator sdk.AccAddress, address EthAddress, signature string) error {
+	if validator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, validator.String())
+	}
+	if err := address.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "This is not a valid Ethereum address")
+	}
+	return validateEthSignature(signature)
+}
+
+// validateEthSignature checks that the signature is the hex encoding of a 65 byte Ethereum signature
+func validateEthSignature(signature string) error {
+	sigBytes, hexErr := hex.DecodeString(signature)
+	if hexErr != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("Could not decode hex string %s", signature))
+	}
+	if len(sigBytes) != 65 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("signature length %d", len(sigBytes)))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetEthAddress) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetEthAddress) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Validator}
+}
+
+// MsgRotateEthAddress
+// This is used by a validator that registered an Ethereum address to replace it with a new one. The
+// new address is signed for like with MsgSetEthAddress and shares its registration nonce. It does
+// not replace the current address right away but takes effect with the next valset request, until
+// that valset is observed on Ethereum confirms signed with either key are accepted. A rotation that
+// is still pending is replaced by a later one.
+// -------------
+type MsgRotateEthAddress struct {
+	// the new ethereum address
+	Address   EthAddress     `json:"address"`
+	Validator sdk.AccAddress `json:"validator"`
+	Nonce     uint64         `json:"nonce"`
+	Signature string         `json:"signature"`
+}
+
+func NewMsgRotateEthAddress(address EthAddress, validator sdk.AccAddress, nonce uint64, signature string) MsgRotateEthAddress {
+	return MsgRotateEthAddress{
+		Address:   address,
+		Validator: validator,
+		Nonce:     nonce,
+		Signature: signature,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRotateEthAddress) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRotateEthAddress) Type() string { return "rotate_eth_address" }
+
+// ValidateBasic runs the same stateless checks as for MsgSetEthAddress
+func (msg MsgRotateEthAddress) ValidateBasic() error {
+	return validateEthAddressRegistration(msg.Validator, msg.Address, msg.Signature)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRotateEthAddress) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRotateEthAddress) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Validator}
+}
+
+// MsgValsetObserved
+// this message is the oracle attestation of a validator that the valset with the nonce was applied
+// to the Peggy contract on Ethereum. When validators holding more than 66% of the bonded power have
+// attested it the valset counts as observed, the eth keys rotated out before it are no longer
+// accepted for confirms from then on.
+// -------------
+type MsgValsetObserved struct {
+	Nonce     int64          `json:"nonce"`
+	Validator sdk.AccAddress `json:"validator"`
+}
+
+func NewMsgValsetObserved(nonce int64, validator sdk.AccAddress) MsgValsetObserved {
+	return MsgValsetObserved{
+		Nonce:     nonce,
+		Validator: validator,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgValsetObserved) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgValsetObserved) Type() string { return "valset_observed" }
+
+func (msg MsgValsetObserved) ValidateBasic() error {
 	if msg.Validator.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
 	}
-	valdiateEthAddr := regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
+	if msg.Nonce <= 0 {
+		return sdkerrors.Wrap(ErrInvalid, "nonce")
+	}
+	return nil
+}
 
-	if !valdiateEthAddr.MatchString(msg.Address) {
-		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "This is not a valid Ethereum address")
+// GetSignBytes encodes the message for signing
+func (msg MsgValsetObserved) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgValsetObserved) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Validator}
+}
+
+// MsgEthBlockObserved
+// this message is the oracle attestation of a validator to the latest Ethereum block it considers
+// final, EthBlockDelay blocks below the head it sees. The power weighted median of the attestations
+// of the bonded validators is the chain's view of Ethereum, see Keeper.GetEthBlock.
+// -------------
+type MsgEthBlockObserved struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+	// Timestamp is the time of the block in unix seconds
+	Timestamp uint64         `json:"timestamp"`
+	Validator sdk.AccAddress `json:"validator"`
+}
+
+func NewMsgEthBlockObserved(number uint64, hash string, timestamp uint64, validator sdk.AccAddress) MsgEthBlockObserved {
+	return MsgEthBlockObserved{
+		Number:    number,
+		Hash:      hash,
+		Timestamp: timestamp,
+		Validator: validator,
 	}
-	sigBytes, hexErr := hex.DecodeString(msg.Signature)
-	if hexErr != nil {
-		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("Could not decode hex string %s", msg.Signature))
+}
+
+// Route should return the name of the module
+func (msg MsgEthBlockObserved) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgEthBlockObserved) Type() string { return "eth_block_observed" }
+
+func (msg MsgEthBlockObserved) ValidateBasic() error {
+	if msg.Validator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
+	}
+	if msg.Number == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "block number")
+	}
+	if bz, err := hexutil.Decode(msg.Hash); err != nil || len(bz) != common.HashLength {
+		return sdkerrors.Wrapf(ErrInvalid, "block hash %q", msg.Hash)
 	}
+	if msg.Timestamp == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "block timestamp")
+	}
+	return nil
+}
 
-	err := utils.ValidateEthSig(crypto.Keccak256(msg.Validator.Bytes()), sigBytes, msg.Address)
+// GetSignBytes encodes the message for signing
+func (msg MsgEthBlockObserved) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgEthBlockObserved) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Validator}
+}
 
-	if err != nil {
-		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("digest: %x sig: %x address %s error: %s", crypto.Keccak256(msg.Validator.Bytes()), msg.Signature, msg.Address, err.Error()))
+// MsgGasPriceObserved
+// this message is the oracle attestation of a validator to the gas price on Ethereum. The power
+// weighted median of the attestations of the bonded validators is the gas price batches are built
+// with, see Keeper.GetGasPrice.
+// -------------
+type MsgGasPriceObserved struct {
+	// GasPrice is in wei per gas
+	GasPrice  sdk.Int        `json:"gas_price"`
+	Validator sdk.AccAddress `json:"validator"`
+}
+
+func NewMsgGasPriceObserved(gasPrice sdk.Int, validator sdk.AccAddress) MsgGasPriceObserved {
+	return MsgGasPriceObserved{
+		GasPrice:  gasPrice,
+		Validator: validator,
 	}
+}
 
+// Route should return the name of the module
+func (msg MsgGasPriceObserved) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgGasPriceObserved) Type() string { return "gas_price_observed" }
+
+func (msg MsgGasPriceObserved) ValidateBasic() error {
+	if msg.Validator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
+	}
+	if msg.GasPrice == (sdk.Int{}) || !msg.GasPrice.IsPositive() {
+		return sdkerrors.Wrap(ErrInvalid, "gas price")
+	}
 	return nil
 }
 
 // GetSignBytes encodes the message for signing
-func (msg MsgSetEthAddress) GetSignBytes() []byte {
+func (msg MsgGasPriceObserved) GetSignBytes() []byte {
 	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
 }
 
 // GetSigners defines whose signature is required
-func (msg MsgSetEthAddress) GetSigners() []sdk.AccAddress {
+func (msg MsgGasPriceObserved) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Validator}
+}
+
+// MsgERC20Deployed
+// this message is the attestation of a validator that the ERC20 representing a native Cosmos denom
+// was deployed on Ethereum with the metadata. Once validators holding a quorum of the power
+// attested the same deployment it is recorded as the CosmosERC20 of the denom.
+// -------------
+type MsgERC20Deployed struct {
+	Denom         string         `json:"denom"`
+	TokenContract EthAddress     `json:"token_contract"`
+	Metadata      ERC20Metadata  `json:"metadata"`
+	Validator     sdk.AccAddress `json:"validator"`
+}
+
+func NewMsgERC20Deployed(denom string, tokenContract EthAddress, metadata ERC20Metadata, validator sdk.AccAddress) MsgERC20Deployed {
+	return MsgERC20Deployed{
+		Denom:         denom,
+		TokenContract: tokenContract,
+		Metadata:      metadata,
+		Validator:     validator,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgERC20Deployed) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgERC20Deployed) Type() string { return "erc20_deployed" }
+
+func (msg MsgERC20Deployed) ValidateBasic() error {
+	if msg.Validator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
+	}
+	if err := sdk.ValidateDenom(msg.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	if err := msg.TokenContract.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "token contract")
+	}
+	return msg.Metadata.ValidateBasic()
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgERC20Deployed) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgERC20Deployed) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Validator}
+}
+
+// MsgPauseVote
+// this message is the emergency vote of a validator to pause the bridge. When validators holding
+// more than 66% of the bonded power voted the bridge is paused, resuming it takes a governance
+// proposal.
+// -------------
+type MsgPauseVote struct {
+	Validator sdk.AccAddress `json:"validator"`
+}
+
+func NewMsgPauseVote(validator sdk.AccAddress) MsgPauseVote {
+	return MsgPauseVote{
+		Validator: validator,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgPauseVote) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgPauseVote) Type() string { return "pause_vote" }
+
+func (msg MsgPauseVote) ValidateBasic() error {
+	if msg.Validator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgPauseVote) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgPauseVote) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Validator}
+}
+
+// MsgVetoTransfer
+// this message is the vote of a validator to veto a time locked transfer. When validators holding
+// more than 66% of the bonded power voted before the transfer is released it is dropped and the
+// sender is refunded.
+// -------------
+type MsgVetoTransfer struct {
+	ID        uint64         `json:"id"`
+	Validator sdk.AccAddress `json:"validator"`
+}
+
+func NewMsgVetoTransfer(id uint64, validator sdk.AccAddress) MsgVetoTransfer {
+	return MsgVetoTransfer{
+		ID:        id,
+		Validator: validator,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgVetoTransfer) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgVetoTransfer) Type() string { return "veto_transfer" }
+
+func (msg MsgVetoTransfer) ValidateBasic() error {
+	if msg.Validator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
+	}
+	if msg.ID == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "id")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgVetoTransfer) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgVetoTransfer) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Validator}
 }
 
 // MsgSendToEth
 // This is the message that a user calls when they want to bridge an asset
 // TODO right now this needs to be locked to a single ERC20
-// TODO fixed fee amounts for now, variable fee amounts in the fee field later
-// TODO actually remove amounts form the users bank balances
-// TODO this message modifies the on chain store by adding itself to a txpool
-// it will later be removed when it is included in a batch and successfully submitted
+// The bridge fee has to reach the minimum fee of the denom, it can be paid in another denom that
+// governance whitelisted for it, see FeeDenom.
+// The amount and fee are escrowed in the module account and the transfer is added to the txpool,
+// it will later be removed when it is included in a batch and successfully submitted. Transfers
+// above the time lock threshold of their denom wait in a delay queue first, see TimeLockedTransfer.
+// Transfers from or to an address on the Blocklist are rejected. The result data is the ID of
+// the TransferStatus that tracks the transfer. Native Cosmos denoms can be sent once their ERC20 was deployed, see CosmosERC20,
+// batching locks them in the module account.
 // tokens are removed from the users balance immediately
 // -------------
 type MsgSendToEth struct {
 	// the source address on Cosmos
 	Sender sdk.AccAddress `json:"sender"`
 	// the destination address on Ethereum
-	DestAddress string `json:"dest_address"`
+	DestAddress EthAddress `json:"dest_address"`
 	// the coin to send across the bridge, note the restriction that this is a
 	// single coin not a set of coins that is normal in other Cosmos messages
 	Send sdk.Coin `json:"send"`
 	// the fee paid for the bridge, distinct from the fee paid to the chain to
 	// actually send this message in the first place. So a successful send has
-	// two layers of fees for the user
+	// two layers of fees for the user. It is in the denom of Send or in a
+	// FeeDenom whitelisted for it.
 	BridgeFee sdk.Coin `json:"bridge_fee"`
 }
 
-func NewMsgSendToEth(sender sdk.AccAddress, destAddress string, send sdk.Coin, bridgeFee sdk.Coin) MsgSendToEth {
+func NewMsgSendToEth(sender sdk.AccAddress, destAddress EthAddress, send sdk.Coin, bridgeFee sdk.Coin) MsgSendToEth {
 	return MsgSendToEth{
 		Sender:      sender,
 		DestAddress: destAddress,
@@ -204,13 +533,20 @@ func (msg MsgSendToEth) Type() string { return "send_to_eth" }
 // ValidateBasic runs stateless checks on the message
 // Checks if the Eth address is valid
 func (msg MsgSendToEth) ValidateBasic() error {
-	// fee and send must be of the same denom
-	if msg.Send.Denom != msg.BridgeFee.Denom {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, fmt.Sprintf("Fee and Send must be the same type!"))
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Sender.String())
+	}
+	if !msg.Send.IsValid() || !msg.Send.IsPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Send.String())
+	}
+	if !msg.BridgeFee.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.BridgeFee.String())
+	}
+	if err := msg.DestAddress.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "This is not a valid Ethereum address")
 	}
-	// TODO validate eth address
 	// TODO for demo get single allowed demon from the store
-	// TODO validate fee is sufficient, fixed fee to start
+	// the fee denom and the minimum fee are checked against the params in the handler
 
 	return nil
 }
@@ -238,9 +574,10 @@ type MsgRequestBatch struct {
 	Denom     string         `json:"denom"`
 }
 
-func NewMsgRequestBatch(requester sdk.AccAddress) MsgRequestBatch {
+func NewMsgRequestBatch(requester sdk.AccAddress, denom string) MsgRequestBatch {
 	return MsgRequestBatch{
 		Requester: requester,
+		Denom:     denom,
 	}
 }
 
@@ -251,6 +588,9 @@ func (msg MsgRequestBatch) Route() string { return RouterKey }
 func (msg MsgRequestBatch) Type() string { return "request_batch" }
 
 func (msg MsgRequestBatch) ValidateBasic() error {
+	if err := sdk.ValidateDenom(msg.Denom); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
 	// TODO ensure that Demon matches hardcoded allowed value
 	// TODO later make sure that Demon matches a list of tokens already
 	// in the bridge to send
@@ -273,18 +613,24 @@ func (msg MsgRequestBatch) GetSigners() []sdk.AccAddress {
 // hardcoded maximum size (to be decided, probably around 100) or when transactions stop being
 // profitable (TODO determine this without nondeterminism)
 // This message includes the batch as well as an Ethereum signature over this batch by the validator
+//
+// When ValsetNonce is set the signature is not over the batch alone but over the batch combined
+// with the checkpoint of the valset with that nonce, so that a relayer can submit both in a single
+// `updateValsetAndSubmitBatch` call.
 // -------------
 type MsgConfirmBatch struct {
-	Nonce     int64          `json:"nonce"`
-	Validator sdk.AccAddress `json:"validator"`
-	Signature string         `json:"signature"`
+	Nonce       int64          `json:"nonce"`
+	ValsetNonce int64          `json:"valset_nonce"`
+	Validator   sdk.AccAddress `json:"validator"`
+	Signature   string         `json:"signature"`
 }
 
-func NewMsgConfirmBatch(nonce int64, validator sdk.AccAddress, signature string) MsgConfirmBatch {
+func NewMsgConfirmBatch(nonce int64, valsetNonce int64, validator sdk.AccAddress, signature string) MsgConfirmBatch {
 	return MsgConfirmBatch{
-		Nonce:     nonce,
-		Validator: validator,
-		Signature: signature,
+		Nonce:       nonce,
+		ValsetNonce: valsetNonce,
+		Validator:   validator,
+		Signature:   signature,
 	}
 }
 
@@ -295,10 +641,10 @@ func (msg MsgConfirmBatch) Route() string { return RouterKey }
 func (msg MsgConfirmBatch) Type() string { return "confirm_batch" }
 
 func (msg MsgConfirmBatch) ValidateBasic() error {
-	// TODO validate signature
-	// TODO get batch from storage
-	// TODO generate batch in storage on MsgRequestBatch in the first place
-	return nil
+	if msg.Validator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
+	}
+	return validateEthSignature(msg.Signature)
 }
 
 // GetSignBytes encodes the message for signing
@@ -365,13 +711,18 @@ type MsgEthDeposit struct {
 	Validator   sdk.AccAddress `json:"validator"`
 	Destination sdk.AccAddress `json:"Destination"`
 	Amount      sdk.Coin       `json:"Amount"`
+	// EthTxHash and LogIndex identify the TransferOutEvent of the deposit on Ethereum
+	EthTxHash string `json:"eth_tx_hash"`
+	LogIndex  uint64 `json:"log_index"`
 }
 
-func NewMsgEthDeposit(validator sdk.AccAddress, destination sdk.AccAddress, amount sdk.Coin) MsgEthDeposit {
+func NewMsgEthDeposit(validator sdk.AccAddress, destination sdk.AccAddress, amount sdk.Coin, ethTxHash string, logIndex uint64) MsgEthDeposit {
 	return MsgEthDeposit{
 		Validator:   validator,
 		Destination: destination,
 		Amount:      amount,
+		EthTxHash:   ethTxHash,
+		LogIndex:    logIndex,
 	}
 }
 
@@ -382,6 +733,18 @@ func (msg MsgEthDeposit) Route() string { return RouterKey }
 func (msg MsgEthDeposit) Type() string { return "eth_deposit" }
 
 func (msg MsgEthDeposit) ValidateBasic() error {
+	if msg.Validator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator.String())
+	}
+	if msg.Destination.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Destination.String())
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+	}
+	if bz, err := hexutil.Decode(msg.EthTxHash); err != nil || len(bz) != common.HashLength {
+		return sdkerrors.Wrapf(ErrInvalid, "eth tx hash %q", msg.EthTxHash)
+	}
 	// TODO ensure that this is an allowed demon for september goal
 	// TODO slashing conditions for false deposit attestation eventually
 	return nil
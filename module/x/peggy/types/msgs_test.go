@@ -0,0 +1,40 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmSignatureValidateBasic(t *testing.T) {
+	validator := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	specs := map[string]struct {
+		signature string
+		expErr    bool
+	}{
+		"65 bytes":  {signature: strings.Repeat("1b", 65)},
+		"64 bytes":  {signature: strings.Repeat("1b", 64), expErr: true},
+		"66 bytes":  {signature: strings.Repeat("1b", 66), expErr: true},
+		"empty":     {signature: "", expErr: true},
+		"not hex":   {signature: strings.Repeat("zz", 65), expErr: true},
+		"0x prefix": {signature: "0x" + strings.Repeat("1b", 65), expErr: true},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			for _, m := range []sdk.Msg{
+				NewMsgConfirmBatch(1, 0, validator, spec.signature),
+				NewMsgValsetConfirm(1, validator, spec.signature),
+			} {
+				err := m.ValidateBasic()
+				if spec.expErr {
+					assert.Error(t, err, m.Type())
+				} else {
+					assert.NoError(t, err, m.Type())
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/params/subspace"
 )
@@ -19,6 +20,35 @@ var (
 	KeyPeggyID      = []byte("PeggyID")
 	KeyContractHash = []byte("ContractHash")
 	KeyStartBlock   = []byte("StartBlock")
+
+	KeyValsetRequestPolicy     = []byte("ValsetRequestPolicy")
+	KeyValsetRequestMinBlocks  = []byte("ValsetRequestMinBlocks")
+	KeyValsetRequestFee        = []byte("ValsetRequestFee")
+	KeyValsetRetentionBlocks   = []byte("ValsetRetentionBlocks")
+	KeyRateLimits              = []byte("RateLimits")
+	KeyTimeLockThresholds      = []byte("TimeLockThresholds")
+	KeyTimeLockBlocks          = []byte("TimeLockBlocks")
+	KeyMinBridgeFees           = []byte("MinBridgeFees")
+	KeyFeeDenoms               = []byte("FeeDenoms")
+	KeyBatchBaseGas            = []byte("BatchBaseGas")
+	KeyBatchGasPerTransfer     = []byte("BatchGasPerTransfer")
+	KeyEthValues               = []byte("EthValues")
+	KeyAttestationExpiryBlocks = []byte("AttestationExpiryBlocks")
+	KeyNativeTokens            = []byte("NativeTokens")
+	KeyTokenDecimals           = []byte("TokenDecimals")
+)
+
+// Every valset request makes all validators sign and store a valset, governance chooses one of
+// these policies to limit them
+const (
+	// ValsetRequestPolicyValidators accepts requests from the accounts of bonded validators only,
+	// their orchestrators send with these accounts
+	ValsetRequestPolicyValidators = "validators"
+	// ValsetRequestPolicyMinBlocks accepts requests from anyone once ValsetRequestMinBlocks blocks
+	// passed since the last valset request
+	ValsetRequestPolicyMinBlocks = "min_blocks"
+	// ValsetRequestPolicyFee accepts requests from anyone paying ValsetRequestFee, it is burned
+	ValsetRequestPolicyFee = "fee"
 )
 
 var _ subspace.ParamSet = &Params{}
@@ -27,6 +57,37 @@ type Params struct {
 	PeggyID      []byte `json:"peggy_id" yaml:"peggy_id"`
 	ContractHash []byte `json:"contract_source_hash" yaml:"contract_source_hash"`
 	StartBlock   uint64 `json:"start_block" yaml:"start_block"`
+
+	ValsetRequestPolicy    string    `json:"valset_request_policy" yaml:"valset_request_policy"`
+	ValsetRequestMinBlocks uint64    `json:"valset_request_min_blocks" yaml:"valset_request_min_blocks"`
+	ValsetRequestFee       sdk.Coins `json:"valset_request_fee" yaml:"valset_request_fee"`
+	// ValsetRetentionBlocks is the number of blocks a valset request is kept when it is not
+	// superseded by an observed valset, zero keeps them until then
+	ValsetRetentionBlocks uint64 `json:"valset_retention_blocks" yaml:"valset_retention_blocks"`
+	// RateLimits are the caps per denom, at most one per denom
+	RateLimits []RateLimit `json:"rate_limits" yaml:"rate_limits"`
+	// TimeLockThresholds are the amounts per denom from which on transfers to Ethereum wait
+	// TimeLockBlocks blocks in the delay queue, at most one per denom
+	TimeLockThresholds []TimeLockThreshold `json:"time_lock_thresholds" yaml:"time_lock_thresholds"`
+	TimeLockBlocks     uint64              `json:"time_lock_blocks" yaml:"time_lock_blocks"`
+	// MinBridgeFees are the lowest bridge fees per denom, at most one per denom
+	MinBridgeFees []MinBridgeFee `json:"min_bridge_fees" yaml:"min_bridge_fees"`
+	// FeeDenoms are the other denoms bridge fees can be paid in, at most one per pair of denoms
+	FeeDenoms []FeeDenom `json:"fee_denoms" yaml:"fee_denoms"`
+	// BatchBaseGas and BatchGasPerTransfer model the gas of submitBatch, see Params.BatchGasCost
+	BatchBaseGas        uint64 `json:"batch_base_gas" yaml:"batch_base_gas"`
+	BatchGasPerTransfer uint64 `json:"batch_gas_per_transfer" yaml:"batch_gas_per_transfer"`
+	// EthValues are the values of denoms in wei, at most one per denom
+	EthValues []EthValue `json:"eth_values" yaml:"eth_values"`
+	// AttestationExpiryBlocks is the age in blocks from which on the Ethereum block and gas price
+	// attestations of a validator no longer count towards the chain's view
+	AttestationExpiryBlocks uint64 `json:"attestation_expiry_blocks" yaml:"attestation_expiry_blocks"`
+	// NativeTokens are the Cosmos denoms that can be bridged to Ethereum once their ERC20 was
+	// deployed, at most one per denom
+	NativeTokens []NativeToken `json:"native_tokens" yaml:"native_tokens"`
+	// TokenDecimals are the decimals of denoms whose ERC20 counts in other units, at most one per
+	// denom
+	TokenDecimals []TokenDecimals `json:"token_decimals" yaml:"token_decimals"`
 }
 
 // NewParams creates a new Params object
@@ -38,6 +99,45 @@ func NewParams(peggyID []byte, contractHash []byte, startBlock uint64) Params {
 	}
 }
 
+// DefaultPeggyID is the PeggyID of a new chain, the contract has to be deployed with the same id
+var DefaultPeggyID = []byte("peggy")
+
+// LegacyPeggyID is the id the checkpoints were signed with before they used the PeggyID param
+var LegacyPeggyID = []byte("foo")
+
+// DefaultValsetRetentionBlocks keeps unobserved valset requests for about two weeks of 5s blocks
+const DefaultValsetRetentionBlocks uint64 = 250000
+
+// DefaultTimeLockBlocks delays large transfers for about four days of 5s blocks, so that a veto
+// proposal fits into the default two day deposit period and two day voting period of x/gov
+const DefaultTimeLockBlocks uint64 = 69120
+
+// DefaultAttestationExpiryBlocks ignores Ethereum block and gas price attestations after about an
+// hour of 5s blocks
+const DefaultAttestationExpiryBlocks uint64 = 720
+
+// DefaultParams returns the params of a new chain, valset requests are limited to validators
+func DefaultParams() Params {
+	return Params{
+		PeggyID:                 DefaultPeggyID,
+		ValsetRequestPolicy:     ValsetRequestPolicyValidators,
+		ValsetRequestMinBlocks:  100,
+		ValsetRequestFee:        sdk.Coins{},
+		ValsetRetentionBlocks:   DefaultValsetRetentionBlocks,
+		RateLimits:              []RateLimit{},
+		TimeLockThresholds:      []TimeLockThreshold{},
+		TimeLockBlocks:          DefaultTimeLockBlocks,
+		MinBridgeFees:           []MinBridgeFee{},
+		FeeDenoms:               []FeeDenom{},
+		BatchBaseGas:            DefaultBatchBaseGas,
+		BatchGasPerTransfer:     DefaultBatchGasPerTransfer,
+		EthValues:               []EthValue{},
+		AttestationExpiryBlocks: DefaultAttestationExpiryBlocks,
+		NativeTokens:            []NativeToken{},
+		TokenDecimals:           []TokenDecimals{},
+	}
+}
+
 // ParamKeyTable for auth module
 func ParamKeyTable() subspace.KeyTable {
 	return subspace.NewKeyTable().RegisterParamSet(&Params{})
@@ -51,6 +151,21 @@ func (p *Params) ParamSetPairs() subspace.ParamSetPairs {
 		params.NewParamSetPair(KeyPeggyID, &p.PeggyID, validatePeggyID),
 		params.NewParamSetPair(KeyContractHash, &p.ContractHash, validateContractHash),
 		params.NewParamSetPair(KeyStartBlock, &p.StartBlock, validateStartBlock),
+		params.NewParamSetPair(KeyValsetRequestPolicy, &p.ValsetRequestPolicy, validateValsetRequestPolicy),
+		params.NewParamSetPair(KeyValsetRequestMinBlocks, &p.ValsetRequestMinBlocks, validateValsetRequestMinBlocks),
+		params.NewParamSetPair(KeyValsetRequestFee, &p.ValsetRequestFee, validateValsetRequestFee),
+		params.NewParamSetPair(KeyValsetRetentionBlocks, &p.ValsetRetentionBlocks, validateValsetRetentionBlocks),
+		params.NewParamSetPair(KeyRateLimits, &p.RateLimits, validateRateLimits),
+		params.NewParamSetPair(KeyTimeLockThresholds, &p.TimeLockThresholds, validateTimeLockThresholds),
+		params.NewParamSetPair(KeyTimeLockBlocks, &p.TimeLockBlocks, validateTimeLockBlocks),
+		params.NewParamSetPair(KeyMinBridgeFees, &p.MinBridgeFees, validateMinBridgeFees),
+		params.NewParamSetPair(KeyFeeDenoms, &p.FeeDenoms, validateFeeDenoms),
+		params.NewParamSetPair(KeyBatchBaseGas, &p.BatchBaseGas, validateBatchGas),
+		params.NewParamSetPair(KeyBatchGasPerTransfer, &p.BatchGasPerTransfer, validateBatchGas),
+		params.NewParamSetPair(KeyEthValues, &p.EthValues, validateEthValues),
+		params.NewParamSetPair(KeyAttestationExpiryBlocks, &p.AttestationExpiryBlocks, validateAttestationExpiryBlocks),
+		params.NewParamSetPair(KeyNativeTokens, &p.NativeTokens, validateNativeTokens),
+		params.NewParamSetPair(KeyTokenDecimals, &p.TokenDecimals, validateTokenDecimals),
 	}
 }
 
@@ -68,14 +183,37 @@ func (p Params) String() string {
 	sb.WriteString(fmt.Sprintf("PeggyID: %d\n", p.PeggyID))
 	sb.WriteString(fmt.Sprintf("ContractHash: %d\n", p.ContractHash))
 	sb.WriteString(fmt.Sprintf("StartBlock: %d\n", p.StartBlock))
+	sb.WriteString(fmt.Sprintf("ValsetRequestPolicy: %s\n", p.ValsetRequestPolicy))
+	sb.WriteString(fmt.Sprintf("ValsetRequestMinBlocks: %d\n", p.ValsetRequestMinBlocks))
+	sb.WriteString(fmt.Sprintf("ValsetRequestFee: %s\n", p.ValsetRequestFee))
+	sb.WriteString(fmt.Sprintf("ValsetRetentionBlocks: %d\n", p.ValsetRetentionBlocks))
+	sb.WriteString(fmt.Sprintf("RateLimits: %v\n", p.RateLimits))
+	sb.WriteString(fmt.Sprintf("TimeLockThresholds: %v\n", p.TimeLockThresholds))
+	sb.WriteString(fmt.Sprintf("TimeLockBlocks: %d\n", p.TimeLockBlocks))
+	sb.WriteString(fmt.Sprintf("MinBridgeFees: %v\n", p.MinBridgeFees))
+	sb.WriteString(fmt.Sprintf("FeeDenoms: %v\n", p.FeeDenoms))
+	sb.WriteString(fmt.Sprintf("BatchBaseGas: %d\n", p.BatchBaseGas))
+	sb.WriteString(fmt.Sprintf("BatchGasPerTransfer: %d\n", p.BatchGasPerTransfer))
+	sb.WriteString(fmt.Sprintf("EthValues: %v\n", p.EthValues))
+	sb.WriteString(fmt.Sprintf("AttestationExpiryBlocks: %d\n", p.AttestationExpiryBlocks))
+	sb.WriteString(fmt.Sprintf("NativeTokens: %v\n", p.NativeTokens))
+	sb.WriteString(fmt.Sprintf("TokenDecimals: %v\n", p.TokenDecimals))
 	return sb.String()
 }
 
 func validatePeggyID(i interface{}) error {
-	_, ok := i.([]byte)
+	v, ok := i.([]byte)
 	if !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
+	// the id separates the signatures for this bridge from those for any other, the contract
+	// takes it as bytes32
+	if len(v) == 0 {
+		return fmt.Errorf("peggy id cannot be empty")
+	}
+	if len(v) > 32 {
+		return fmt.Errorf("peggy id longer than 32 bytes: %d", len(v))
+	}
 
 	return nil
 }
@@ -98,6 +236,292 @@ func validateStartBlock(i interface{}) error {
 	return nil
 }
 
+func validateValsetRequestPolicy(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	switch v {
+	case ValsetRequestPolicyValidators, ValsetRequestPolicyMinBlocks, ValsetRequestPolicyFee:
+		return nil
+	default:
+		return fmt.Errorf("unknown valset request policy: %q", v)
+	}
+}
+
+func validateValsetRequestMinBlocks(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateValsetRequestFee(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if !v.IsValid() {
+		return fmt.Errorf("invalid valset request fee: %s", v)
+	}
+
+	return nil
+}
+
+func validateValsetRetentionBlocks(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateRateLimits(i interface{}) error {
+	v, ok := i.([]RateLimit)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(v))
+	for _, l := range v {
+		if err := l.ValidateBasic(); err != nil {
+			return err
+		}
+		if seen[l.Denom] {
+			return fmt.Errorf("duplicate rate limit for %s", l.Denom)
+		}
+		seen[l.Denom] = true
+	}
+
+	return nil
+}
+
+func validateTimeLockThresholds(i interface{}) error {
+	v, ok := i.([]TimeLockThreshold)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(v))
+	for _, t := range v {
+		if err := t.ValidateBasic(); err != nil {
+			return err
+		}
+		if seen[t.Denom] {
+			return fmt.Errorf("duplicate time lock threshold for %s", t.Denom)
+		}
+		seen[t.Denom] = true
+	}
+
+	return nil
+}
+
+// validateTimeLockBlocks only checks the type, the gov params are not known here. The delay should
+// cover the deposit and voting periods of x/gov in blocks, transfers that are released before a
+// TransferVetoProposal passes can only be vetoed by a quorum of validators.
+func validateTimeLockBlocks(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateMinBridgeFees(i interface{}) error {
+	v, ok := i.([]MinBridgeFee)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(v))
+	for _, m := range v {
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+		if seen[m.Denom] {
+			return fmt.Errorf("duplicate min bridge fee for %s", m.Denom)
+		}
+		seen[m.Denom] = true
+	}
+
+	return nil
+}
+
+func validateFeeDenoms(i interface{}) error {
+	v, ok := i.([]FeeDenom)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[[2]string]bool, len(v))
+	for _, f := range v {
+		if err := f.ValidateBasic(); err != nil {
+			return err
+		}
+		pair := [2]string{f.Denom, f.TransferDenom}
+		if seen[pair] {
+			return fmt.Errorf("duplicate fee denom %s for %s", f.Denom, f.TransferDenom)
+		}
+		seen[pair] = true
+	}
+
+	return nil
+}
+
+func validateBatchGas(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateEthValues(i interface{}) error {
+	v, ok := i.([]EthValue)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(v))
+	for _, e := range v {
+		if err := e.ValidateBasic(); err != nil {
+			return err
+		}
+		if seen[e.Denom] {
+			return fmt.Errorf("duplicate eth value for %s", e.Denom)
+		}
+		seen[e.Denom] = true
+	}
+
+	return nil
+}
+
+func validateAttestationExpiryBlocks(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v == 0 {
+		return fmt.Errorf("attestation expiry must be positive")
+	}
+
+	return nil
+}
+
+func validateNativeTokens(i interface{}) error {
+	v, ok := i.([]NativeToken)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(v))
+	for _, t := range v {
+		if err := t.ValidateBasic(); err != nil {
+			return err
+		}
+		if seen[t.Denom] {
+			return fmt.Errorf("duplicate native token %s", t.Denom)
+		}
+		seen[t.Denom] = true
+	}
+
+	return nil
+}
+
+func validateTokenDecimals(i interface{}) error {
+	v, ok := i.([]TokenDecimals)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(v))
+	for _, d := range v {
+		if err := d.ValidateBasic(); err != nil {
+			return err
+		}
+		if seen[d.Denom] {
+			return fmt.Errorf("duplicate token decimals for %s", d.Denom)
+		}
+		seen[d.Denom] = true
+	}
+
+	return nil
+}
+
+// GetMinBridgeFee returns the lowest bridge fee of transfers of the denom, zero without a minimum
+func (p Params) GetMinBridgeFee(denom string) sdk.Int {
+	for _, m := range p.MinBridgeFees {
+		if m.Denom == denom {
+			return m.MinFee
+		}
+	}
+	return sdk.ZeroInt()
+}
+
+// BridgeFeeValue returns what the fee of a transfer of the denom counts as in that denom. It
+// returns false when the fee is in another denom that is not whitelisted for it.
+func (p Params) BridgeFeeValue(denom string, fee sdk.Coin) (sdk.Int, bool) {
+	if fee.Denom == denom {
+		return fee.Amount, true
+	}
+	for _, f := range p.FeeDenoms {
+		if f.Denom == fee.Denom && f.TransferDenom == denom {
+			return f.Rate.MulInt(fee.Amount).TruncateInt(), true
+		}
+	}
+	return sdk.Int{}, false
+}
+
+// GetEthValue returns the value of one unit of the denom in wei
+func (p Params) GetEthValue(denom string) (sdk.Dec, bool) {
+	for _, v := range p.EthValues {
+		if v.Denom == denom {
+			return v.WeiPerUnit, true
+		}
+	}
+	return sdk.Dec{}, false
+}
+
+// GetNativeToken returns the native token of the denom, ok is false for denoms that originate on
+// Ethereum
+func (p Params) GetNativeToken(denom string) (NativeToken, bool) {
+	for _, t := range p.NativeTokens {
+		if t.Denom == denom {
+			return t, true
+		}
+	}
+	return NativeToken{}, false
+}
+
+// GetTokenDecimals returns the decimals of the denom and its ERC20, the same decimals on both
+// sides when governance set none
+func (p Params) GetTokenDecimals(denom string) TokenDecimals {
+	for _, d := range p.TokenDecimals {
+		if d.Denom == denom {
+			return d
+		}
+	}
+	return TokenDecimals{Denom: denom}
+}
+
+// GetTimeLockThreshold returns the time lock threshold of the denom
+func (p Params) GetTimeLockThreshold(denom string) (sdk.Int, bool) {
+	for _, t := range p.TimeLockThresholds {
+		if t.Denom == denom {
+			return t.Threshold, true
+		}
+	}
+	return sdk.Int{}, false
+}
+
+// GetRateLimit returns the rate limit of the denom
+func (p Params) GetRateLimit(denom string) (RateLimit, bool) {
+	for _, l := range p.RateLimits {
+		if l.Denom == denom {
+			return l, true
+		}
+	}
+	return RateLimit{}, false
+}
+
 // Validate checks that the parameters have valid values.
 func (p Params) Validate() error {
 	if err := validatePeggyID(p.PeggyID); err != nil {
@@ -109,6 +533,57 @@ func (p Params) Validate() error {
 	if err := validateStartBlock(p.StartBlock); err != nil {
 		return err
 	}
+	if err := validateValsetRequestPolicy(p.ValsetRequestPolicy); err != nil {
+		return err
+	}
+	if err := validateValsetRequestMinBlocks(p.ValsetRequestMinBlocks); err != nil {
+		return err
+	}
+	if err := validateValsetRequestFee(p.ValsetRequestFee); err != nil {
+		return err
+	}
+	if err := validateValsetRetentionBlocks(p.ValsetRetentionBlocks); err != nil {
+		return err
+	}
+	if err := validateRateLimits(p.RateLimits); err != nil {
+		return err
+	}
+	if err := validateTimeLockThresholds(p.TimeLockThresholds); err != nil {
+		return err
+	}
+	if err := validateTimeLockBlocks(p.TimeLockBlocks); err != nil {
+		return err
+	}
+	if err := validateMinBridgeFees(p.MinBridgeFees); err != nil {
+		return err
+	}
+	if err := validateFeeDenoms(p.FeeDenoms); err != nil {
+		return err
+	}
+	if err := validateBatchGas(p.BatchBaseGas); err != nil {
+		return err
+	}
+	if err := validateBatchGas(p.BatchGasPerTransfer); err != nil {
+		return err
+	}
+	if err := validateEthValues(p.EthValues); err != nil {
+		return err
+	}
+	if err := validateAttestationExpiryBlocks(p.AttestationExpiryBlocks); err != nil {
+		return err
+	}
+	if err := validateNativeTokens(p.NativeTokens); err != nil {
+		return err
+	}
+	if err := validateTokenDecimals(p.TokenDecimals); err != nil {
+		return err
+	}
+	// the ERC20 of a native token counts in the decimals of its metadata
+	for _, d := range p.TokenDecimals {
+		if t, ok := p.GetNativeToken(d.Denom); ok && d.ERC20Decimals != t.Metadata.Decimals {
+			return fmt.Errorf("erc20 decimals %d of %s differ from its metadata", d.ERC20Decimals, d.Denom)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// ProposalTypeBridgePause is the gov proposal type that pauses or resumes the bridge
+const ProposalTypeBridgePause = "BridgePause"
+
+// Sources of a pause
+const (
+	PauseSourceGovernance = "governance"
+	PauseSourceValidators = "validators"
+)
+
+// PauseState is the circuit breaker of the bridge. While it is paused no transfers to Ethereum
+// are accepted, no batches are built and no deposits are minted. Confirms and observations are
+// still recorded so that the bridge can resume from a consistent state.
+type PauseState struct {
+	Paused bool `json:"paused"`
+	// Height is the block height of the last change
+	Height int64 `json:"height"`
+	// Source is who made the last change, PauseSourceGovernance or PauseSourceValidators
+	Source string `json:"source,omitempty"`
+}
+
+func (p PauseState) String() string {
+	if !p.Paused {
+		return "bridge active"
+	}
+	return fmt.Sprintf("bridge paused by %s at height %d", p.Source, p.Height)
+}
+
+// BridgePauseProposal pauses or resumes the bridge through governance. It is the only way to
+// resume a bridge that validators paused.
+type BridgePauseProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	Paused      bool   `json:"paused" yaml:"paused"`
+}
+
+func NewBridgePauseProposal(title, description string, paused bool) govtypes.Content {
+	return BridgePauseProposal{Title: title, Description: description, Paused: paused}
+}
+
+var _ govtypes.Content = BridgePauseProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeBridgePause)
+	govtypes.RegisterProposalTypeCodec(BridgePauseProposal{}, "peggy/BridgePauseProposal")
+}
+
+// nolint
+func (p BridgePauseProposal) GetTitle() string       { return p.Title }
+func (p BridgePauseProposal) GetDescription() string { return p.Description }
+func (p BridgePauseProposal) ProposalRoute() string  { return RouterKey }
+func (p BridgePauseProposal) ProposalType() string   { return ProposalTypeBridgePause }
+func (p BridgePauseProposal) ValidateBasic() error {
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p BridgePauseProposal) String() string {
+	return fmt.Sprintf(`Bridge Pause Proposal:
+  Title:       %s
+  Description: %s
+  Paused:      %t
+`, p.Title, p.Description, p.Paused)
+}
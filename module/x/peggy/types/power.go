@@ -0,0 +1,64 @@
+package types
+
+import (
+	"math/big"
+	"sort"
+)
+
+// The contract compares the summed power of the signers against the fixed `state_powerThreshold`
+// it was deployed with. Valsets therefore carry powers scaled to PowerTotal, so that the threshold
+// stays the same share of the stake when the bonded tokens change.
+const (
+	// PowerTotal is the sum of the powers of every valset
+	PowerTotal int64 = 1 << 32
+	// PowerThreshold is the `state_powerThreshold` the contract has to be deployed with, 2/3 of
+	// PowerTotal. Signatures are sufficient when their power is strictly greater than it.
+	PowerThreshold int64 = PowerTotal * 2 / 3
+)
+
+// NormalizePowers scales the powers to sum up to PowerTotal. Every power is rounded down and the
+// units that are left are handed out one each to the largest remainders, ties go to the higher
+// index so that an ascending order of the input is kept for equal powers. The result only depends
+// on the input so every node computes the same valset. All powers are zero when the input sums up
+// to zero.
+func NormalizePowers(powers []int64) []int64 {
+	res := make([]int64, len(powers))
+	total := new(big.Int)
+	for _, p := range powers {
+		total.Add(total, big.NewInt(p))
+	}
+	if total.Sign() <= 0 {
+		return res
+	}
+
+	remainders := make([]*big.Int, len(powers))
+	distributed := int64(0)
+	for i, p := range powers {
+		scaled := new(big.Int).Mul(big.NewInt(p), big.NewInt(PowerTotal))
+		q, r := new(big.Int).QuoRem(scaled, total, new(big.Int))
+		res[i] = q.Int64()
+		remainders[i] = r
+		distributed += res[i]
+	}
+
+	order := make([]int, len(powers))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		if c := remainders[order[a]].Cmp(remainders[order[b]]); c != 0 {
+			return c > 0
+		}
+		return order[a] > order[b]
+	})
+	for i := int64(0); i < PowerTotal-distributed; i++ {
+		res[order[i]]++
+	}
+	return res
+}
+
+// HasQuorum returns true when the normalized power is sufficient for the contract to accept a
+// checkpoint, it is the same comparison as in `checkValidatorSignatures`
+func HasQuorum(power int64) bool {
+	return power > PowerThreshold
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePowers(t *testing.T) {
+	specs := map[string]struct {
+		src []int64
+		exp []int64
+	}{
+		"empty": {
+			src: []int64{},
+			exp: []int64{},
+		},
+		"single": {
+			src: []int64{7},
+			exp: []int64{PowerTotal},
+		},
+		"equal shares": {
+			src: []int64{100, 100},
+			exp: []int64{PowerTotal / 2, PowerTotal / 2},
+		},
+		"remainder to higher index on ties": {
+			src: []int64{1, 1, 1},
+			exp: []int64{1431655765, 1431655765, 1431655766},
+		},
+		"remainder to largest fraction": {
+			src: []int64{1, 2},
+			exp: []int64{1431655765, 2863311531},
+		},
+		"all zero": {
+			src: []int64{0, 0},
+			exp: []int64{0, 0},
+		},
+		"zero power stays zero": {
+			src: []int64{0, 5},
+			exp: []int64{0, PowerTotal},
+		},
+		"large powers": {
+			src: []int64{math.MaxInt64 / 2, math.MaxInt64 / 2},
+			exp: []int64{PowerTotal / 2, PowerTotal / 2},
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got := NormalizePowers(spec.src)
+			assert.Equal(t, spec.exp, got)
+			var sum int64
+			for _, p := range got {
+				sum += p
+			}
+			if len(spec.src) != 0 && spec.exp[len(spec.exp)-1] != 0 {
+				assert.Equal(t, PowerTotal, sum)
+			}
+		})
+	}
+}
+
+func TestHasQuorum(t *testing.T) {
+	assert.False(t, HasQuorum(PowerThreshold))
+	assert.True(t, HasQuorum(PowerThreshold+1))
+	// like the tendermint commit rule more than 2/3 are needed, three of four equal validators are
+	// sufficient, two of four are not
+	powers := NormalizePowers([]int64{10, 10, 10, 10})
+	assert.True(t, HasQuorum(powers[0]+powers[1]+powers[2]))
+	assert.False(t, HasQuorum(powers[0]+powers[1]))
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The directions a rate limit caps
+const (
+	// RateLimitOutflow is the value sent to Ethereum with MsgSendToEth, transfer and bridge fee
+	RateLimitOutflow = "outflow"
+	// RateLimitMint is the value minted for deposits from Ethereum
+	RateLimitMint = "mint"
+)
+
+// RateLimit caps the value of a denom that can leave Cosmos and that can be minted over a rolling
+// window of blocks. Requests that would exceed a cap are rejected. A zero cap does not limit.
+type RateLimit struct {
+	Denom        string  `json:"denom" yaml:"denom"`
+	WindowBlocks uint64  `json:"window_blocks" yaml:"window_blocks"`
+	MaxOutflow   sdk.Int `json:"max_outflow" yaml:"max_outflow"`
+	MaxMint      sdk.Int `json:"max_mint" yaml:"max_mint"`
+}
+
+// Max returns the cap of the direction, zero when it is not limited
+func (l RateLimit) Max(direction string) sdk.Int {
+	switch direction {
+	case RateLimitOutflow:
+		return l.MaxOutflow
+	case RateLimitMint:
+		return l.MaxMint
+	default:
+		panic(fmt.Sprintf("unknown rate limit direction %q", direction))
+	}
+}
+
+// ValidateBasic checks the denom, window and caps
+func (l RateLimit) ValidateBasic() error {
+	if err := sdk.ValidateDenom(l.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	if l.WindowBlocks == 0 {
+		return sdkerrors.Wrapf(ErrInvalid, "rate limit of %s: window blocks", l.Denom)
+	}
+	for _, max := range []sdk.Int{l.MaxOutflow, l.MaxMint} {
+		if max == (sdk.Int{}) || max.IsNegative() {
+			return sdkerrors.Wrapf(ErrInvalid, "rate limit of %s: cap", l.Denom)
+		}
+	}
+	return nil
+}
+
+func (l RateLimit) String() string {
+	return fmt.Sprintf("%s: outflow %s mint %s per %d blocks", l.Denom, l.MaxOutflow, l.MaxMint, l.WindowBlocks)
+}
+
+// RateLimitCapacity is the use of a rate limit over its current window
+type RateLimitCapacity struct {
+	Denom        string  `json:"denom"`
+	Direction    string  `json:"direction"`
+	WindowBlocks uint64  `json:"window_blocks"`
+	Max          sdk.Int `json:"max"`
+	Used         sdk.Int `json:"used"`
+	// Remaining is the value that can still pass in the current block
+	Remaining sdk.Int `json:"remaining"`
+}
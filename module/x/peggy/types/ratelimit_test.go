@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRateLimits(t *testing.T) {
+	valid := RateLimit{Denom: "mytoken", WindowBlocks: 100, MaxOutflow: sdk.NewInt(10), MaxMint: sdk.ZeroInt()}
+	specs := map[string]struct {
+		src    []RateLimit
+		expErr bool
+	}{
+		"empty": {
+			src: []RateLimit{},
+		},
+		"valid": {
+			src: []RateLimit{valid},
+		},
+		"invalid denom": {
+			src:    []RateLimit{{Denom: "X", WindowBlocks: 100, MaxOutflow: sdk.NewInt(10), MaxMint: sdk.ZeroInt()}},
+			expErr: true,
+		},
+		"no window": {
+			src:    []RateLimit{{Denom: "mytoken", MaxOutflow: sdk.NewInt(10), MaxMint: sdk.ZeroInt()}},
+			expErr: true,
+		},
+		"negative cap": {
+			src:    []RateLimit{{Denom: "mytoken", WindowBlocks: 100, MaxOutflow: sdk.NewInt(-1), MaxMint: sdk.ZeroInt()}},
+			expErr: true,
+		},
+		"missing cap": {
+			src:    []RateLimit{{Denom: "mytoken", WindowBlocks: 100, MaxOutflow: sdk.NewInt(10)}},
+			expErr: true,
+		},
+		"duplicate denom": {
+			src:    []RateLimit{valid, valid},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := validateRateLimits(spec.src)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
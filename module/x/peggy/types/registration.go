@@ -0,0 +1,27 @@
+package types
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EthAddressRecord is an eth address a validator registered. The records of a validator are kept
+// after it registers another address, so that signatures made with an earlier key can still be
+// attributed to it.
+type EthAddressRecord struct {
+	EthAddress EthAddress `json:"eth_address"`
+	// Nonce is the registration nonce the address was registered with
+	Nonce uint64 `json:"nonce"`
+	// Height is the block height of the registration
+	Height int64 `json:"height"`
+}
+
+// EthAddressRegistrationHash returns the hash an eth key signs to be registered for a validator.
+// It is bound to the chain, the bridge by the PeggyID param and the registration nonce of the
+// validator, which is the number of its earlier registrations, so that a signature can neither be
+// replayed on another chain or bridge nor for a later registration.
+func EthAddressRegistrationHash(peggyID []byte, chainID string, validator sdk.AccAddress, nonce uint64) ([]byte, error) {
+	return encodeCheckpoint(ethAddressRegistrationArgs, peggyIDBytes32(peggyID), methodNameBytes32("ethAddressRegistration"),
+		chainID, validator.Bytes(), new(big.Int).SetUint64(nonce))
+}
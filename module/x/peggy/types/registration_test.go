@@ -0,0 +1,26 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthAddressRegistrationHash(t *testing.T) {
+	validator := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	hash := func(peggyID string, chainID string, validator sdk.AccAddress, nonce uint64) string {
+		bz, err := EthAddressRegistrationHash([]byte(peggyID), chainID, validator, nonce)
+		require.NoError(t, err)
+		return string(bz)
+	}
+	ref := hash("foo", "peggy-test", validator, 0)
+	assert.Len(t, ref, 32)
+	assert.Equal(t, ref, hash("foo", "peggy-test", validator, 0))
+	assert.NotEqual(t, ref, hash("bar", "peggy-test", validator, 0), "peggy id")
+	assert.NotEqual(t, ref, hash("foo", "peggy-other", validator, 0), "chain id")
+	assert.NotEqual(t, ref, hash("foo", "peggy-test", bytes.Repeat([]byte{2}, sdk.AddrLen), 0), "validator")
+	assert.NotEqual(t, ref, hash("foo", "peggy-test", validator, 1), "nonce")
+}
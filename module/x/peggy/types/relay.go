@@ -0,0 +1,182 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// peggyRelayAbiJSON is the subset of the Peggy contract ABI a relayer needs to submit batches
+const peggyRelayAbiJSON = `[{
+  "inputs": [
+    { "internalType": "address[]", "name": "_currentValidators", "type": "address[]" },
+    { "internalType": "uint256[]", "name": "_currentPowers", "type": "uint256[]" },
+    { "internalType": "uint256", "name": "_currentValsetNonce", "type": "uint256" },
+    { "internalType": "uint8[]", "name": "_v", "type": "uint8[]" },
+    { "internalType": "bytes32[]", "name": "_r", "type": "bytes32[]" },
+    { "internalType": "bytes32[]", "name": "_s", "type": "bytes32[]" },
+    { "internalType": "uint256[]", "name": "_amounts", "type": "uint256[]" },
+    { "internalType": "address[]", "name": "_destinations", "type": "address[]" },
+    { "internalType": "uint256[]", "name": "_fees", "type": "uint256[]" },
+    { "internalType": "uint256[]", "name": "_nonces", "type": "uint256[]" }
+  ],
+  "name": "submitBatch",
+  "outputs": [],
+  "stateMutability": "nonpayable",
+  "type": "function"
+}, {
+  "inputs": [
+    { "internalType": "address[]", "name": "_currentValidators", "type": "address[]" },
+    { "internalType": "uint256[]", "name": "_currentPowers", "type": "uint256[]" },
+    { "internalType": "uint256", "name": "_currentValsetNonce", "type": "uint256" },
+    { "internalType": "uint8[]", "name": "_v", "type": "uint8[]" },
+    { "internalType": "bytes32[]", "name": "_r", "type": "bytes32[]" },
+    { "internalType": "bytes32[]", "name": "_s", "type": "bytes32[]" },
+    { "internalType": "address[]", "name": "_newValidators", "type": "address[]" },
+    { "internalType": "uint256[]", "name": "_newPowers", "type": "uint256[]" },
+    { "internalType": "uint256", "name": "_newValsetNonce", "type": "uint256" },
+    { "internalType": "uint256[]", "name": "_amounts", "type": "uint256[]" },
+    { "internalType": "address[]", "name": "_destinations", "type": "address[]" },
+    { "internalType": "uint256[]", "name": "_fees", "type": "uint256[]" },
+    { "internalType": "uint256[]", "name": "_nonces", "type": "uint256[]" }
+  ],
+  "name": "updateValsetAndSubmitBatch",
+  "outputs": [],
+  "stateMutability": "nonpayable",
+  "type": "function"
+}]`
+
+// PeggyRelayABI is the parsed form of peggyRelayAbiJSON, it is parsed once at startup
+var PeggyRelayABI abi.ABI
+
+func init() {
+	var err error
+	// error case here should not occur outside of development since the above is a constant
+	PeggyRelayABI, err = abi.JSON(strings.NewReader(peggyRelayAbiJSON))
+	if err != nil {
+		panic(fmt.Sprintf("Bad ABI constant! %s", err))
+	}
+}
+
+// RelayBatchPayload holds everything a relayer needs to submit a batch to the Peggy contract.
+// The signature arrays are aligned with CurrentValidators, validators that have not signed
+// are represented by a zero V value which the contract skips over. NewValidators, NewPowers
+// and NewValsetNonce are only set for `updateValsetAndSubmitBatch` payloads. SignedPower is the
+// power of the current validators with a signature, the contract rejects the call unless Quorum.
+//
+// Calldata is the complete ABI encoded contract call including the method id, so a relayer
+// only has to put it into the data field of an Ethereum transaction to the Peggy contract.
+type RelayBatchPayload struct {
+	Method             string       `json:"method"`
+	CurrentValidators  []EthAddress `json:"current_validators"`
+	CurrentPowers      []int64      `json:"current_powers"`
+	CurrentValsetNonce int64        `json:"current_valset_nonce"`
+	SignedPower        int64        `json:"signed_power"`
+	Quorum             bool         `json:"quorum"`
+	V                  []uint8      `json:"v"`
+	R                  []string     `json:"r"`
+	S                  []string     `json:"s"`
+	NewValidators      []EthAddress `json:"new_validators,omitempty"`
+	NewPowers          []int64      `json:"new_powers,omitempty"`
+	NewValsetNonce     int64        `json:"new_valset_nonce,omitempty"`
+	Amounts            []sdk.Int    `json:"amounts"`
+	Destinations       []EthAddress `json:"destinations"`
+	Fees               []sdk.Int    `json:"fees"`
+	Nonces             []uint64     `json:"nonces"`
+	Calldata           string       `json:"calldata"`
+}
+
+// NewRelayBatchPayload assembles the arguments for `submitBatch`, or `updateValsetAndSubmitBatch`
+// when newValset is not nil. sigs maps the EIP-55 eth addresses of the current validator set to their
+// hex encoded signatures over the matching batch checkpoint.
+func NewRelayBatchPayload(batch OutgoingTxBatch, currentValset Valset, newValset *Valset, sigs map[string]string) (*RelayBatchPayload, error) {
+	p := RelayBatchPayload{
+		Method:             "submitBatch",
+		CurrentValidators:  currentValset.EthAddresses,
+		CurrentPowers:      currentValset.Powers,
+		CurrentValsetNonce: currentValset.Nonce,
+		V:                  make([]uint8, len(currentValset.EthAddresses)),
+		R:                  make([]string, len(currentValset.EthAddresses)),
+		S:                  make([]string, len(currentValset.EthAddresses)),
+		Amounts:            make([]sdk.Int, len(batch.Elements)),
+		Destinations:       make([]EthAddress, len(batch.Elements)),
+		Fees:               make([]sdk.Int, len(batch.Elements)),
+		Nonces:             make([]uint64, len(batch.Elements)),
+	}
+	if newValset != nil {
+		p.Method = "updateValsetAndSubmitBatch"
+		p.NewValidators = newValset.EthAddresses
+		p.NewPowers = newValset.Powers
+		p.NewValsetNonce = newValset.Nonce
+	}
+
+	r := make([][32]byte, len(currentValset.EthAddresses))
+	s := make([][32]byte, len(currentValset.EthAddresses))
+	for i, ethAddress := range currentValset.EthAddresses {
+		sig, ok := sigs[ethAddress.String()]
+		if !ok {
+			// a zero v tells the contract that there is no signature for this validator
+			p.R[i], p.S[i] = hexutil.Encode(r[i][:]), hexutil.Encode(s[i][:])
+			continue
+		}
+		sigBytes, err := hex.DecodeString(sig)
+		if err != nil || len(sigBytes) != 65 {
+			return nil, fmt.Errorf("invalid signature for %s", ethAddress)
+		}
+		copy(r[i][:], sigBytes[:32])
+		copy(s[i][:], sigBytes[32:64])
+		// go-ethereum produces 0/1 recovery ids, the contract expects the legacy 27/28 form
+		p.V[i] = sigBytes[64]
+		if p.V[i] < 27 {
+			p.V[i] += 27
+		}
+		p.R[i], p.S[i] = hexutil.Encode(r[i][:]), hexutil.Encode(s[i][:])
+		if i < len(currentValset.Powers) {
+			p.SignedPower += currentValset.Powers[i]
+		}
+	}
+	p.Quorum = HasQuorum(p.SignedPower)
+
+	for i, tx := range batch.Elements {
+		p.Amounts[i], p.Fees[i] = batch.ERC20Amounts(tx)
+		p.Destinations[i] = tx.DestAddress
+		p.Nonces[i] = tx.ID
+	}
+
+	currentAddresses, currentPowers, err := valsetArgs(currentValset)
+	if err != nil {
+		return nil, err
+	}
+	amounts, destinations, fees, nonces, err := batchTxArgs(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	var calldata []byte
+	if newValset == nil {
+		calldata, err = PeggyRelayABI.Pack(p.Method,
+			currentAddresses, currentPowers, big.NewInt(currentValset.Nonce),
+			p.V, r, s,
+			amounts, destinations, fees, nonces)
+	} else {
+		newAddresses, newPowers, valsetErr := valsetArgs(*newValset)
+		if valsetErr != nil {
+			return nil, valsetErr
+		}
+		calldata, err = PeggyRelayABI.Pack(p.Method,
+			currentAddresses, currentPowers, big.NewInt(currentValset.Nonce),
+			p.V, r, s,
+			newAddresses, newPowers, big.NewInt(newValset.Nonce),
+			amounts, destinations, fees, nonces)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("packing %s call: %s", p.Method, err)
+	}
+	p.Calldata = hexutil.Encode(calldata)
+	return &p, nil
+}
@@ -0,0 +1,28 @@
+package types
+
+import "time"
+
+// ValsetRequestRecord is when a valset was requested, it decides when the request and its confirms
+// can be pruned
+type ValsetRequestRecord struct {
+	Nonce  int64     `json:"nonce"`
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+}
+
+// ValsetRetention are the bounds of the valset requests and confirms that are kept. Requests older
+// than the last observed valset or than RetentionBlocks are pruned once their confirms are no
+// longer needed as slashing evidence, that is after the unbonding time passed since the request.
+// The latest and the last observed valset are never pruned.
+type ValsetRetention struct {
+	// OldestNonce is the nonce of the oldest valset request that is kept, zero when there is none
+	OldestNonce int64 `json:"oldest_nonce"`
+	// LatestNonce is the nonce of the latest valset request
+	LatestNonce int64 `json:"latest_nonce"`
+	// LastObservedNonce is the nonce of the last valset observed on Ethereum
+	LastObservedNonce int64 `json:"last_observed_nonce"`
+	// RetentionBlocks is the number of blocks unobserved requests are kept, zero for no limit
+	RetentionBlocks uint64 `json:"retention_blocks"`
+	// EvidencePeriod is the unbonding time confirms are kept for after their request
+	EvidencePeriod time.Duration `json:"evidence_period"`
+}
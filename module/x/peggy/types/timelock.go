@@ -0,0 +1,83 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// ProposalTypeTransferVeto is the gov proposal type that vetoes time locked transfers
+const ProposalTypeTransferVeto = "TransferVeto"
+
+// TimeLockThreshold is the amount of a denom from which on a transfer to Ethereum is time locked
+type TimeLockThreshold struct {
+	Denom     string  `json:"denom" yaml:"denom"`
+	Threshold sdk.Int `json:"threshold" yaml:"threshold"`
+}
+
+// ValidateBasic checks the denom and that the threshold is positive
+func (t TimeLockThreshold) ValidateBasic() error {
+	if err := sdk.ValidateDenom(t.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	if t.Threshold == (sdk.Int{}) || !t.Threshold.IsPositive() {
+		return sdkerrors.Wrapf(ErrInvalid, "time lock threshold of %s", t.Denom)
+	}
+	return nil
+}
+
+func (t TimeLockThreshold) String() string {
+	return fmt.Sprintf("%s%s", t.Threshold, t.Denom)
+}
+
+// TimeLockedTransfer is a large transfer to Ethereum that waits in the delay queue. It joins the
+// outgoing pool under a new pool ID at ReleaseHeight unless it is vetoed before, the sender is
+// refunded then.
+type TimeLockedTransfer struct {
+	// Transfer is the transfer, its ID is the ID in the delay queue
+	Transfer      OutgoingTx `json:"transfer"`
+	ReleaseHeight int64      `json:"release_height"`
+	// Height is the height of the send that consumed the outflow rate limit
+	Height int64 `json:"height"`
+}
+
+// TransferVetoProposal vetoes time locked transfers through governance, the ones that are no longer
+// in the delay queue when it passes are skipped
+type TransferVetoProposal struct {
+	Title       string   `json:"title" yaml:"title"`
+	Description string   `json:"description" yaml:"description"`
+	IDs         []uint64 `json:"ids" yaml:"ids"`
+}
+
+func NewTransferVetoProposal(title, description string, ids []uint64) govtypes.Content {
+	return TransferVetoProposal{Title: title, Description: description, IDs: ids}
+}
+
+var _ govtypes.Content = TransferVetoProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeTransferVeto)
+	govtypes.RegisterProposalTypeCodec(TransferVetoProposal{}, "peggy/TransferVetoProposal")
+}
+
+// nolint
+func (p TransferVetoProposal) GetTitle() string       { return p.Title }
+func (p TransferVetoProposal) GetDescription() string { return p.Description }
+func (p TransferVetoProposal) ProposalRoute() string  { return RouterKey }
+func (p TransferVetoProposal) ProposalType() string   { return ProposalTypeTransferVeto }
+func (p TransferVetoProposal) ValidateBasic() error {
+	if len(p.IDs) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "transfer ids")
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p TransferVetoProposal) String() string {
+	return fmt.Sprintf(`Transfer Veto Proposal:
+  Title:       %s
+  Description: %s
+  IDs:         %v
+`, p.Title, p.Description, p.IDs)
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// MaxERC20Decimals is the highest number of decimals an ERC20 can declare, it is an uint8
+const MaxERC20Decimals = 255
+
+// ERC20Metadata is the optional metadata of an ERC20
+type ERC20Metadata struct {
+	Name     string `json:"name" yaml:"name"`
+	Symbol   string `json:"symbol" yaml:"symbol"`
+	Decimals uint32 `json:"decimals" yaml:"decimals"`
+}
+
+// ValidateBasic checks that the name and symbol are set and the decimals fit an uint8
+func (m ERC20Metadata) ValidateBasic() error {
+	if m.Name == "" {
+		return sdkerrors.Wrap(ErrEmpty, "token name")
+	}
+	if m.Symbol == "" {
+		return sdkerrors.Wrap(ErrEmpty, "token symbol")
+	}
+	if m.Decimals > MaxERC20Decimals {
+		return sdkerrors.Wrapf(ErrInvalid, "token decimals %d", m.Decimals)
+	}
+	return nil
+}
+
+func (m ERC20Metadata) String() string {
+	return fmt.Sprintf("%s (%s, %d decimals)", m.Name, m.Symbol, m.Decimals)
+}
+
+// NativeToken is a Cosmos denom governance made bridgeable to Ethereum together with the metadata
+// the ERC20 representing it has to have
+type NativeToken struct {
+	Denom    string        `json:"denom" yaml:"denom"`
+	Metadata ERC20Metadata `json:"metadata" yaml:"metadata"`
+}
+
+// ValidateBasic checks the denom and the metadata
+func (t NativeToken) ValidateBasic() error {
+	if err := sdk.ValidateDenom(t.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	return t.Metadata.ValidateBasic()
+}
+
+func (t NativeToken) String() string {
+	return fmt.Sprintf("%s as %s", t.Denom, t.Metadata)
+}
+
+// CosmosERC20 is the ERC20 that represents a native Cosmos denom on Ethereum. Transfers of the
+// denom to Ethereum lock the coins in the module account, deposits of the ERC20 unlock them.
+type CosmosERC20 struct {
+	Denom         string        `json:"denom"`
+	TokenContract EthAddress    `json:"token_contract"`
+	Metadata      ERC20Metadata `json:"metadata"`
+	// Locked is the amount of the denom batched to Ethereum and not deposited back yet
+	Locked sdk.Int `json:"locked"`
+	// Height is the block height the mapping was recorded at
+	Height int64 `json:"height"`
+}
+
+// ERC20DeployedClaimHash returns the hash that identifies the deployment the message claims.
+// Claims of the same denom with a different contract or metadata are different deployments.
+func ERC20DeployedClaimHash(msg MsgERC20Deployed) []byte {
+	return tmhash.Sum(ModuleCdc.MustMarshalBinaryBare(MsgERC20Deployed{
+		Denom:         msg.Denom,
+		TokenContract: msg.TokenContract,
+		Metadata:      msg.Metadata,
+	}))
+}
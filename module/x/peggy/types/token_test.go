@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNativeTokens(t *testing.T) {
+	valid := NativeToken{Denom: "stake", Metadata: ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 6}}
+	specs := map[string]struct {
+		src    []NativeToken
+		expErr bool
+	}{
+		"empty": {
+			src: []NativeToken{},
+		},
+		"valid": {
+			src: []NativeToken{valid},
+		},
+		"no decimals": {
+			src: []NativeToken{{Denom: "stake", Metadata: ERC20Metadata{Name: "Stake", Symbol: "STK"}}},
+		},
+		"missing name": {
+			src:    []NativeToken{{Denom: "stake", Metadata: ERC20Metadata{Symbol: "STK", Decimals: 6}}},
+			expErr: true,
+		},
+		"missing symbol": {
+			src:    []NativeToken{{Denom: "stake", Metadata: ERC20Metadata{Name: "Stake", Decimals: 6}}},
+			expErr: true,
+		},
+		"decimals above uint8": {
+			src:    []NativeToken{{Denom: "stake", Metadata: ERC20Metadata{Name: "Stake", Symbol: "STK", Decimals: 256}}},
+			expErr: true,
+		},
+		"invalid denom": {
+			src:    []NativeToken{{Denom: "1x", Metadata: valid.Metadata}},
+			expErr: true,
+		},
+		"duplicate denom": {
+			src:    []NativeToken{valid, valid},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := validateNativeTokens(spec.src)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -1,121 +1,54 @@
 package types
 
 import (
-	"fmt"
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 type Valset struct {
 	Nonce        int64
 	Powers       []int64
-	EthAddresses []string
+	EthAddresses []EthAddress
 }
 
-func (v Valset) GetCheckpoint() []byte {
-	// TODO replace hardcoded "foo" here with a getter to retrieve the correct PeggyID from the store
-	// this will work for now because 'foo' is the test Peggy ID we are using
-	var peggyIDString = "foo"
+// ValidatorRegistration is the eth address registration status of a bonded validator. Only
+// registered validators are part of a valset, the power of the others is redistributed among them.
+type ValidatorRegistration struct {
+	Validator  sdk.ValAddress `json:"validator"`
+	EthAddress EthAddress     `json:"eth_address"`
+	Registered bool           `json:"registered"`
+	// Power is the consensus power of the validator
+	Power int64 `json:"power"`
+	// NormalizedPower is the share of the bonded power, normalized to PowerTotal
+	NormalizedPower int64 `json:"normalized_power"`
+	// PendingEthAddress is the address the validator rotates to with the next valset request
+	PendingEthAddress EthAddress `json:"pending_eth_address,omitempty"`
+}
 
-	// The go-ethereum ABI encoder *only* encodes function calls and then it only encodes
-	// function calls for which you provide an ABI json just like you would get out of the
-	// solidity compiler with your compiled contract.
-	// You are supposed to compile your contract, use abigen to generate an ABI , import
-	// this generated go module and then use for that for all testing and development.
-	// This abstraction layer is more trouble than it's worth, because we don't want to
-	// encode a function call at all, but instead we want to emulate a Solidity encode operation
-	// which has no equal available from go-ethereum.
-	//
-	// In order to work around this absurd series of problems we have to manually write the below
-	// 'function specification' that will encode the same arguments into a function call. We can then
-	// truncate the first several bytes where the call name is encoded to finally get the equal of the
-	// Solidity abi.Encode() call.
-	checkpointAbiJSON := `[{
-	  "inputs": [
-	    {
-	      "internalType": "bytes32",
-	      "name": "_peggyId",
-	      "type": "bytes32"
-	    },
-	    {
-	      "internalType": "bytes32",
-	      "name": "_checkpoint",
-	      "type": "bytes32"
-	    },
-	    {
-	      "internalType": "uint256",
-	      "name": "_valsetNonce",
-	      "type": "uint256"
-	    },
-	    {
-	      "internalType": "address[]",
-	      "name": "_validators",
-	      "type": "address[]"
-	    },
-	    {
-	      "internalType": "uint256[]",
-	      "name": "_powers",
-	      "type": "uint256[]"
-	    }
-	  ],
-	  "name": "checkpoint",
-	  "outputs": [
-	    {
-	      "internalType": "bytes32",
-	      "name": "",
-	      "type": "bytes32"
-	    }
-	  ],
-	  "stateMutability": "pure",
-	  "type": "function"
-	}]`
-	// error case here should not occur outside of testing since the above is a constant
-	contractAbi, abiErr := abi.JSON(strings.NewReader(checkpointAbiJSON))
-	if abiErr != nil {
-		panic("Bad ABI constant!")
-	}
-	peggyIDBytes := []uint8(peggyIDString)
+// peggyIDBytes32 returns the PeggyID param as the fixed length bytes32 the contract expects
+func peggyIDBytes32(peggyID []byte) [32]uint8 {
 	// the contract argument is not a arbitrary length array but a fixed length 32 byte
-	// array, therefore we have to utf8 encode the string (the default in this case) and
-	// then copy the variable length encoded data into a fixed length array. This function
-	// will panic if peggyId is too long to fit in 32 bytes
-	var peggyID [32]uint8
-	copy(peggyID[:], peggyIDBytes[:])
-	checkpointBytes := []uint8("checkpoint")
-	var checkpoint [32]uint8
-	copy(checkpoint[:], checkpointBytes[:])
+	// array, therefore we copy the variable length id into a fixed length array.
+	var id [32]uint8
+	copy(id[:], peggyID)
+	return id
+}
 
-	var convertedPowers []*big.Int
-	var convertedAddresses []common.Address
-	for _, power := range v.Powers {
-		convertedPowers = append(convertedPowers, big.NewInt(int64(power)))
-	}
-	for _, ethAddress := range v.EthAddresses {
-		if !strings.HasPrefix(ethAddress, "0x") {
-			panic(fmt.Sprintf("Eth Address in store %s does not have 0x prefix!", ethAddress))
-		}
-		convertedAddresses = append(convertedAddresses, common.HexToAddress(ethAddress))
-	}
-	// the word 'checkpoint' needs to be the same as the 'name' above in the checkpointAbiJson
-	// but other than that it's a constant that has no impact on the output. This is because
-	// it gets encoded as a function name which we must then discard.
-	bytes, packErr := contractAbi.Pack("checkpoint", peggyID, checkpoint, big.NewInt(int64(v.Nonce)), convertedAddresses, convertedPowers)
+// methodNameBytes32 returns the bytes32 encoding of a method name constant as used by the
+// contract to separate the different kinds of signed hashes from each other
+func methodNameBytes32(name string) [32]uint8 {
+	var methodName [32]uint8
+	copy(methodName[:], []uint8(name))
+	return methodName
+}
 
-	// this should never happen outside of test since any case that could crash on encoding
-	// should be filtered above.
-	if packErr != nil {
-		panic(fmt.Sprintf("Error packing checkpoint! %s/n", packErr))
+// GetCheckpoint returns the hash the validators sign to approve this validator set, it is the
+// same as the checkpoint the contract with the PeggyID computes in `makeCheckpoint`
+func (v Valset) GetCheckpoint(peggyID []byte) ([]byte, error) {
+	addresses, powers, err := valsetArgs(v)
+	if err != nil {
+		return nil, err
 	}
-
-	// we hash the resulting encoded bytes discarding the first 4 bytes these 4 bytes are the constant
-	// method name 'checkpoint'. If you where to replace the checkpoint constant in this code you would
-	// then need to adjust how many bytes you truncate off the front to get the output of abi.encode()
-	hash := crypto.Keccak256Hash(bytes[4:])
-
-	return hash.Bytes()
-
+	return encodeCheckpoint(valsetCheckpointArgs, peggyIDBytes32(peggyID), methodNameBytes32("checkpoint"), big.NewInt(v.Nonce), addresses, powers)
 }
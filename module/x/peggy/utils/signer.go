@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CheckpointKind is the kind of peggy checkpoint a signature is requested for
+type CheckpointKind string
+
+const (
+	CheckpointValset         CheckpointKind = "valset"
+	CheckpointBatch          CheckpointKind = "batch"
+	CheckpointValsetAndBatch CheckpointKind = "valset_and_batch"
+)
+
+// SignRequest asks for a signature over a checkpoint hash. Kind, Nonce and ValsetNonce identify the
+// checkpoint, a signer that protects against double signing refuses to sign two different hashes
+// for the same identity.
+type SignRequest struct {
+	Kind CheckpointKind `json:"kind"`
+	// Nonce is the valset nonce for valset checkpoints and the batch nonce otherwise
+	Nonce int64 `json:"nonce"`
+	// ValsetNonce is the nonce of the new valset of a valset_and_batch checkpoint
+	ValsetNonce int64  `json:"valset_nonce,omitempty"`
+	Hash        []byte `json:"hash"`
+}
+
+// ID identifies the checkpoint of the request independent of its hash
+func (r SignRequest) ID() string {
+	return fmt.Sprintf("%s/%d/%d", r.Kind, r.Nonce, r.ValsetNonce)
+}
+
+// Signer signs checkpoint hashes with the eth key of a validator. Signatures are 65 bytes in the
+// go-ethereum [R || S || V] format with V being 0 or 1.
+type Signer interface {
+	// Address is the eth address of the signing key
+	Address() common.Address
+	SignCheckpoint(req SignRequest) ([]byte, error)
+}
+
+var _ Signer = LocalSigner{}
+
+// LocalSigner signs with a key held in process
+type LocalSigner struct {
+	Key *ecdsa.PrivateKey
+}
+
+func (s LocalSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.Key.PublicKey)
+}
+
+func (s LocalSigner) SignCheckpoint(req SignRequest) ([]byte, error) {
+	return crypto.Sign(req.Hash, s.Key)
+}
@@ -3,10 +3,11 @@ package utils
 import (
 	"errors"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-func ValidateEthSig(hash []byte, signature []byte, ethAddress string) error {
+func ValidateEthSig(hash []byte, signature []byte, ethAddress common.Address) error {
 	// To verify signature
 	// - use crypto.SigToPub to get the public key
 	// - use crypto.PubkeyToAddress to get the address
@@ -21,6 +22,9 @@ func ValidateEthSig(hash []byte, signature []byte, ethAddress string) error {
 	//
 	// We could attempt to break or otherwise exit early on obviously invalid values for this
 	// byte, but that's a task best left to go-ethereum
+	if len(signature) != crypto.SignatureLength {
+		return errors.New("Signature has the wrong length")
+	}
 	if signature[64] == 27 || signature[64] == 28 {
 		signature[64] -= 27
 	}
@@ -32,7 +36,7 @@ func ValidateEthSig(hash []byte, signature []byte, ethAddress string) error {
 
 	addr := crypto.PubkeyToAddress(*pubkey)
 
-	if addr.Hex() != ethAddress {
+	if addr != ethAddress {
 		return errors.New("Signature is not valid")
 	}
 